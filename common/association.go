@@ -0,0 +1,74 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"errors"
+)
+
+var (
+	// ErrMissingAssociationEnd is returned when either side of an association is not identified.
+	ErrMissingAssociationEnd = errors.New("association requires object name and record ID on both sides")
+
+	// ErrMissingAssociationType is returned when the provider cannot infer how two objects are linked.
+	ErrMissingAssociationType = errors.New("association type is required for these objects")
+
+	// ErrUnsupportedAssociation is returned when the connector cannot link the two objects.
+	ErrUnsupportedAssociation = errors.New("association between these objects is not supported")
+)
+
+// AssociationType tells which kind of link connects two records, when objects can be linked in several ways.
+// The zero value stands for the provider default.
+type AssociationType struct {
+	// Id is the provider identifier of the link,
+	// ex: HubSpot association type ID, Salesforce lookup field, Attio attribute slug.
+	Id string
+	// Category qualifies Id where the provider needs it, ex: "HUBSPOT_DEFINED" or "USER_DEFINED" for HubSpot.
+	Category string
+	// Label is the human-readable name. Informational only.
+	Label string
+}
+
+func (t AssociationType) IsZero() bool {
+	return t.Id == "" && t.Category == ""
+}
+
+// AssociationDescriptor identifies a link between two records.
+type AssociationDescriptor struct {
+	FromObject string
+	FromId     string
+	ToObject   string
+	ToId       string
+	Type       AssociationType // optional
+}
+
+// ListAssociationsParams selects records of ToObject which are linked to a single record.
+type ListAssociationsParams struct {
+	FromObject string
+	FromId     string
+	ToObject   string
+	Type       AssociationType // optional
+	NextPage   NextPageToken   // optional
+}
+
+// ListAssociationsResult lists linked records in the same format as ReadResultRow.Associations.
+type ListAssociationsResult struct {
+	Associations []Association
+	NextPage     NextPageToken
+	Done         bool
+}
+
+func (d AssociationDescriptor) ValidateParams() error {
+	if d.FromObject == "" || d.FromId == "" || d.ToObject == "" || d.ToId == "" {
+		return ErrMissingAssociationEnd
+	}
+
+	return nil
+}
+
+func (p ListAssociationsParams) ValidateParams() error {
+	if p.FromObject == "" || p.FromId == "" || p.ToObject == "" {
+		return ErrMissingAssociationEnd
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedPayload is returned when an encoder doesn't know how to serialize the given payload.
+	ErrUnsupportedPayload = errors.New("payload type is not supported by encoder")
+
+	// ErrUnsupportedDecodeTarget is returned when a decoder doesn't know how to populate the given target.
+	ErrUnsupportedDecodeTarget = errors.New("decode target is not supported by decoder")
+
+	// ErrUnknownMediaType is returned when no decoder is registered for the response content type.
+	ErrUnknownMediaType = errors.New("no decoder for media type")
+)
+
+// Media types supported out of the box by ContentHTTPClient.
+const (
+	MediaTypeJSON      = "application/json"
+	MediaTypeXML       = "application/xml"
+	MediaTypeTextXML   = "text/xml"
+	MediaTypeCSV       = "text/csv"
+	MediaTypeNDJSON    = "application/x-ndjson"
+	MediaTypeForm      = "application/x-www-form-urlencoded"
+	MediaTypeMultipart = "multipart/form-data"
+)
+
+// Encoder serializes a payload into request body bytes.
+// The returned content type is used as the Content-Type header,
+// it may carry parameters such as the multipart boundary.
+type Encoder interface {
+	Encode(payload any) (body []byte, contentType string, err error)
+}
+
+// Decoder deserializes response body bytes into the target, which is usually a pointer.
+type Decoder interface {
+	// MediaTypes lists content types this decoder understands, the first one is used for the Accept header.
+	MediaTypes() []string
+	Decode(body []byte, target any) error
+}
+
+// Payload couples request data with the encoder that knows how to serialize it.
+type Payload struct {
+	Data    any
+	Encoder Encoder
+}
+
+// JSONPayload is a Payload sent as application/json.
+func JSONPayload(data any) Payload { return Payload{Data: data, Encoder: JSONCodec{}} }
+
+// XMLPayload is a Payload sent as application/xml.
+// Data may be *xquery.XML, XMLSchema or any value supported by encoding/xml.
+func XMLPayload(data any) Payload { return Payload{Data: data, Encoder: XMLCodec{}} }
+
+// CSVPayload is a Payload sent as text/csv.
+// Data may be raw []byte, [][]string or []map[string]any.
+func CSVPayload(data any) Payload { return Payload{Data: data, Encoder: CSVCodec{}} }
+
+// NDJSONPayload is a Payload sent as newline delimited JSON. Data must be a slice.
+func NDJSONPayload(data any) Payload { return Payload{Data: data, Encoder: NDJSONCodec{}} }
+
+// FormPayload is a Payload sent as application/x-www-form-urlencoded.
+// Data may be url.Values, map[string]string or map[string]any.
+func FormPayload(data any) Payload { return Payload{Data: data, Encoder: FormCodec{}} }
+
+// MultipartPayload is a Payload sent as multipart/form-data.
+func MultipartPayload(form MultipartForm) Payload {
+	return Payload{Data: form, Encoder: MultipartCodec{}}
+}
+
+// Decoders is a registry of decoders looked up by media type.
+type Decoders map[string]Decoder
+
+// NewDecoders creates a registry holding the given decoders.
+func NewDecoders(decoders ...Decoder) Decoders {
+	registry := make(Decoders)
+	registry.Register(decoders...)
+
+	return registry
+}
+
+// DefaultDecoders knows how to decode JSON, XML, CSV and NDJSON responses.
+func DefaultDecoders() Decoders {
+	return NewDecoders(JSONCodec{}, XMLCodec{}, CSVCodec{}, NDJSONCodec{})
+}
+
+// Register adds decoders, replacing any previously registered for the same media types.
+func (d Decoders) Register(decoders ...Decoder) {
+	for _, decoder := range decoders {
+		for _, mediaType := range decoder.MediaTypes() {
+			d[mediaType] = decoder
+		}
+	}
+}
+
+// Lookup finds a decoder for the Content-Type header value.
+// Vendor JSON types, ex: application/vnd.api+json, are matched to the JSON decoder.
+func (d Decoders) Lookup(contentType string) (Decoder, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnknownMediaType, err)
+	}
+
+	if decoder, ok := d[mediaType]; ok {
+		return decoder, nil
+	}
+
+	if strings.HasSuffix(mediaType, "+json") {
+		if decoder, ok := d[MediaTypeJSON]; ok {
+			return decoder, nil
+		}
+	}
+
+	if strings.HasSuffix(mediaType, "+xml") {
+		if decoder, ok := d[MediaTypeXML]; ok {
+			return decoder, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMediaType, mediaType)
+}
+
+// accept lists every registered media type, suitable for the Accept header.
+func (d Decoders) accept() string {
+	types := make([]string, 0, len(d))
+	for mediaType := range d {
+		types = append(types, mediaType)
+	}
+
+	slices.Sort(types)
+
+	return strings.Join(types, ", ")
+}
+
+// JSONCodec encodes and decodes application/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(payload any) ([]byte, string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("request body is not valid JSON, body is %v:\n%w", payload, err)
+	}
+
+	return data, MediaTypeJSON, nil
+}
+
+func (JSONCodec) MediaTypes() []string {
+	return []string{MediaTypeJSON}
+}
+
+func (JSONCodec) Decode(body []byte, target any) error {
+	if err := json.Unmarshal(body, target); err != nil {
+		return errors.Join(err, ErrFailedToUnmarshalBody)
+	}
+
+	return nil
+}
+
+// NDJSONCodec encodes and decodes newline delimited JSON, one value per line.
+// Bulk APIs use it to stream large result sets.
+type NDJSONCodec struct{}
+
+func (NDJSONCodec) Encode(payload any) ([]byte, string, error) {
+	value := reflect.ValueOf(payload)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, "", fmt.Errorf("%w: NDJSON expects a slice, got %T", ErrUnsupportedPayload, payload)
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	for index := range value.Len() {
+		// Encoder terminates every value with a newline.
+		if err := encoder.Encode(value.Index(index).Interface()); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return buffer.Bytes(), MediaTypeNDJSON, nil
+}
+
+func (NDJSONCodec) MediaTypes() []string {
+	return []string{MediaTypeNDJSON, "application/jsonl", "application/x-jsonlines"}
+}
+
+// Decode appends every line to the target, which must be a pointer to a slice.
+// Blank lines are skipped.
+func (NDJSONCodec) Decode(body []byte, target any) error {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Pointer || pointer.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: NDJSON expects pointer to slice, got %T", ErrUnsupportedDecodeTarget, target)
+	}
+
+	slice := pointer.Elem()
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(body)+1)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		item := reflect.New(slice.Type().Elem())
+		if err := json.Unmarshal(line, item.Interface()); err != nil {
+			return errors.Join(err, ErrFailedToUnmarshalBody)
+		}
+
+		slice = reflect.Append(slice, item.Elem())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	pointer.Elem().Set(slice)
+
+	return nil
+}
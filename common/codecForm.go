@@ -0,0 +1,252 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// FormCodec encodes application/x-www-form-urlencoded payloads.
+type FormCodec struct{}
+
+// Encode accepts url.Values, map[string]string or map[string]any.
+// For map[string]any nil values are skipped, slices become repeated keys
+// and every other value is formatted via fmt.
+func (FormCodec) Encode(payload any) ([]byte, string, error) {
+	values, err := toURLValues(payload)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return []byte(values.Encode()), MediaTypeForm, nil
+}
+
+func (FormCodec) MediaTypes() []string {
+	return []string{MediaTypeForm}
+}
+
+// Decode populates *url.Values.
+func (FormCodec) Decode(body []byte, target any) error {
+	output, ok := target.(*url.Values)
+	if !ok {
+		return fmt.Errorf("%w: form cannot decode into %T", ErrUnsupportedDecodeTarget, target)
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrParseError, err)
+	}
+
+	*output = values
+
+	return nil
+}
+
+// MultipartForm is a multipart/form-data payload made of plain fields and files.
+type MultipartForm struct {
+	// Fields are plain form values. Accepts the same types as FormCodec.
+	Fields any
+	// Files are attached after the fields, in the given order.
+	Files []MultipartFile
+}
+
+// MultipartFile is a single file part of a multipart/form-data payload.
+type MultipartFile struct {
+	// FieldName is the form field name, ex: "file".
+	FieldName string
+	// FileName is reported to the server via Content-Disposition.
+	// Optional, parts without a file name carry structured data, ex: JSON metadata.
+	FileName string
+	// ContentType of the file, defaults to application/octet-stream.
+	ContentType string
+	// Content is read until EOF.
+	Content io.Reader
+}
+
+// MultipartCodec encodes multipart/form-data payloads.
+// Fields are written in alphabetical order to keep the body deterministic.
+type MultipartCodec struct {
+	// Boundary is optional, a random boundary is used by default.
+	Boundary string
+}
+
+// Encode accepts MultipartForm, *MultipartForm or plain fields accepted by FormCodec.
+func (c MultipartCodec) Encode(payload any) ([]byte, string, error) {
+	var form MultipartForm
+
+	switch data := payload.(type) {
+	case MultipartForm:
+		form = data
+	case *MultipartForm:
+		form = *data
+	default:
+		form = MultipartForm{Fields: payload}
+	}
+
+	var buffer bytes.Buffer
+
+	contentType, err := c.Write(&buffer, form)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buffer.Bytes(), contentType, nil
+}
+
+// Write streams the multipart body into the writer and returns the Content-Type header value,
+// which includes the boundary. Files are copied without being buffered in memory.
+func (c MultipartCodec) Write(output io.Writer, form MultipartForm) (string, error) {
+	writer, err := c.newWriter(output)
+	if err != nil {
+		return "", err
+	}
+
+	if err = writeMultipartForm(writer, form); err != nil {
+		return "", err
+	}
+
+	return writer.FormDataContentType(), nil
+}
+
+// Reader returns the multipart body as a stream together with the Content-Type header value.
+// The body is produced lazily while the reader is consumed, so large files are never held in memory.
+// Pass the reader as the request body, the HTTP transport closes it once the request is done.
+// A reader that is not sent must be closed by the caller, which stops the goroutine producing the body.
+func (c MultipartCodec) Reader(form MultipartForm) (io.ReadCloser, string, error) {
+	pipeReader, pipeWriter := io.Pipe()
+
+	writer, err := c.newWriter(pipeWriter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	go func() {
+		// Reader side observes any failure as a read error.
+		pipeWriter.CloseWithError(writeMultipartForm(writer, form))
+	}()
+
+	return pipeReader, writer.FormDataContentType(), nil
+}
+
+func (c MultipartCodec) newWriter(output io.Writer) (*multipart.Writer, error) {
+	writer := multipart.NewWriter(output)
+
+	if c.Boundary != "" {
+		if err := writer.SetBoundary(c.Boundary); err != nil {
+			return nil, err
+		}
+	}
+
+	return writer, nil
+}
+
+func writeMultipartForm(writer *multipart.Writer, form MultipartForm) error {
+	if form.Fields != nil {
+		values, err := toURLValues(form.Fields)
+		if err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			for _, value := range values[key] {
+				if err = writer.WriteField(key, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, file := range form.Files {
+		if err := writeMultipartFile(writer, file); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+func writeMultipartFile(writer *multipart.Writer, file MultipartFile) error {
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := fmt.Sprintf(`form-data; name="%s"`, escapeQuotes(file.FieldName))
+	if file.FileName != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, escapeQuotes(file.FileName))
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", disposition)
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	if file.Content == nil {
+		return nil
+	}
+
+	_, err = io.Copy(part, file.Content)
+
+	return err
+}
+
+func toURLValues(payload any) (url.Values, error) {
+	switch data := payload.(type) {
+	case url.Values:
+		return data, nil
+	case map[string]string:
+		values := make(url.Values, len(data))
+		for key, value := range data {
+			values.Set(key, value)
+		}
+
+		return values, nil
+	case map[string]any:
+		values := make(url.Values, len(data))
+
+		for key, value := range data {
+			switch typed := value.(type) {
+			case nil:
+				continue
+			case string:
+				values.Add(key, typed)
+			case []string:
+				values[key] = append(values[key], typed...)
+			case []any:
+				for _, item := range typed {
+					values.Add(key, fmt.Sprintf("%v", item))
+				}
+			default:
+				values.Add(key, fmt.Sprintf("%v", typed))
+			}
+		}
+
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%w: %w, got %T", ErrUnsupportedPayload, ErrPayloadNotURLForm, payload)
+	}
+}
+
+// nolint:gochecknoglobals
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
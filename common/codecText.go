@@ -0,0 +1,170 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"slices"
+
+	"github.com/amp-labs/connectors/common/xquery"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// XMLCodec encodes and decodes application/xml and text/xml, which SOAP APIs use.
+type XMLCodec struct{}
+
+// Encode accepts *xquery.XML, XMLSchema (ex: *XMLData), raw []byte or any value supported by encoding/xml.
+func (XMLCodec) Encode(payload any) ([]byte, string, error) {
+	switch data := payload.(type) {
+	case *xquery.XML:
+		return []byte(data.RawXML()), MediaTypeXML, nil
+	case XMLSchema:
+		if err := data.Validate(); err != nil {
+			return nil, "", err
+		}
+
+		return []byte(data.String()), MediaTypeXML, nil
+	case []byte:
+		return data, MediaTypeXML, nil
+	default:
+		body, err := xml.Marshal(payload)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", ErrUnsupportedPayload, err)
+		}
+
+		return body, MediaTypeXML, nil
+	}
+}
+
+func (XMLCodec) MediaTypes() []string {
+	return []string{MediaTypeXML, MediaTypeTextXML}
+}
+
+// Decode populates **xquery.XML for querying, or any struct supported by encoding/xml.
+func (XMLCodec) Decode(body []byte, target any) error {
+	if node, ok := target.(**xquery.XML); ok {
+		parsed, err := xquery.NewXML(body)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrNotXML, err)
+		}
+
+		*node = parsed
+
+		return nil
+	}
+
+	if err := xml.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotXML, err)
+	}
+
+	return nil
+}
+
+// CSVCodec encodes and decodes text/csv, which bulk APIs use for uploads and downloads.
+type CSVCodec struct{}
+
+// Encode accepts raw []byte, rows as [][]string, or records as []map[string]any.
+// Records produce a header row with the union of all keys in alphabetical order.
+func (CSVCodec) Encode(payload any) ([]byte, string, error) {
+	var rows [][]string
+
+	switch data := payload.(type) {
+	case []byte:
+		return data, MediaTypeCSV, nil
+	case [][]string:
+		rows = data
+	case []map[string]any:
+		rows = recordsToRows(data)
+	default:
+		return nil, "", fmt.Errorf("%w: CSV cannot encode %T", ErrUnsupportedPayload, payload)
+	}
+
+	var buffer bytes.Buffer
+
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, "", err
+	}
+
+	return buffer.Bytes(), MediaTypeCSV, nil
+}
+
+func (CSVCodec) MediaTypes() []string {
+	return []string{MediaTypeCSV}
+}
+
+// Decode populates *[][]string with every row,
+// or *[]map[string]string using the first row as a header.
+func (CSVCodec) Decode(body []byte, target any) error {
+	rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrParseError, err)
+	}
+
+	switch output := target.(type) {
+	case *[][]string:
+		*output = rows
+
+		return nil
+	case *[]map[string]string:
+		*output = rowsToRecords(rows)
+
+		return nil
+	default:
+		return fmt.Errorf("%w: CSV cannot decode into %T", ErrUnsupportedDecodeTarget, target)
+	}
+}
+
+func recordsToRows(records []map[string]any) [][]string {
+	columns := datautils.NewStringSet()
+	for _, record := range records {
+		for key := range record {
+			columns.AddOne(key)
+		}
+	}
+
+	header := columns.List()
+	slices.Sort(header)
+
+	rows := make([][]string, 0, len(records)+1)
+	rows = append(rows, header)
+
+	for _, record := range records {
+		row := make([]string, len(header))
+
+		for index, column := range header {
+			if value, ok := record[column]; ok && value != nil {
+				row[index] = fmt.Sprintf("%v", value)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func rowsToRecords(rows [][]string) []map[string]string {
+	if len(rows) == 0 {
+		return []map[string]string{}
+	}
+
+	header := rows[0]
+	records := make([]map[string]string, 0, len(rows)-1)
+
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(header))
+
+		for index, column := range header {
+			if index < len(row) {
+				record[column] = row[index]
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
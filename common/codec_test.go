@@ -0,0 +1,302 @@
+// nolint:revive
+package common
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/amp-labs/connectors/common/xquery"
+	"github.com/go-test/deep"
+)
+
+func TestCodecEncode(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name                string
+		payload             Payload
+		expectedBody        string
+		expectedContentType string
+		expectedErr         error
+	}{
+		{
+			name:                "JSON object",
+			payload:             JSONPayload(map[string]any{"name": "Bob"}),
+			expectedBody:        `{"name":"Bob"}`,
+			expectedContentType: MediaTypeJSON,
+		},
+		{
+			name:                "NDJSON one value per line",
+			payload:             NDJSONPayload([]map[string]any{{"id": 1}, {"id": 2}}),
+			expectedBody:        "{\"id\":1}\n{\"id\":2}\n",
+			expectedContentType: MediaTypeNDJSON,
+		},
+		{
+			name:        "NDJSON rejects non slice",
+			payload:     NDJSONPayload(map[string]any{"id": 1}),
+			expectedErr: ErrUnsupportedPayload,
+		},
+		{
+			name: "CSV records produce sorted header",
+			payload: CSVPayload([]map[string]any{
+				{"name": "Bob", "age": 30},
+				{"name": "Alice", "city": "Paris"},
+			}),
+			expectedBody:        "age,city,name\n30,,Bob\n,Paris,Alice\n",
+			expectedContentType: MediaTypeCSV,
+		},
+		{
+			name:        "CSV rejects unknown payload",
+			payload:     CSVPayload("a,b"),
+			expectedErr: ErrUnsupportedPayload,
+		},
+		{
+			name:                "Form skips nil and repeats slices",
+			payload:             FormPayload(map[string]any{"tag": []any{"a", "b"}, "empty": nil, "n": 1}),
+			expectedBody:        "n=1&tag=a&tag=b",
+			expectedContentType: MediaTypeForm,
+		},
+		{
+			name:        "Form rejects struct",
+			payload:     FormPayload(struct{}{}),
+			expectedErr: ErrUnsupportedPayload,
+		},
+		{
+			name: "XML struct",
+			payload: XMLPayload(struct {
+				XMLName struct{} `xml:"note"`
+				To      string   `xml:"to"`
+			}{To: "Bob"}),
+			expectedBody:        "<note><to>Bob</to></note>",
+			expectedContentType: MediaTypeXML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, contentType, err := tt.payload.Encoder.Encode(tt.payload.Data)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(body) != tt.expectedBody {
+				t.Fatalf("expected body %q, got %q", tt.expectedBody, string(body))
+			}
+
+			if contentType != tt.expectedContentType {
+				t.Fatalf("expected content type %q, got %q", tt.expectedContentType, contentType)
+			}
+		})
+	}
+}
+
+func TestMultipartCodec(t *testing.T) {
+	t.Parallel()
+
+	body, contentType, err := MultipartCodec{}.Encode(MultipartForm{
+		Fields: map[string]string{"title": "Report"},
+		Files: []MultipartFile{{
+			FieldName:   "file",
+			FileName:    "report.csv",
+			ContentType: MediaTypeCSV,
+			Content:     strings.NewReader("a,b\n"),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != MediaTypeMultipart {
+		t.Fatalf("unexpected content type %q: %v", contentType, err)
+	}
+
+	form, err := multipart.NewReader(strings.NewReader(string(body)), params["boundary"]).ReadForm(1024)
+	if err != nil {
+		t.Fatalf("failed to read multipart body: %v", err)
+	}
+
+	if diff := deep.Equal(form.Value, map[string][]string{"title": {"Report"}}); diff != nil {
+		t.Fatalf("fields mismatch: %v", diff)
+	}
+
+	file, err := form.File["file"][0].Open()
+	if err != nil {
+		t.Fatalf("failed to open file part: %v", err)
+	}
+
+	content, _ := io.ReadAll(file)
+	if string(content) != "a,b\n" || form.File["file"][0].Filename != "report.csv" {
+		t.Fatalf("unexpected file part %q", string(content))
+	}
+}
+
+func TestDecodersLookup(t *testing.T) {
+	t.Parallel()
+
+	decoders := DefaultDecoders()
+
+	tests := []struct {
+		contentType string
+		expected    Decoder
+		expectedErr error
+	}{
+		{contentType: "application/json; charset=utf-8", expected: JSONCodec{}},
+		{contentType: "application/vnd.api+json", expected: JSONCodec{}},
+		{contentType: "text/xml", expected: XMLCodec{}},
+		{contentType: "application/soap+xml", expected: XMLCodec{}},
+		{contentType: "application/jsonl", expected: NDJSONCodec{}},
+		{contentType: "text/csv", expected: CSVCodec{}},
+		{contentType: "image/png", expectedErr: ErrUnknownMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			t.Parallel()
+
+			decoder, err := decoders.Lookup(tt.contentType)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+
+			if decoder != tt.expected {
+				t.Fatalf("expected decoder %T, got %T", tt.expected, decoder)
+			}
+		})
+	}
+}
+
+func TestContentHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		values, _ := url.ParseQuery(string(body))
+		if r.Header.Get("Content-Type") != MediaTypeForm || values.Get("name") != "Bob" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", MediaTypeCSV)
+		_, _ = w.Write([]byte("id,name\n1,Bob\n"))
+	}))
+	defer server.Close()
+
+	client := &ContentHTTPClient{
+		HTTPClient: &HTTPClient{
+			Client:       server.Client(),
+			ErrorHandler: InterpretError,
+		},
+	}
+
+	response, err := client.Post(t.Context(), server.URL, FormPayload(map[string]string{"name": "Bob"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var records []map[string]string
+	if err = response.Decode(&records); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+
+	if diff := deep.Equal(records, []map[string]string{{"id": "1", "name": "Bob"}}); diff != nil {
+		t.Fatalf("records mismatch: %v", diff)
+	}
+
+	_, err = client.Post(t.Context(), server.URL, JSONPayload(map[string]string{"name": "Bob"}))
+	if !errors.Is(err, ErrCaller) {
+		t.Fatalf("expected caller error, got %v", err)
+	}
+}
+
+func TestXMLHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get("Content-Type") != MediaTypeXML || !strings.Contains(string(body), "ping") {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", MediaTypeTextXML)
+		_, _ = w.Write([]byte("<pong/>"))
+	}))
+	defer server.Close()
+
+	client := &XMLHTTPClient{
+		HTTPClient: &HTTPClient{
+			Client:       server.Client(),
+			ErrorHandler: InterpretError,
+		},
+	}
+
+	node, err := xquery.NewXML([]byte("<ping/>"))
+	if err != nil {
+		t.Fatalf("failed to build XML: %v", err)
+	}
+
+	response, err := client.Post(t.Context(), server.URL, node)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(response.Body.RawXML(), "pong") {
+		t.Fatalf("unexpected response body: %v", response.Body.RawXML())
+	}
+}
+
+func TestPutCSV(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method != http.MethodPut || r.Header.Get("Content-Type") != MediaTypeCSV ||
+			string(body) != "id,name\n1,Bob\n" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &JSONHTTPClient{
+		HTTPClient: &HTTPClient{
+			Client:       server.Client(),
+			ErrorHandler: InterpretError,
+		},
+	}
+
+	body, err := client.PutCSV(t.Context(), server.URL, []byte("id,name\n1,Bob\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(body) != 0 {
+		t.Fatalf("expected empty response, got %s", body)
+	}
+}
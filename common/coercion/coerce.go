@@ -0,0 +1,77 @@
+// Package coercion normalizes ReadResultRow.Fields into canonical Go types
+// according to the FieldMetadata.ValueType of each field.
+//
+// Providers return dates in many string formats, numbers as strings
+// and booleans as "true". Typed reading converts them into time.Time (UTC),
+// int64/float64, bool and []string. Values that cannot be converted are left untouched
+// and reported via ReadResultRow.ConversionErrors, the page itself never fails.
+package coercion
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Schema is a lowercase index of field metadata, matching the keys of ReadResultRow.Fields.
+type Schema map[string]common.FieldMetadata
+
+// NewSchema indexes object metadata by lowercase field names.
+// Fields with unknown value type are skipped as there is nothing to convert them to.
+func NewSchema(metadata *common.ObjectMetadata) Schema {
+	schema := make(Schema)
+
+	if metadata == nil {
+		return schema
+	}
+
+	for name, field := range metadata.Fields {
+		if field.ValueType == "" || field.ValueType == common.ValueTypeOther {
+			continue
+		}
+
+		schema[strings.ToLower(name)] = field
+	}
+
+	return schema
+}
+
+// Result converts every row of the read result in place.
+func (s Schema) Result(result *common.ReadResult) {
+	if result == nil {
+		return
+	}
+
+	for index := range result.Data {
+		s.Row(&result.Data[index])
+	}
+}
+
+// Row converts field values of a single row in place.
+// Conversion failures are appended to row.ConversionErrors.
+func (s Schema) Row(row *common.ReadResultRow) {
+	for name, value := range row.Fields {
+		field, ok := s[name]
+		if !ok {
+			continue
+		}
+
+		converted, err := Value(field.ValueType, value)
+		if err != nil {
+			row.ConversionErrors = append(row.ConversionErrors, common.FieldConversionError{
+				Field:     name,
+				ValueType: field.ValueType,
+				Message:   err.Error(),
+			})
+
+			continue
+		}
+
+		row.Fields[name] = converted
+	}
+
+	slices.SortFunc(row.ConversionErrors, func(a, b common.FieldConversionError) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+}
@@ -0,0 +1,170 @@
+// nolint:revive,godoclint
+package coercion
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/go-test/deep"
+)
+
+func TestValue(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		valueType common.ValueType
+		input     any
+		expected  any
+		wantErr   error
+	}{
+		{"Nil stays nil", common.ValueTypeInt, nil, nil, nil},
+		{"Other type untouched", common.ValueTypeOther, map[string]any{"a": 1}, map[string]any{"a": 1}, nil},
+		{"Number to string", common.ValueTypeString, 12.5, "12.5", nil},
+		{"Object is not a string", common.ValueTypeString, map[string]any{}, nil, ErrUnsupportedValue},
+		{"Boolean string", common.ValueTypeBoolean, "TRUE", true, nil},
+		{"Boolean number", common.ValueTypeBoolean, float64(0), false, nil},
+		{"Bad boolean", common.ValueTypeBoolean, "maybe", nil, ErrUnsupportedValue},
+		{"Currency string to float", common.ValueTypeFloat, " 1234.50 ", 1234.5, nil},
+		{"Bad float", common.ValueTypeFloat, "12abc", nil, ErrUnsupportedValue},
+		{"JSON number to int", common.ValueTypeInt, float64(42), int64(42), nil},
+		{"String to int", common.ValueTypeInt, "42", int64(42), nil},
+		{"Fraction is not int", common.ValueTypeInt, 4.2, nil, ErrUnsupportedValue},
+		{"Int overflow", common.ValueTypeInt, float64(1 << 63), nil, ErrUnsupportedValue},
+		{"Largest float below overflow", common.ValueTypeInt, math.Nextafter(1<<63, 0), int64(1<<63 - 1024), nil},
+		{"Smallest int", common.ValueTypeInt, float64(math.MinInt64), int64(math.MinInt64), nil},
+		{
+			"RFC3339 with offset", common.ValueTypeDateTime, "2024-03-05T10:00:00+02:00",
+			time.Date(2024, 3, 5, 8, 0, 0, 0, time.UTC), nil,
+		},
+		{
+			"Salesforce datetime", common.ValueTypeDateTime, "2024-03-05T10:00:00.000+0000",
+			time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), nil,
+		},
+		{
+			"Space separated", common.ValueTypeDateTime, "2024-03-05 10:00:00",
+			time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), nil,
+		},
+		{
+			"Date only", common.ValueTypeDate, "2024-03-05",
+			time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), nil,
+		},
+		{
+			"Epoch milliseconds string", common.ValueTypeDateTime, "1709632800000",
+			time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), nil,
+		},
+		{
+			"Epoch seconds", common.ValueTypeDateTime, float64(1709632800),
+			time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC), nil,
+		},
+		{"Unknown date", common.ValueTypeDate, "yesterday", nil, ErrUnknownTimeFormat},
+		{"Single select number", common.ValueTypeSingleSelect, float64(3), "3", nil},
+		{"Multi select string", common.ValueTypeMultiSelect, "red; green;blue", []string{"red", "green", "blue"}, nil},
+		{"Multi select array", common.ValueTypeMultiSelect, []any{"red", "green"}, []string{"red", "green"}, nil},
+		{"Multi select empty", common.ValueTypeMultiSelect, "", []string{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := Value(tt.valueType, tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if diff := deep.Equal(output, tt.expected); diff != nil {
+				t.Fatalf("unexpected output: %v", diff)
+			}
+		})
+	}
+}
+
+type fakeConnector struct {
+	metadataCalls int
+}
+
+func (f *fakeConnector) Read(context.Context, common.ReadParams) (*common.ReadResult, error) {
+	return &common.ReadResult{
+		Rows: 1,
+		Data: []common.ReadResultRow{{
+			Fields: map[string]any{
+				"amount":    "99.90",
+				"iswon":     "true",
+				"closedate": "not a date",
+				"notes":     "12",
+				"unknown":   "1",
+			},
+		}},
+		Done: true,
+	}, nil
+}
+
+func (f *fakeConnector) ListObjectMetadata(
+	_ context.Context, objectNames []string,
+) (*common.ListObjectMetadataResult, error) {
+	f.metadataCalls++
+
+	result := common.NewListObjectMetadataResult()
+	result.Result[objectNames[0]] = *common.NewObjectMetadata("Deals", common.FieldsMetadata{
+		"Amount":    {ValueType: common.ValueTypeFloat},
+		"IsWon":     {ValueType: common.ValueTypeBoolean},
+		"CloseDate": {ValueType: common.ValueTypeDate},
+		"Notes":     {ValueType: common.ValueTypeOther},
+	})
+
+	return result, nil
+}
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeConnector{}
+	reader := NewReader(conn, 0)
+
+	var (
+		result *common.ReadResult
+		err    error
+	)
+
+	for range 2 {
+		result, err = reader.Read(t.Context(), common.ReadParams{ObjectName: "deals"})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	if conn.metadataCalls != 1 {
+		t.Fatalf("metadata should be cached, fetched %v times", conn.metadataCalls)
+	}
+
+	row := result.Data[0]
+
+	expectedFields := map[string]any{
+		"amount":    99.9,
+		"iswon":     true,
+		"closedate": "not a date",
+		"notes":     "12",
+		"unknown":   "1",
+	}
+
+	if diff := deep.Equal(row.Fields, expectedFields); diff != nil {
+		t.Fatalf("unexpected fields: %v", diff)
+	}
+
+	if len(row.ConversionErrors) != 1 || row.ConversionErrors[0].Field != "closedate" {
+		t.Fatalf("unexpected conversion errors: %+v", row.ConversionErrors)
+	}
+}
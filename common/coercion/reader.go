@@ -0,0 +1,56 @@
+package coercion
+
+import (
+	"context"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/metadatacache"
+)
+
+// Connector is a connector capable of both reading and describing objects.
+type Connector interface {
+	Read(ctx context.Context, params common.ReadParams) (*common.ReadResult, error)
+	ListObjectMetadata(ctx context.Context, objectNames []string) (*common.ListObjectMetadataResult, error)
+}
+
+// Reader enables typed reading on top of any connector.
+// Object metadata is fetched once per object and reused until the TTL expires.
+type Reader struct {
+	Connector
+
+	metadata *metadatacache.Cache
+}
+
+// NewReader wraps the connector. Zero TTL keeps metadata for the lifetime of the Reader.
+func NewReader(conn Connector, ttl time.Duration) *Reader {
+	return &Reader{
+		Connector: conn,
+		metadata:  metadatacache.New(conn, ttl),
+	}
+}
+
+// Read delegates to the underlying connector and converts field values of every row
+// according to the object metadata. Failing to obtain metadata fails the Read,
+// while failing to convert an individual value is reported on the row.
+func (r *Reader) Read(ctx context.Context, params common.ReadParams) (*common.ReadResult, error) {
+	metadata, err := r.metadata.Get(ctx, params.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.Connector.Read(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	NewSchema(metadata).Result(result)
+
+	return result, nil
+}
+
+// Invalidate forgets cached metadata, forcing it to be fetched on the next Read.
+// Useful when a schema change is detected mid sync.
+func (r *Reader) Invalidate(objectNames ...string) {
+	r.metadata.Invalidate(objectNames...)
+}
@@ -0,0 +1,256 @@
+package coercion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+var (
+	// ErrUnsupportedValue is returned when a value is of a shape that cannot represent the ValueType.
+	ErrUnsupportedValue = errors.New("value cannot be converted")
+	// ErrUnknownTimeFormat is returned when a string doesn't match any of the known date/time layouts.
+	ErrUnknownTimeFormat = errors.New("unknown date/time format")
+)
+
+// MultiSelectSeparator is used by providers (ex: Salesforce, HubSpot) which encode
+// multi select values as a single string.
+const MultiSelectSeparator = ";"
+
+// Epoch numbers above this value are treated as milliseconds rather than seconds.
+// It corresponds to the year 5138 in seconds, far beyond any realistic date.
+const epochMillisecondsThreshold = 1e11
+
+// timeLayouts are tried in order when parsing date and datetime strings.
+// nolint:gochecknoglobals
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05 MST",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	"01/02/2006 15:04:05",
+	"01/02/2006",
+}
+
+// Value converts a single field value to the canonical Go type for the ValueType:
+//
+//	string                  -> string
+//	boolean                 -> bool
+//	int                     -> int64
+//	float                   -> float64
+//	date, datetime          -> time.Time in UTC
+//	singleSelect            -> string
+//	multiSelect             -> []string
+//
+// Nil values and values of unknown or "other" type are returned unchanged.
+func Value(valueType common.ValueType, value any) (any, error) {
+	if value == nil {
+		return nil, nil // nolint:nilnil
+	}
+
+	switch valueType {
+	case common.ValueTypeString, common.ValueTypeSingleSelect:
+		return toString(value)
+	case common.ValueTypeBoolean:
+		return toBool(value)
+	case common.ValueTypeInt:
+		return toInt(value)
+	case common.ValueTypeFloat:
+		return toFloat(value)
+	case common.ValueTypeDate, common.ValueTypeDateTime:
+		return toTime(value)
+	case common.ValueTypeMultiSelect:
+		return toStrings(value)
+	default:
+		return value, nil
+	}
+}
+
+func toString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case int, int32, int64:
+		return fmt.Sprintf("%d", v), nil
+	default:
+		return "", unsupported(value)
+	}
+}
+
+func toBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "yes", "1", "y", "on":
+			return true, nil
+		case "false", "no", "0", "n", "off":
+			return false, nil
+		}
+	case float64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+	case int64:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+	case int:
+		if v == 0 || v == 1 {
+			return v == 1, nil
+		}
+	}
+
+	return false, unsupported(value)
+}
+
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", ErrUnsupportedValue, err)
+		}
+
+		return number, nil
+	default:
+		return 0, unsupported(value)
+	}
+}
+
+func toInt(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case string:
+		if number, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			return number, nil
+		}
+	}
+
+	number, err := toFloat(value)
+	if err != nil {
+		return 0, err
+	}
+
+	// The float nearest to MaxInt64 is 2^63, which is already out of range, while MinInt64 is exact.
+	if number != math.Trunc(number) || math.IsInf(number, 0) ||
+		number >= 1<<63 || number < math.MinInt64 {
+		return 0, fmt.Errorf("%w: %v is not an integer", ErrUnsupportedValue, value)
+	}
+
+	return int64(number), nil
+}
+
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC(), nil
+	case string:
+		return parseTime(strings.TrimSpace(v))
+	case float64, int, int64, json.Number:
+		seconds, err := toFloat(v)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return fromEpoch(seconds), nil
+	default:
+		return time.Time{}, unsupported(value)
+	}
+}
+
+func parseTime(text string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+
+	// Some providers send epoch timestamps as strings.
+	if number, err := strconv.ParseFloat(text, 64); err == nil {
+		return fromEpoch(number), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %q", ErrUnknownTimeFormat, text)
+}
+
+func fromEpoch(number float64) time.Time {
+	if math.Abs(number) > epochMillisecondsThreshold {
+		return time.UnixMilli(int64(number)).UTC()
+	}
+
+	seconds, fraction := math.Modf(number)
+
+	return time.Unix(int64(seconds), int64(fraction*float64(time.Second))).UTC()
+}
+
+func toStrings(value any) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case string:
+		if v == "" {
+			return []string{}, nil
+		}
+
+		parts := strings.Split(v, MultiSelectSeparator)
+		for index, part := range parts {
+			parts[index] = strings.TrimSpace(part)
+		}
+
+		return parts, nil
+	case []any:
+		result := make([]string, len(v))
+
+		for index, item := range v {
+			text, err := toString(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result[index] = text
+		}
+
+		return result, nil
+	default:
+		return nil, unsupported(value)
+	}
+}
+
+func unsupported(value any) error {
+	return fmt.Errorf("%w: unexpected %T", ErrUnsupportedValue, value)
+}
@@ -0,0 +1,78 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMissingCompositeNodes is returned when a composite write has nothing to write.
+	ErrMissingCompositeNodes = errors.New("no nodes provided in CompositeWriteParams")
+
+	// ErrMissingReferenceID is returned when a composite write node cannot be referenced.
+	ErrMissingReferenceID = errors.New("no reference ID provided")
+
+	// ErrDuplicateReferenceID is returned when two composite write nodes share a reference ID.
+	ErrDuplicateReferenceID = errors.New("reference ID is not unique")
+
+	// ErrUnresolvedReference is returned when a placeholder refers to a node which is not written before it.
+	ErrUnresolvedReference = errors.New("reference doesn't match any preceding node")
+)
+
+// CompositeWriteParams describes several writes which succeed or fail together.
+// Nodes refer to the outcome of preceding nodes with placeholders inside RecordData,
+// using the provider syntax, ex: "@{refAccount.id}" for Salesforce.
+type CompositeWriteParams struct {
+	// Nodes are written in order.
+	Nodes []CompositeWriteNode
+	// Headers contains additional headers to be added to the request.
+	Headers []WriteHeader // optional
+}
+
+// CompositeWriteNode is a single write of a composite write.
+type CompositeWriteNode struct {
+	// ReferenceId names the node for placeholders of other nodes and keys its result.
+	ReferenceId string // nolint:revive
+	// Params describe the write. Record is updated when RecordId is set, otherwise created.
+	// Params.Headers are ignored in favour of CompositeWriteParams.Headers.
+	Params WriteParams
+}
+
+// CompositeWriteResult is the outcome of a composite write.
+type CompositeWriteResult struct {
+	// Success is true when all nodes were written. Otherwise, nothing was written.
+	Success bool
+	// Results holds the outcome of every node keyed by reference ID.
+	// When the write fails, nodes which were valid report ErrBatchUnprocessedRecord.
+	Results map[string]*WriteResult
+	// Errors lists why the write failed: errors of the nodes which were rejected,
+	// followed by failures not tied to any node.
+	Errors []any
+}
+
+func (p CompositeWriteParams) ValidateParams() error {
+	if len(p.Nodes) == 0 {
+		return ErrMissingCompositeNodes
+	}
+
+	seen := make(map[string]bool, len(p.Nodes))
+
+	for index, node := range p.Nodes {
+		if node.ReferenceId == "" {
+			return fmt.Errorf("node %d: %w", index, ErrMissingReferenceID)
+		}
+
+		if seen[node.ReferenceId] {
+			return fmt.Errorf("%w: %s", ErrDuplicateReferenceID, node.ReferenceId)
+		}
+
+		seen[node.ReferenceId] = true
+
+		if err := node.Params.ValidateParams(); err != nil {
+			return fmt.Errorf("node %s: %w", node.ReferenceId, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"context"
+	"net/http"
+)
+
+// ContentClient is an HTTP client that negotiates request and response formats via codecs.
+// Payload encoders decide how the request body is serialized, while the response body
+// is decoded according to its Content-Type.
+type ContentClient interface {
+	Get(ctx context.Context, url string, headers ...Header) (*ContentHTTPResponse, error)
+	Post(ctx context.Context, url string, payload Payload, headers ...Header) (*ContentHTTPResponse, error)
+	Put(ctx context.Context, url string, payload Payload, headers ...Header) (*ContentHTTPResponse, error)
+	Patch(ctx context.Context, url string, payload Payload, headers ...Header) (*ContentHTTPResponse, error)
+	Delete(ctx context.Context, url string, headers ...Header) (*ContentHTTPResponse, error)
+}
+
+var _ ContentClient = (*ContentHTTPClient)(nil)
+
+// ContentHTTPClient implements ContentClient on top of HTTPClient.
+// It shares authentication, error handling and logging with JSONHTTPClient and XMLHTTPClient,
+// but isn't limited to a single format. Supported out of the box:
+// JSON, XML, CSV, NDJSON, url-encoded form and multipart form.
+// Custom formats are added by implementing Encoder and Decoder.
+type ContentHTTPClient struct {
+	HTTPClient         *HTTPClient        // underlying HTTP client. Required.
+	ErrorPostProcessor ErrorPostProcessor // Errors returned from CRUD methods will go via this method. Optional.
+	// Decoders is used to parse responses and to build the Accept header.
+	// Optional, defaults to DefaultDecoders.
+	Decoders Decoders
+}
+
+// ContentHTTPResponse is a response of any format.
+// Use Decode to parse the body according to its Content-Type.
+type ContentHTTPResponse struct {
+	// bodyBytes is the raw response body.
+	bodyBytes []byte
+
+	decoders Decoders
+
+	// Code is the HTTP status code of the response.
+	Code int
+
+	// Headers are the HTTP headers of the response.
+	Headers http.Header
+}
+
+// Bytes returns the raw response body.
+func (r *ContentHTTPResponse) Bytes() []byte {
+	return r.bodyBytes
+}
+
+// IsEmpty returns true if the response has no body.
+func (r *ContentHTTPResponse) IsEmpty() bool {
+	return len(r.bodyBytes) == 0
+}
+
+// ContentType returns the Content-Type header value.
+func (r *ContentHTTPResponse) ContentType() string {
+	return r.Headers.Get("Content-Type")
+}
+
+// Decode parses the body into target using the decoder registered for the response Content-Type.
+// Responses without a Content-Type are treated as JSON. Empty body leaves the target untouched.
+func (r *ContentHTTPResponse) Decode(target any) error {
+	if r.IsEmpty() {
+		return nil
+	}
+
+	contentType := r.ContentType()
+	if contentType == "" {
+		contentType = MediaTypeJSON
+	}
+
+	decoder, err := r.decoders.Lookup(contentType)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(r.bodyBytes, target)
+}
+
+// DecodeWith parses the body using the given decoder, ignoring the response Content-Type.
+// Useful for providers that mislabel responses, ex: CSV served as text/plain.
+func (r *ContentHTTPResponse) DecodeWith(decoder Decoder, target any) error {
+	if r.IsEmpty() {
+		return nil
+	}
+
+	return decoder.Decode(r.bodyBytes, target)
+}
+
+func (c *ContentHTTPClient) Get(ctx context.Context, url string, headers ...Header) (*ContentHTTPResponse, error) {
+	return c.send(ctx, http.MethodGet, url, nil, headers)
+}
+
+func (c *ContentHTTPClient) Post(ctx context.Context,
+	url string, payload Payload, headers ...Header,
+) (*ContentHTTPResponse, error) {
+	return c.send(ctx, http.MethodPost, url, &payload, headers)
+}
+
+func (c *ContentHTTPClient) Put(ctx context.Context,
+	url string, payload Payload, headers ...Header,
+) (*ContentHTTPResponse, error) {
+	return c.send(ctx, http.MethodPut, url, &payload, headers)
+}
+
+func (c *ContentHTTPClient) Patch(ctx context.Context,
+	url string, payload Payload, headers ...Header,
+) (*ContentHTTPResponse, error) {
+	return c.send(ctx, http.MethodPatch, url, &payload, headers)
+}
+
+func (c *ContentHTTPClient) Delete(ctx context.Context, url string, headers ...Header) (*ContentHTTPResponse, error) {
+	return c.send(ctx, http.MethodDelete, url, nil, headers)
+}
+
+func (c *ContentHTTPClient) send(ctx context.Context,
+	method, url string, payload *Payload, headers Headers,
+) (*ContentHTTPResponse, error) {
+	var body []byte
+
+	if payload != nil {
+		encoder := payload.Encoder
+		if encoder == nil {
+			encoder = JSONCodec{}
+		}
+
+		data, contentType, err := encoder.Encode(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		body = data
+
+		// Caller supplied headers take precedence over the defaults.
+		if !headers.HasKey("Content-Type") {
+			headers = append(headers, Header{Key: "Content-Type", Value: contentType})
+		}
+	}
+
+	decoders := c.decoders()
+
+	if !headers.HasKey("Accept") {
+		headers = append(headers, Header{Key: "Accept", Value: decoders.accept()})
+	}
+
+	res, resBody, err := c.HTTPClient.Send(ctx, method, url, body, headers...) //nolint:bodyclose
+	if err != nil {
+		return nil, c.ErrorPostProcessor.handleError(err)
+	}
+
+	return &ContentHTTPResponse{
+		bodyBytes: resBody,
+		decoders:  decoders,
+		Code:      res.StatusCode,
+		Headers:   res.Header,
+	}, nil
+}
+
+func (c *ContentHTTPClient) decoders() Decoders {
+	if c.Decoders == nil {
+		return DefaultDecoders()
+	}
+
+	return c.Decoders
+}
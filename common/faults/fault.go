@@ -0,0 +1,164 @@
+// Package faults describes failures injected into mock servers and mock connectors.
+// A Profile decides which call fails and how. Profiles are deterministic for a given sequence of calls,
+// so that retry and resume logic can be tested reliably in unit tests.
+package faults
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Kind is the type of failure.
+type Kind string
+
+const (
+	// KindLatency delays the response, the call then proceeds normally.
+	KindLatency Kind = "latency"
+	// KindRateLimit responds with 429 Too Many Requests and the Retry-After header.
+	KindRateLimit Kind = "rateLimit"
+	// KindServerError responds with a 5xx status.
+	KindServerError Kind = "serverError"
+	// KindTruncatedBody sends only part of the response body, the connection is closed mid-response.
+	KindTruncatedBody Kind = "truncatedBody"
+	// KindCursorExpired rejects a pagination cursor, the read must restart.
+	// It responds with 400 Bad Request, the way most providers reject a cursor they no longer know.
+	KindCursorExpired Kind = "cursorExpired"
+	// KindTokenExpired rejects the access token, the client must refresh it.
+	KindTokenExpired Kind = "tokenExpired"
+)
+
+// Fault is a failure of a single call. The zero value is no failure, see Pass.
+type Fault struct {
+	Kind Kind
+	// Delay is the added latency, for KindLatency.
+	Delay time.Duration
+	// RetryAfter is sent in whole seconds with KindRateLimit.
+	RetryAfter time.Duration
+	// Status overrides the default HTTP status of the fault.
+	Status int
+	// Body overrides the default response body, ex: to mimic the provider's error format.
+	Body []byte
+}
+
+// Pass is a step of a Script that lets the call through.
+func Pass() Fault {
+	return Fault{}
+}
+
+func Latency(delay time.Duration) Fault {
+	return Fault{Kind: KindLatency, Delay: delay}
+}
+
+func RateLimit(retryAfter time.Duration) Fault {
+	return Fault{Kind: KindRateLimit, RetryAfter: retryAfter}
+}
+
+// ServerError fails with the status, 503 Service Unavailable if zero.
+func ServerError(status int) Fault {
+	return Fault{Kind: KindServerError, Status: status}
+}
+
+func TruncatedBody() Fault {
+	return Fault{Kind: KindTruncatedBody}
+}
+
+func CursorExpired() Fault {
+	return Fault{Kind: KindCursorExpired}
+}
+
+func TokenExpired() Fault {
+	return Fault{Kind: KindTokenExpired}
+}
+
+// IsZero reports whether the fault lets the call through.
+func (f Fault) IsZero() bool {
+	return f.Kind == ""
+}
+
+// StatusCode is the HTTP status of the failed response, zero if the fault doesn't change the status.
+func (f Fault) StatusCode() int {
+	if f.Status != 0 {
+		return f.Status
+	}
+
+	switch f.Kind {
+	case KindRateLimit:
+		return http.StatusTooManyRequests
+	case KindServerError:
+		return http.StatusServiceUnavailable
+	case KindCursorExpired:
+		return http.StatusBadRequest
+	case KindTokenExpired:
+		return http.StatusUnauthorized
+	case KindLatency, KindTruncatedBody:
+		return 0
+	default:
+		return 0
+	}
+}
+
+// ResponseBody is the body of the failed response.
+func (f Fault) ResponseBody() []byte {
+	if f.Body != nil {
+		return f.Body
+	}
+
+	var message string
+
+	switch f.Kind {
+	case KindRateLimit:
+		message = "rate limit exceeded"
+	case KindServerError:
+		message = "service unavailable"
+	case KindCursorExpired:
+		message = "cursor expired"
+	case KindTokenExpired:
+		message = "access token expired"
+	case KindLatency, KindTruncatedBody:
+		return nil
+	}
+
+	return []byte(fmt.Sprintf(`{"error":{"message":%q}}`, message))
+}
+
+// Headers are sent with the failed response.
+func (f Fault) Headers() common.Headers {
+	if f.Kind != KindRateLimit {
+		return nil
+	}
+
+	return common.Headers{{
+		Key:   "Retry-After",
+		Value: strconv.Itoa(int(f.RetryAfter.Seconds())),
+	}}
+}
+
+// Err is the error an in-process connector returns for the fault, the same a provider connector would report.
+// Returns nil for latency.
+func (f Fault) Err() error {
+	var err error
+
+	switch f.Kind {
+	case KindRateLimit:
+		err = common.ErrLimitExceeded
+	case KindServerError:
+		err = common.ErrServer
+	case KindCursorExpired:
+		err = common.ErrCursorGone
+	case KindTokenExpired:
+		err = common.ErrAccessToken
+	case KindTruncatedBody:
+		return fmt.Errorf("%w: response body is truncated", io.ErrUnexpectedEOF)
+	case KindLatency:
+		return nil
+	default:
+		return nil
+	}
+
+	return common.NewHTTPError(f.StatusCode(), f.ResponseBody(), f.Headers(), err)
+}
@@ -0,0 +1,148 @@
+package faults
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Handler injects the faults of the profile into responses of the next handler.
+//
+// Once a call fails with KindTokenExpired, its Authorization header stays rejected,
+// so that only clients which refresh the token can proceed.
+// Truncated bodies declare the full Content-Length but send half of the bytes,
+// the client sees an unexpected EOF while reading.
+// The handler can't tell which requests carry a page token, so KindCursorExpired lets every call through,
+// see HandlerWithCursor.
+func Handler(profile Profile, next http.Handler) http.Handler {
+	return HandlerWithCursor(profile, next, nil)
+}
+
+// HandlerWithCursor is a Handler that reports Call.Cursor for requests which carry a page token.
+// The isCursor function knows where the API of the next handler expects the token, ex: a query parameter.
+// KindCursorExpired fails only such requests, the first page has no cursor to expire.
+func HandlerWithCursor(profile Profile, next http.Handler, isCursor func(r *http.Request) bool) http.Handler {
+	return &handler{
+		profile:  profile,
+		next:     next,
+		isCursor: isCursor,
+		expired:  make(map[string]bool),
+	}
+}
+
+type handler struct {
+	profile  Profile
+	next     http.Handler
+	isCursor func(r *http.Request) bool
+
+	mu      sync.Mutex
+	expired map[string]bool
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if h.isExpired(token) {
+		writeFault(w, TokenExpired())
+
+		return
+	}
+
+	call := Call{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Cursor: h.isCursor != nil && h.isCursor(r),
+	}
+
+	fault := h.profile.Next(call)
+	if fault.Kind == KindCursorExpired && !call.Cursor {
+		fault = Pass()
+	}
+
+	switch fault.Kind {
+	case "":
+		h.next.ServeHTTP(w, r)
+	case KindLatency:
+		select {
+		case <-time.After(fault.Delay):
+			h.next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	case KindTruncatedBody:
+		h.serveTruncated(w, r)
+	case KindTokenExpired:
+		if token != "" {
+			h.expire(token)
+		}
+
+		writeFault(w, fault)
+	default:
+		writeFault(w, fault)
+	}
+}
+
+func (h *handler) serveTruncated(w http.ResponseWriter, r *http.Request) {
+	recorder := newRecorder()
+	h.next.ServeHTTP(recorder, r)
+
+	body := recorder.body.Bytes()
+
+	for key, values := range recorder.header {
+		w.Header()[key] = values
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(recorder.status)
+	_, _ = w.Write(body[:len(body)/2])
+}
+
+// recorder keeps the response of the next handler, so that it can be cut short.
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+func (h *handler) isExpired(token string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.expired[token]
+}
+
+func (h *handler) expire(token string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.expired[token] = true
+}
+
+func writeFault(w http.ResponseWriter, fault Fault) {
+	for _, header := range fault.Headers() {
+		w.Header().Set(header.Key, header.Value)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(fault.StatusCode())
+	_, _ = w.Write(fault.ResponseBody())
+}
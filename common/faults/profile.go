@@ -0,0 +1,153 @@
+package faults
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// Call describes a request subject to faults.
+type Call struct {
+	// Method is the HTTP method, or the operation of an in-process connector, ex: "read".
+	Method string
+	// Path is the URL path, or the object name for an in-process connector.
+	Path string
+	// Cursor is set when the call continues a paginated read.
+	// Mock servers only know it when the handler is told how to spot a page token, see HandlerWithCursor.
+	Cursor bool
+}
+
+// Profile decides which calls fail. Implementations are safe for concurrent use.
+type Profile interface {
+	// Next returns the fault of the call, the zero Fault lets the call through.
+	Next(call Call) Fault
+}
+
+type randomProfile struct {
+	mu     sync.Mutex
+	rng    *rand.Rand
+	rate   float64
+	faults []Fault
+}
+
+// Random fails calls with the probability rate, picking one of the faults at random.
+// The seed makes the sequence of failures repeatable.
+func Random(seed uint64, rate float64, faults ...Fault) Profile {
+	return &randomProfile{
+		rng:    rand.New(rand.NewPCG(seed, seed)), // nolint:gosec
+		rate:   rate,
+		faults: faults,
+	}
+}
+
+func (p *randomProfile) Next(Call) Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.faults) == 0 || p.rng.Float64() >= p.rate {
+		return Pass()
+	}
+
+	return p.faults[p.rng.IntN(len(p.faults))]
+}
+
+type everyNthProfile struct {
+	mu     sync.Mutex
+	calls  int
+	n      int
+	faults []Fault
+}
+
+// EveryNth fails calls number n, 2n, 3n and so on, cycling through the faults.
+func EveryNth(n int, faults ...Fault) Profile {
+	return &everyNthProfile{
+		n:      n,
+		faults: faults,
+	}
+}
+
+func (p *everyNthProfile) Next(Call) Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls++
+
+	if p.n <= 0 || len(p.faults) == 0 || p.calls%p.n != 0 {
+		return Pass()
+	}
+
+	return p.faults[(p.calls/p.n-1)%len(p.faults)]
+}
+
+// Rule is a script of faults for calls to a path.
+type Rule struct {
+	// Method of the call, any method if empty.
+	Method string
+	// Path of the call.
+	Path string
+	// Faults are applied to consecutive matching calls, use Pass to let a call through.
+	// Calls after the last step are let through.
+	Faults []Fault
+}
+
+type scriptProfile struct {
+	mu    sync.Mutex
+	rules []Rule
+	steps []int
+}
+
+// Script plays the faults of the first matching rule, one per call.
+//
+// Example, the second page of contacts is rate limited, then the third one fails:
+//
+//	faults.Script(faults.Rule{
+//		Path:   "/v1/contacts",
+//		Faults: []faults.Fault{faults.Pass(), faults.RateLimit(time.Second), faults.Pass(), faults.ServerError(0)},
+//	})
+func Script(rules ...Rule) Profile {
+	return &scriptProfile{
+		rules: rules,
+		steps: make([]int, len(rules)),
+	}
+}
+
+func (p *scriptProfile) Next(call Call) Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for index, rule := range p.rules {
+		if rule.Path != call.Path || (rule.Method != "" && rule.Method != call.Method) {
+			continue
+		}
+
+		step := p.steps[index]
+		p.steps[index]++
+
+		if step < len(rule.Faults) {
+			return rule.Faults[step]
+		}
+
+		return Pass()
+	}
+
+	return Pass()
+}
+
+type combinedProfile []Profile
+
+// Combine consults every profile for each call, the first fault wins.
+// All profiles see every call, so their schedules don't depend on each other.
+func Combine(profiles ...Profile) Profile {
+	return combinedProfile(profiles)
+}
+
+func (p combinedProfile) Next(call Call) Fault {
+	result := Pass()
+
+	for _, profile := range p {
+		if fault := profile.Next(call); result.IsZero() {
+			result = fault
+		}
+	}
+
+	return result
+}
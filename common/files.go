@@ -0,0 +1,93 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrMissingFileName is returned when an upload has no file name.
+	ErrMissingFileName = errors.New("no file name provided")
+
+	// ErrMissingFileContent is returned when an upload has no content reader.
+	ErrMissingFileContent = errors.New("no file content provided")
+
+	// ErrMissingFileID is returned when a download doesn't specify which file to fetch.
+	ErrMissingFileID = errors.New("no file ID provided")
+)
+
+// UnknownFileSize is reported when the provider doesn't disclose the size of a file.
+const UnknownFileSize int64 = -1
+
+// FileUploadParams describes a file to upload.
+type FileUploadParams struct {
+	// FileName is the name the file will have in the provider, including extension. Required.
+	FileName string
+	// Content is read until EOF and streamed to the provider. Required.
+	// If it implements io.Closer, the caller is still responsible for closing it.
+	Content io.Reader
+	// ContentType is the MIME type of the content. Optional, defaults to application/octet-stream.
+	ContentType string
+	// Size of the content in bytes, or zero if unknown.
+	// It is reported back in the result by providers which don't return the size of stored files.
+	Size int64
+	// Folder is a provider specific location for the file, ex: folder ID or path. Optional.
+	Folder string
+	// ParentId links the file to a record, ex: the Salesforce record the file is published to. Optional.
+	ParentId string // nolint:revive
+	// Attributes are provider specific properties sent alongside the file. Optional.
+	Attributes map[string]any
+}
+
+// FileUploadResult is returned once the upload completes.
+type FileUploadResult struct {
+	// FileId is the identifier to pass to FileDownloadParams.
+	FileId string // nolint:revive
+	// FileName as stored by the provider.
+	FileName string
+	// Size in bytes, or UnknownFileSize.
+	Size int64
+	// ContentType as stored by the provider, if reported.
+	ContentType string
+	// Data is the raw provider response.
+	Data map[string]any
+}
+
+// FileDownloadParams identifies a file to download.
+type FileDownloadParams struct {
+	// FileId is the provider identifier of the file. Required.
+	FileId string // nolint:revive
+}
+
+// FileDownloadResult streams file content.
+type FileDownloadResult struct {
+	// Content is the file body. The caller must close it.
+	Content io.ReadCloser
+	// FileName of the file, if known.
+	FileName string
+	// Size in bytes, or UnknownFileSize.
+	Size int64
+	// ContentType is the MIME type of the file.
+	ContentType string
+}
+
+func (p FileUploadParams) ValidateParams() error {
+	if len(p.FileName) == 0 {
+		return ErrMissingFileName
+	}
+
+	if p.Content == nil {
+		return ErrMissingFileContent
+	}
+
+	return nil
+}
+
+func (p FileDownloadParams) ValidateParams() error {
+	if len(p.FileId) == 0 {
+		return ErrMissingFileID
+	}
+
+	return nil
+}
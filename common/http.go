@@ -85,6 +85,19 @@ func (h Headers) Has(target Header) bool {
 	return false
 }
 
+// HasKey returns true if any header has the given key, compared case-insensitively.
+func (h Headers) HasKey(key string) bool {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+
+	for _, header := range h {
+		if textproto.CanonicalMIMEHeaderKey(header.Key) == key {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (h Headers) ApplyToRequest(req *http.Request) {
 	for _, header := range h {
 		header.ApplyToRequest(req)
@@ -279,6 +292,26 @@ func (h *HTTPClient) Delete(ctx context.Context,
 	return res, body, nil
 }
 
+// Send makes a request of any method with an already serialized body and returns the response & response body.
+// Unlike Post/Put/Patch the body is sent as-is, the caller is responsible for the Content-Type header.
+// It is used by ContentHTTPClient, which serializes payloads of various formats.
+func (h *HTTPClient) Send(ctx context.Context,
+	method, url string, reqBody []byte, headers ...Header,
+) (*http.Response, []byte, error) {
+	fullURL, err := h.getURL(url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Make the request, get the response body
+	res, body, err := h.httpSend(ctx, method, fullURL, headers, reqBody) //nolint:bodyclose
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, body, nil
+}
+
 // httpGet makes a GET request to the given URL and returns the response & response body.
 func (h *HTTPClient) httpGet(ctx context.Context, //nolint:dupl
 	url string, headers []Header,
@@ -485,6 +518,49 @@ func (h *HTTPClient) httpDelete(ctx context.Context, //nolint:dupl
 	return rsp, rspBody, nil
 }
 
+// httpSend makes a request with raw body bytes and returns the response & response body.
+func (h *HTTPClient) httpSend(ctx context.Context, method, url string, //nolint:dupl
+	headers []Header, requestBody []byte,
+) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if requestBody != nil {
+		reader = bytes.NewReader(requestBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req = addHeaders(req, headers)
+
+	correlationId := uuid.Must(uuid.NewRandom()).String()
+
+	if logging.IsVerboseLogging(ctx) {
+		logRequestWithBody(logging.VerboseLogger(ctx), req, method, correlationId, url, requestBody)
+	} else {
+		logRequestWithoutBody(logging.Logger(ctx), req, method, correlationId, url)
+	}
+
+	rsp, responseBody, err := h.sendRequest(req)
+
+	if logging.IsVerboseLogging(ctx) {
+		logResponseWithBody(logging.VerboseLogger(ctx), rsp, method, correlationId, url, responseBody)
+	} else {
+		logResponseWithoutBody(logging.Logger(ctx), rsp, method, correlationId, url)
+	}
+
+	if err != nil {
+		logging.Logger(ctx).Error("HTTP request failed",
+			"method", method, "url", url,
+			"correlationId", correlationId, "error", err)
+
+		return nil, nil, err
+	}
+
+	return rsp, responseBody, nil
+}
+
 // MakeGetRequest creates a GET request with the given headers.
 func MakeGetRequest(ctx context.Context, url string, headers []Header) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -0,0 +1,115 @@
+// nolint:revive,godoclint
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/amp-labs/connectors/common/logging"
+	"github.com/google/uuid"
+)
+
+// Stream sends a request whose body is read from the reader and returns the response with its body still open.
+// Unlike other HTTPClient methods neither the request nor the response body is buffered in memory,
+// which makes it suitable for file uploads and downloads.
+// The caller must close the response body. On error responses the body is consumed, closed,
+// and passed to the ErrorHandler, same as in other methods.
+// Bodies are never logged, even with verbose logging.
+// A request body implementing io.Closer is always closed, even when the request is never sent,
+// so producers writing into it, ex: MultipartCodec.Reader, are released.
+func (h *HTTPClient) Stream(ctx context.Context,
+	method, url string, reqBody io.Reader, headers ...Header,
+) (*http.Response, error) {
+	fullURL, err := h.getURL(url)
+	if err != nil {
+		closeRequestBody(reqBody)
+
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	if err != nil {
+		closeRequestBody(reqBody)
+
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req = addHeaders(req, headers)
+
+	correlationId := uuid.Must(uuid.NewRandom()).String()
+
+	logRequestWithoutBody(logging.Logger(ctx), req, method, correlationId, fullURL)
+
+	rsp, err := h.streamRequest(req)
+
+	if rsp != nil {
+		logResponseWithoutBody(logging.Logger(ctx), rsp, method, correlationId, fullURL)
+	}
+
+	if err != nil {
+		logging.Logger(ctx).Error("HTTP request failed",
+			"method", method, "url", fullURL,
+			"correlationId", correlationId, "error", err)
+
+		return nil, err
+	}
+
+	return rsp, nil
+}
+
+// closeRequestBody closes a body that never reached the transport, which otherwise closes it.
+func closeRequestBody(body io.Reader) {
+	closer, ok := body.(io.Closer)
+	if !ok {
+		return
+	}
+
+	if err := closer.Close(); err != nil {
+		slog.Warn("unable to close request body", "error", err)
+	}
+}
+
+func (h *HTTPClient) streamRequest(req *http.Request) (*http.Response, error) {
+	res, err := h.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.ResponseHandler != nil {
+		res, err = h.ResponseHandler(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shouldHandleError := h.ShouldHandleError
+	if shouldHandleError == nil {
+		shouldHandleError = func(response *http.Response) bool {
+			return response.StatusCode < 200 || response.StatusCode > 299
+		}
+	}
+
+	if !shouldHandleError(res) {
+		// Success, the caller owns the body.
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+
+	if closeErr := res.Body.Close(); closeErr != nil {
+		slog.Warn("unable to close response body", "error", closeErr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if h.ErrorHandler != nil {
+		return res, h.ErrorHandler(res, body)
+	}
+
+	return res, InterpretError(res, body)
+}
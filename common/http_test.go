@@ -206,3 +206,22 @@ func TestApplyAppendHeadersToRequest(t *testing.T) {
 	require.Equal(t, "value2", vals[1])
 	require.Equal(t, "value3", vals[2])
 }
+
+func TestStreamClosesUnsentBody(t *testing.T) {
+	t.Parallel()
+
+	body, contentType, err := MultipartCodec{}.Reader(MultipartForm{
+		Fields: map[string]string{"name": "report"},
+	})
+	require.NoError(t, err)
+
+	client := &HTTPClient{Client: &http.Client{Transport: &dummyTransport{}}}
+
+	// An invalid method fails before the request is sent.
+	_, err = client.Stream(t.Context(), "bad method", "http://localhost", body,
+		Header{Key: "Content-Type", Value: contentType})
+	require.Error(t, err)
+
+	_, err = body.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
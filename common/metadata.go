@@ -90,6 +90,9 @@ func (a AssociationOnDeleteAction) IsValid() bool {
 type UpsertMetadataParams struct {
 	// Maps object names to field definitions.
 	Fields map[string][]FieldDefinition `json:"fields"`
+	// DryRun computes which fields would be created, updated or left unchanged
+	// without making any changes. The plan is returned as UpsertMetadataResult.
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 var ErrFieldTypeUnknown = errors.New("unrecognized field type")
@@ -249,6 +252,13 @@ type UpsertMetadataResult struct {
 
 	// Maps object name -> field name -> upsert result.
 	Fields map[string]map[string]FieldUpsertResult `json:"fields"`
+
+	// DryRun indicates that the result is a plan and nothing was changed.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Maps permission set name -> changes made to grant access to upserted fields.
+	// Only populated by providers which manage field visibility through permission sets.
+	PermissionSets map[string]PermissionSetUpsertResult `json:"permissionSets,omitempty"`
 }
 
 // FieldUpsertResult is the result of an upsert operation for a single field.
@@ -266,3 +276,14 @@ type FieldUpsertResult struct {
 	// such as unsupported field attributes.
 	Warnings []string `json:"warnings,omitempty"`
 }
+
+// PermissionSetUpsertResult is the result of an upsert operation for a single permission set.
+type PermissionSetUpsertResult struct {
+	// Name is the name of the permission set.
+	Name string `json:"name"`
+	// Action indicates what action was taken (create, update, none).
+	Action UpsertMetadataAction `json:"action"`
+	// GrantedFields lists fields that are made visible by the permission set.
+	// Only fields which were not visible before are listed.
+	GrantedFields []string `json:"grantedFields,omitempty"`
+}
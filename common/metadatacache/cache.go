@@ -0,0 +1,118 @@
+// Package metadatacache keeps object metadata in memory, so that features which
+// consult the schema on every Read or Write don't call ListObjectMetadata each time.
+package metadatacache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ErrMissingMetadata is returned when the provider didn't describe the requested object.
+var ErrMissingMetadata = errors.New("object metadata is not available")
+
+// Source is anything capable of listing object metadata, usually a connector
+// implementing connectors.ObjectMetadataConnector.
+type Source interface {
+	ListObjectMetadata(ctx context.Context, objectNames []string) (*common.ListObjectMetadataResult, error)
+}
+
+// Cache stores ObjectMetadata per object name.
+// Entries expire after the configured TTL. Zero TTL means entries never expire.
+// It is safe for concurrent use.
+type Cache struct {
+	source Source
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]entry
+}
+
+type entry struct {
+	metadata *common.ObjectMetadata
+	loadedAt time.Time
+}
+
+// New creates a cache which loads metadata from the source on demand.
+func New(source Source, ttl time.Duration) *Cache {
+	return &Cache{
+		source:  source,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]entry),
+	}
+}
+
+// Get returns metadata for the object, calling the source only if the object is not cached or has expired.
+func (c *Cache) Get(ctx context.Context, objectName string) (*common.ObjectMetadata, error) {
+	if metadata, ok := c.lookup(objectName); ok {
+		return metadata, nil
+	}
+
+	result, err := c.source.ListObjectMetadata(ctx, []string{objectName})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = result.Errors[objectName]; err != nil {
+		return nil, err
+	}
+
+	metadata, ok := result.Result[objectName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingMetadata, objectName)
+	}
+
+	c.Set(objectName, &metadata)
+
+	return &metadata, nil
+}
+
+// Set stores metadata for the object, replacing any previous entry.
+func (c *Cache) Set(objectName string, metadata *common.ObjectMetadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[objectName] = entry{
+		metadata: metadata,
+		loadedAt: c.now(),
+	}
+}
+
+// Invalidate drops cached metadata for the listed objects.
+// When no objects are given the whole cache is cleared.
+func (c *Cache) Invalidate(objectNames ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(objectNames) == 0 {
+		c.entries = make(map[string]entry)
+
+		return
+	}
+
+	for _, objectName := range objectNames {
+		delete(c.entries, objectName)
+	}
+}
+
+func (c *Cache) lookup(objectName string) (*common.ObjectMetadata, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, ok := c.entries[objectName]
+	if !ok {
+		return nil, false
+	}
+
+	if c.ttl > 0 && c.now().Sub(cached.loadedAt) > c.ttl {
+		return nil, false
+	}
+
+	return cached.metadata, true
+}
@@ -0,0 +1,235 @@
+// Package metadatadiff detects schema drift between two snapshots of object metadata.
+//
+// Custom fields are routinely added, renamed and retyped in customer instances.
+// Comparing the output of ListObjectMetadata taken at different points in time
+// allows callers to notice that field mappings are about to break.
+// Snapshots could come from a live connector or from the static schemas.json files.
+package metadatadiff
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// Compare produces a drift report going from the older snapshot to the newer one.
+// Nil snapshots are treated as empty.
+// Objects which have an error in either snapshot are not compared and are listed as skipped.
+func Compare(before, after *common.ListObjectMetadataResult) *Report {
+	before = orEmpty(before)
+	after = orEmpty(after)
+
+	report := &Report{
+		Objects: make(map[string]ObjectDiff),
+	}
+
+	skipped := datautils.NewStringSet()
+	for objectName := range before.Errors {
+		skipped.AddOne(objectName)
+	}
+
+	for objectName := range after.Errors {
+		skipped.AddOne(objectName)
+	}
+
+	for objectName, newer := range after.Result {
+		if skipped.Has(objectName) {
+			continue
+		}
+
+		older, ok := before.Result[objectName]
+		if !ok {
+			report.AddedObjects = append(report.AddedObjects, objectName)
+
+			continue
+		}
+
+		if diff := CompareObjects(older, newer); !diff.IsEmpty() {
+			report.Objects[objectName] = *diff
+		}
+	}
+
+	for objectName := range before.Result {
+		if skipped.Has(objectName) {
+			continue
+		}
+
+		if _, ok := after.Result[objectName]; !ok {
+			report.RemovedObjects = append(report.RemovedObjects, objectName)
+		}
+	}
+
+	report.SkippedObjects = skipped.List()
+
+	slices.Sort(report.AddedObjects)
+	slices.Sort(report.RemovedObjects)
+	slices.Sort(report.SkippedObjects)
+
+	return report
+}
+
+// CompareObjects produces field level drift for a single object.
+// Legacy metadata which only populates ObjectMetadata.FieldsMap is compared by display names.
+func CompareObjects(before, after common.ObjectMetadata) *ObjectDiff {
+	olderFields := fieldsOf(before)
+	newerFields := fieldsOf(after)
+
+	diff := &ObjectDiff{
+		DisplayName: after.DisplayName,
+	}
+
+	for fieldName, newer := range newerFields {
+		older, ok := olderFields[fieldName]
+		if !ok {
+			diff.AddedFields = append(diff.AddedFields, summarize(fieldName, newer))
+
+			continue
+		}
+
+		if changes := compareFields(older, newer); len(changes) != 0 {
+			diff.ChangedFields = append(diff.ChangedFields, FieldDiff{
+				FieldName: fieldName,
+				Changes:   changes,
+			})
+		}
+	}
+
+	for fieldName, older := range olderFields {
+		if _, ok := newerFields[fieldName]; !ok {
+			diff.RemovedFields = append(diff.RemovedFields, summarize(fieldName, older))
+		}
+	}
+
+	slices.SortFunc(diff.AddedFields, compareSummaries)
+	slices.SortFunc(diff.RemovedFields, compareSummaries)
+	slices.SortFunc(diff.ChangedFields, func(a, b FieldDiff) int {
+		return strings.Compare(a.FieldName, b.FieldName)
+	})
+
+	return diff
+}
+
+func compareFields(before, after common.FieldMetadata) []PropertyDiff {
+	var changes []PropertyDiff
+
+	if before.ValueType != after.ValueType {
+		changes = append(changes, PropertyDiff{
+			Kind:   ChangeValueType,
+			Before: before.ValueType,
+			After:  after.ValueType,
+		})
+	}
+
+	if before.ProviderType != after.ProviderType {
+		changes = append(changes, PropertyDiff{
+			Kind:   ChangeProviderType,
+			Before: before.ProviderType,
+			After:  after.ProviderType,
+		})
+	}
+
+	if !equalFlags(before.IsRequired, after.IsRequired) {
+		changes = append(changes, PropertyDiff{
+			Kind:   ChangeIsRequired,
+			Before: before.IsRequired,
+			After:  after.IsRequired,
+		})
+	}
+
+	if !equalFlags(before.ReadOnly, after.ReadOnly) {
+		changes = append(changes, PropertyDiff{
+			Kind:   ChangeReadOnly,
+			Before: before.ReadOnly,
+			After:  after.ReadOnly,
+		})
+	}
+
+	if change, ok := compareValues(before.Values, after.Values); ok {
+		changes = append(changes, change)
+	}
+
+	if before.DisplayName != after.DisplayName {
+		changes = append(changes, PropertyDiff{
+			Kind:   ChangeDisplayName,
+			Before: before.DisplayName,
+			After:  after.DisplayName,
+		})
+	}
+
+	return changes
+}
+
+// compareValues reports picklist changes. Options are compared as sets, so reordering is not a change.
+// Options are matched by value, a change of display value alone is still reported with empty added/removed lists.
+func compareValues(before, after []common.FieldValue) (PropertyDiff, bool) {
+	if len(datautils.NewSetFromList(before).Diff(datautils.NewSetFromList(after))) == 0 {
+		return PropertyDiff{}, false
+	}
+
+	olderValues := datautils.NewSetFromList(optionValues(before))
+	newerValues := datautils.NewSetFromList(optionValues(after))
+
+	added := newerValues.Subtract(olderValues)
+	removed := olderValues.Subtract(newerValues)
+
+	slices.Sort(added)
+	slices.Sort(removed)
+
+	return PropertyDiff{
+		Kind:          ChangeValues,
+		Before:        before,
+		After:         after,
+		AddedValues:   added,
+		RemovedValues: removed,
+	}, true
+}
+
+func fieldsOf(metadata common.ObjectMetadata) common.FieldsMetadata {
+	if len(metadata.Fields) != 0 {
+		return metadata.Fields
+	}
+
+	fields := make(common.FieldsMetadata, len(metadata.FieldsMap))
+	for fieldName, displayName := range metadata.FieldsMap {
+		fields.AddFieldWithDisplayOnly(fieldName, displayName)
+	}
+
+	return fields
+}
+
+func summarize(fieldName string, field common.FieldMetadata) FieldSummary {
+	return FieldSummary{
+		FieldName:    fieldName,
+		DisplayName:  field.DisplayName,
+		ValueType:    field.ValueType,
+		ProviderType: field.ProviderType,
+	}
+}
+
+func optionValues(values []common.FieldValue) []string {
+	return datautils.ForEach(values, func(value common.FieldValue) string {
+		return value.Value
+	})
+}
+
+func equalFlags(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func orEmpty(result *common.ListObjectMetadataResult) *common.ListObjectMetadataResult {
+	if result == nil {
+		return common.NewListObjectMetadataResult()
+	}
+
+	return result
+}
+
+func compareSummaries(a, b FieldSummary) int {
+	return strings.Compare(a.FieldName, b.FieldName)
+}
@@ -0,0 +1,261 @@
+// nolint:revive,godoclint
+package metadatadiff
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/goutils"
+	"github.com/go-test/deep"
+)
+
+func TestCompare(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	before := &common.ListObjectMetadataResult{
+		Result: map[string]common.ObjectMetadata{
+			"contacts": *common.NewObjectMetadata("Contacts", common.FieldsMetadata{
+				"id": {
+					DisplayName:  "ID",
+					ValueType:    common.ValueTypeString,
+					ProviderType: "id",
+					ReadOnly:     goutils.Pointer(true),
+				},
+				"age": {
+					DisplayName:  "Age",
+					ValueType:    common.ValueTypeString,
+					ProviderType: "text",
+				},
+				"stage": {
+					DisplayName:  "Stage",
+					ValueType:    common.ValueTypeSingleSelect,
+					ProviderType: "picklist",
+					IsRequired:   goutils.Pointer(false),
+					Values: []common.FieldValue{
+						{Value: "lead", DisplayValue: "Lead"},
+						{Value: "customer", DisplayValue: "Customer"},
+					},
+				},
+				"old_field": {
+					DisplayName:  "Old Field",
+					ValueType:    common.ValueTypeString,
+					ProviderType: "text",
+				},
+			}),
+			"deals":   *common.NewObjectMetadata("Deals", common.FieldsMetadata{}),
+			"tickets": *common.NewObjectMetadata("Tickets", common.FieldsMetadata{}),
+		},
+		Errors: map[string]error{},
+	}
+
+	after := &common.ListObjectMetadataResult{
+		Result: map[string]common.ObjectMetadata{
+			"contacts": *common.NewObjectMetadata("Contacts", common.FieldsMetadata{
+				"id": {
+					DisplayName:  "ID",
+					ValueType:    common.ValueTypeString,
+					ProviderType: "id",
+					ReadOnly:     goutils.Pointer(true),
+				},
+				"age": {
+					DisplayName:  "Age",
+					ValueType:    common.ValueTypeInt,
+					ProviderType: "number",
+				},
+				"stage": {
+					DisplayName:  "Lifecycle Stage",
+					ValueType:    common.ValueTypeSingleSelect,
+					ProviderType: "picklist",
+					IsRequired:   goutils.Pointer(true),
+					Values: []common.FieldValue{
+						{Value: "lead", DisplayValue: "Lead"},
+						{Value: "opportunity", DisplayValue: "Opportunity"},
+					},
+				},
+				"new_field": {
+					DisplayName:  "New Field",
+					ValueType:    common.ValueTypeBoolean,
+					ProviderType: "checkbox",
+				},
+			}),
+			"companies": *common.NewObjectMetadata("Companies", common.FieldsMetadata{}),
+		},
+		Errors: map[string]error{
+			"tickets": errors.New("permission denied"),
+		},
+	}
+
+	expected := &Report{
+		AddedObjects:   []string{"companies"},
+		RemovedObjects: []string{"deals"},
+		SkippedObjects: []string{"tickets"},
+		Objects: map[string]ObjectDiff{
+			"contacts": {
+				DisplayName: "Contacts",
+				AddedFields: []FieldSummary{{
+					FieldName:    "new_field",
+					DisplayName:  "New Field",
+					ValueType:    common.ValueTypeBoolean,
+					ProviderType: "checkbox",
+				}},
+				RemovedFields: []FieldSummary{{
+					FieldName:    "old_field",
+					DisplayName:  "Old Field",
+					ValueType:    common.ValueTypeString,
+					ProviderType: "text",
+				}},
+				ChangedFields: []FieldDiff{{
+					FieldName: "age",
+					Changes: []PropertyDiff{
+						{Kind: ChangeValueType, Before: common.ValueType(common.ValueTypeString),
+							After: common.ValueType(common.ValueTypeInt)},
+						{Kind: ChangeProviderType, Before: "text", After: "number"},
+					},
+				}, {
+					FieldName: "stage",
+					Changes: []PropertyDiff{
+						{Kind: ChangeIsRequired, Before: goutils.Pointer(false), After: goutils.Pointer(true)},
+						{
+							Kind: ChangeValues,
+							Before: []common.FieldValue{
+								{Value: "lead", DisplayValue: "Lead"},
+								{Value: "customer", DisplayValue: "Customer"},
+							},
+							After: []common.FieldValue{
+								{Value: "lead", DisplayValue: "Lead"},
+								{Value: "opportunity", DisplayValue: "Opportunity"},
+							},
+							AddedValues:   []string{"opportunity"},
+							RemovedValues: []string{"customer"},
+						},
+						{Kind: ChangeDisplayName, Before: "Stage", After: "Lifecycle Stage"},
+					},
+				}},
+			},
+		},
+	}
+
+	report := Compare(before, after)
+	if diff := deep.Equal(report, expected); diff != nil {
+		t.Fatalf("unexpected report: %v", diff)
+	}
+
+	if !report.HasChanges() {
+		t.Fatal("report should have changes")
+	}
+
+	if !report.Objects["contacts"].ChangedFields[0].IsRetyped() {
+		t.Fatal("age field should be retyped")
+	}
+
+	if report.Objects["contacts"].ChangedFields[1].IsRetyped() {
+		t.Fatal("stage field should not be retyped")
+	}
+
+	if _, err := json.Marshal(report); err != nil {
+		t.Fatalf("report must be serializable: %v", err)
+	}
+}
+
+func TestCompareNoChanges(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &common.ListObjectMetadataResult{
+		Result: map[string]common.ObjectMetadata{
+			"contacts": {
+				DisplayName: "Contacts",
+				FieldsMap: map[string]string{
+					"id":   "ID",
+					"name": "Name",
+				},
+			},
+		},
+	}
+
+	report := Compare(snapshot, snapshot)
+	if report.HasChanges() {
+		t.Fatalf("identical snapshots must not drift, got %+v", report)
+	}
+
+	report = Compare(nil, snapshot)
+	if diff := deep.Equal(report.AddedObjects, []string{"contacts"}); diff != nil {
+		t.Fatalf("unexpected added objects: %v", diff)
+	}
+}
+
+func TestFromStaticSchemas(t *testing.T) {
+	t.Parallel()
+
+	extended := []byte(`{"modules":{"root":{"id":"root","path":"","objects":{
+		"users":{"displayName":"Users","path":"/users","responseKey":"users","fields":{
+			"id":{"displayName":"Id","valueType":"string","providerType":"string"}
+		}}
+	}}}}`)
+
+	basic := []byte(`{"modules":{"root":{"id":"root","path":"","objects":{
+		"users":{"displayName":"Users","path":"/users","responseKey":"users","fields":{
+			"id":"Id", "email":"Email"
+		}}
+	}}}}`)
+
+	older, err := FromStaticSchemas(basic, "")
+	if err != nil {
+		t.Fatalf("failed to load basic schemas: %v", err)
+	}
+
+	newer, err := FromStaticSchemas(extended, common.ModuleRoot)
+	if err != nil {
+		t.Fatalf("failed to load extended schemas: %v", err)
+	}
+
+	report := Compare(older, newer)
+
+	users := report.Objects["users"]
+	if len(users.RemovedFields) != 1 || users.RemovedFields[0].FieldName != "email" {
+		t.Fatalf("email field should be removed, got %+v", users)
+	}
+
+	if len(users.ChangedFields) != 1 || !users.ChangedFields[0].IsRetyped() {
+		t.Fatalf("id field should be retyped, got %+v", users)
+	}
+
+	if _, err = FromStaticSchemas([]byte("not json"), ""); !errors.Is(err, ErrInvalidSchemasFile) {
+		t.Fatalf("expected invalid schemas error, got %v", err)
+	}
+}
+
+func TestCompareReorderedValues(t *testing.T) {
+	t.Parallel()
+
+	snapshot := func(values ...common.FieldValue) *common.ListObjectMetadataResult {
+		return &common.ListObjectMetadataResult{
+			Result: map[string]common.ObjectMetadata{
+				"contacts": *common.NewObjectMetadata("Contacts", common.FieldsMetadata{
+					"stage": {
+						DisplayName: "Stage",
+						ValueType:   common.ValueTypeSingleSelect,
+						Values:      values,
+					},
+				}),
+			},
+		}
+	}
+
+	lead := common.FieldValue{Value: "lead", DisplayValue: "Lead"}
+	customer := common.FieldValue{Value: "customer", DisplayValue: "Customer"}
+
+	report := Compare(snapshot(lead, customer), snapshot(customer, lead))
+	if report.HasChanges() {
+		t.Fatalf("reordered values must not drift, got %+v", report)
+	}
+
+	// Relabeled option is still a change, even though no value was added or removed.
+	prospect := common.FieldValue{Value: "lead", DisplayValue: "Prospect"}
+
+	report = Compare(snapshot(lead, customer), snapshot(customer, prospect))
+	if !report.HasChanges() {
+		t.Fatal("relabeled value must drift")
+	}
+}
@@ -0,0 +1,138 @@
+package metadatadiff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// PlanUpsert reports what UpsertMetadata would do with the field definitions
+// given the current metadata of objects, as returned by ListObjectMetadata. Nothing is written.
+//
+// Objects and fields are matched by name ignoring case, since providers differ in how they spell names.
+// A field missing from the object, or an object missing from the metadata, is planned for creation.
+// So is an object whose metadata failed with common.ErrNotFound, other failures are returned.
+// An existing field is planned for update when its display name, value type, requirement
+// or select values differ. Properties that the metadata doesn't describe are not compared.
+// Differences are listed under the "changes" key of the field result metadata.
+func PlanUpsert(
+	params *common.UpsertMetadataParams, current *common.ListObjectMetadataResult,
+) (*common.UpsertMetadataResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	current = orEmpty(current)
+
+	result := &common.UpsertMetadataResult{
+		Success: true,
+		Fields:  make(map[string]map[string]common.FieldUpsertResult, len(params.Fields)),
+		DryRun:  true,
+	}
+
+	for objectName, definitions := range params.Fields {
+		if err, ok := lookupIgnoringCase(current.Errors, objectName); ok && !errors.Is(err, common.ErrNotFound) {
+			return nil, fmt.Errorf("object %s: %w", objectName, err)
+		}
+
+		object, _ := lookupIgnoringCase(current.Result, objectName)
+
+		existing := make(common.FieldsMetadata)
+		for fieldName, field := range fieldsOf(object) {
+			existing[strings.ToLower(fieldName)] = field
+		}
+
+		fields := make(map[string]common.FieldUpsertResult, len(definitions))
+
+		for _, definition := range definitions {
+			plan := common.FieldUpsertResult{
+				FieldName: definition.FieldName,
+				Action:    common.UpsertMetadataActionCreate,
+			}
+
+			if field, ok := existing[strings.ToLower(definition.FieldName)]; ok {
+				plan.Action = common.UpsertMetadataActionNone
+
+				if changes := planChanges(field, definition); len(changes) != 0 {
+					plan.Action = common.UpsertMetadataActionUpdate
+					plan.Metadata = map[string]any{"changes": changes}
+				}
+			}
+
+			fields[definition.FieldName] = plan
+		}
+
+		result.Fields[objectName] = fields
+	}
+
+	return result, nil
+}
+
+// planChanges lists properties of the existing field that the definition would change.
+func planChanges(field common.FieldMetadata, definition common.FieldDefinition) []ChangeKind {
+	var changes []ChangeKind
+
+	if field.DisplayName != "" && definition.DisplayName != "" && field.DisplayName != definition.DisplayName {
+		changes = append(changes, ChangeDisplayName)
+	}
+
+	// Type of a relationship field is implied by the association.
+	if definition.Association == nil && !compatibleTypes(field.ValueType, definition.ValueType) {
+		changes = append(changes, ChangeValueType)
+	}
+
+	if field.IsRequired != nil && *field.IsRequired != definition.Required {
+		changes = append(changes, ChangeIsRequired)
+	}
+
+	if definition.ValueType.IsSelectionType() && field.Values != nil &&
+		definition.StringOptions != nil && len(definition.StringOptions.Values) != 0 {
+		current := datautils.NewStringSet()
+		for _, value := range field.Values {
+			// Definitions carry a single text per option, it may match either of the two.
+			current.AddOne(value.Value)
+			current.AddOne(value.DisplayValue)
+		}
+
+		desired := datautils.NewSetFromList(definition.StringOptions.Values)
+		if len(desired.Subtract(current)) != 0 || len(field.Values) != len(desired) {
+			changes = append(changes, ChangeValues)
+		}
+	}
+
+	return changes
+}
+
+// compatibleTypes reports whether the existing field already has the type of the definition.
+// Unknown types are assumed compatible, validation of definitions is left to the provider.
+// Integers are stored as floats by providers that don't differentiate the two.
+func compatibleTypes(existing common.ValueType, desired common.FieldType) bool {
+	if existing == "" || existing == common.ValueTypeOther {
+		return true
+	}
+
+	if desired == common.FieldTypeInt && existing == common.ValueTypeFloat {
+		return true
+	}
+
+	return string(existing) == string(desired)
+}
+
+func lookupIgnoringCase[V any](registry map[string]V, name string) (V, bool) {
+	if value, ok := registry[name]; ok {
+		return value, true
+	}
+
+	for key, value := range registry {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+
+	var empty V
+
+	return empty, false
+}
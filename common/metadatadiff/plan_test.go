@@ -0,0 +1,156 @@
+// nolint:revive,godoclint
+package metadatadiff
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/goutils"
+	"github.com/go-test/deep"
+)
+
+func TestPlanUpsert(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	current := &common.ListObjectMetadataResult{
+		Result: map[string]common.ObjectMetadata{
+			"contacts": *common.NewObjectMetadata("Contacts", common.FieldsMetadata{
+				"nickname__c": {
+					DisplayName:  "Nickname",
+					ValueType:    common.ValueTypeString,
+					ProviderType: "string",
+					IsRequired:   goutils.Pointer(false),
+				},
+				"score__c": {
+					DisplayName:  "Score",
+					ValueType:    common.ValueTypeFloat,
+					ProviderType: "double",
+				},
+				"tier__c": {
+					DisplayName:  "Tier",
+					ValueType:    common.ValueTypeSingleSelect,
+					ProviderType: "picklist",
+					Values: []common.FieldValue{
+						{Value: "gold", DisplayValue: "Gold"},
+						{Value: "silver", DisplayValue: "Silver"},
+					},
+				},
+			}),
+		},
+		Errors: map[string]error{},
+	}
+
+	params := &common.UpsertMetadataParams{
+		Fields: map[string][]common.FieldDefinition{
+			"contacts": {{
+				FieldName:   "Nickname__c",
+				DisplayName: "Nickname",
+				ValueType:   common.FieldTypeString,
+			}, {
+				FieldName:   "Score__c",
+				DisplayName: "Lead Score",
+				ValueType:   common.FieldTypeInt,
+			}, {
+				FieldName:     "Tier__c",
+				DisplayName:   "Tier",
+				ValueType:     common.FieldTypeSingleSelect,
+				Required:      true,
+				StringOptions: &common.StringFieldOptions{Values: []string{"Gold", "Silver", "Bronze"}},
+			}, {
+				FieldName:   "Renewal__c",
+				DisplayName: "Renewal",
+				ValueType:   common.FieldTypeDate,
+			}},
+			"deals": {{
+				FieldName:   "Region__c",
+				DisplayName: "Region",
+				ValueType:   common.FieldTypeString,
+			}},
+		},
+		DryRun: true,
+	}
+
+	plan, err := PlanUpsert(params, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &common.UpsertMetadataResult{
+		Success: true,
+		DryRun:  true,
+		Fields: map[string]map[string]common.FieldUpsertResult{
+			"contacts": {
+				"Nickname__c": {
+					FieldName: "Nickname__c",
+					Action:    common.UpsertMetadataActionNone,
+				},
+				"Score__c": {
+					FieldName: "Score__c",
+					Action:    common.UpsertMetadataActionUpdate,
+					Metadata:  map[string]any{"changes": []ChangeKind{ChangeDisplayName}},
+				},
+				"Tier__c": {
+					FieldName: "Tier__c",
+					Action:    common.UpsertMetadataActionUpdate,
+					Metadata:  map[string]any{"changes": []ChangeKind{ChangeValues}},
+				},
+				"Renewal__c": {
+					FieldName: "Renewal__c",
+					Action:    common.UpsertMetadataActionCreate,
+				},
+			},
+			"deals": {
+				"Region__c": {
+					FieldName: "Region__c",
+					Action:    common.UpsertMetadataActionCreate,
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(plan, expected); diff != nil {
+		t.Fatalf("unexpected plan: %v", diff)
+	}
+}
+
+func TestPlanUpsertObjectError(t *testing.T) {
+	t.Parallel()
+
+	errObject := errors.New("permission denied")
+
+	_, err := PlanUpsert(&common.UpsertMetadataParams{
+		Fields: map[string][]common.FieldDefinition{
+			"leads": {{FieldName: "Topic", DisplayName: "Topic", ValueType: common.FieldTypeString}},
+		},
+	}, &common.ListObjectMetadataResult{
+		Result: map[string]common.ObjectMetadata{},
+		Errors: map[string]error{"leads": errObject},
+	})
+	if !errors.Is(err, errObject) {
+		t.Fatalf("expected object error, got %v", err)
+	}
+
+	// Objects which are not found are yet to be created.
+	plan, err := PlanUpsert(&common.UpsertMetadataParams{
+		Fields: map[string][]common.FieldDefinition{
+			"leads": {{FieldName: "Topic", DisplayName: "Topic", ValueType: common.FieldTypeString}},
+		},
+	}, &common.ListObjectMetadataResult{
+		Result: map[string]common.ObjectMetadata{},
+		Errors: map[string]error{"Leads": fmt.Errorf("%w: leads", common.ErrNotFound)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if action := plan.Fields["leads"]["Topic"].Action; action != common.UpsertMetadataActionCreate {
+		t.Fatalf("expected create, got %v", action)
+	}
+
+	_, err = PlanUpsert(nil, nil)
+	if !errors.Is(err, common.ErrMissingFieldsMetadata) {
+		t.Fatalf("expected missing fields error, got %v", err)
+	}
+}
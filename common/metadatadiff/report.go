@@ -0,0 +1,99 @@
+package metadatadiff
+
+import "github.com/amp-labs/connectors/common"
+
+// ChangeKind names a property of common.FieldMetadata that differs between two snapshots.
+type ChangeKind string
+
+const (
+	// ChangeValueType means the Ampersand value type has changed, ex: string -> float.
+	ChangeValueType ChangeKind = "valueType"
+	// ChangeProviderType means the raw provider type has changed, ex: text -> currency.
+	ChangeProviderType ChangeKind = "providerType"
+	// ChangeIsRequired means the field became required or optional.
+	ChangeIsRequired ChangeKind = "isRequired"
+	// ChangeReadOnly means the field became read-only or writable.
+	ChangeReadOnly ChangeKind = "readOnly"
+	// ChangeValues means picklist options were added or removed.
+	ChangeValues ChangeKind = "values"
+	// ChangeDisplayName means the human-readable label was renamed while the field name stayed the same.
+	ChangeDisplayName ChangeKind = "displayName"
+)
+
+// Report describes schema drift between two common.ListObjectMetadataResult snapshots.
+// It is safe to serialize to JSON and store alongside the snapshots.
+type Report struct {
+	// AddedObjects are present only in the newer snapshot.
+	AddedObjects []string `json:"addedObjects,omitempty"`
+	// RemovedObjects are present only in the older snapshot.
+	RemovedObjects []string `json:"removedObjects,omitempty"`
+	// SkippedObjects could not be compared because either snapshot reported an error for them.
+	SkippedObjects []string `json:"skippedObjects,omitempty"`
+	// Objects holds field level differences for objects present in both snapshots.
+	// Objects without any difference are omitted.
+	Objects map[string]ObjectDiff `json:"objects,omitempty"`
+}
+
+// HasChanges returns true if any drift was detected.
+func (r Report) HasChanges() bool {
+	return len(r.AddedObjects) != 0 || len(r.RemovedObjects) != 0 || len(r.Objects) != 0
+}
+
+// ObjectDiff describes field level drift for a single object.
+type ObjectDiff struct {
+	// DisplayName is the display name from the newer snapshot.
+	DisplayName string `json:"displayName,omitempty"`
+	// AddedFields are fields present only in the newer snapshot.
+	AddedFields []FieldSummary `json:"addedFields,omitempty"`
+	// RemovedFields are fields present only in the older snapshot.
+	// A renamed field shows up as one removed and one added field.
+	RemovedFields []FieldSummary `json:"removedFields,omitempty"`
+	// ChangedFields are fields present in both snapshots whose metadata differs.
+	ChangedFields []FieldDiff `json:"changedFields,omitempty"`
+}
+
+// IsEmpty returns true if there is no drift for the object.
+func (d ObjectDiff) IsEmpty() bool {
+	return len(d.AddedFields) == 0 && len(d.RemovedFields) == 0 && len(d.ChangedFields) == 0
+}
+
+// FieldSummary is a short description of a field that was added or removed.
+type FieldSummary struct {
+	FieldName    string           `json:"fieldName"`
+	DisplayName  string           `json:"displayName,omitempty"`
+	ValueType    common.ValueType `json:"valueType,omitempty"`
+	ProviderType string           `json:"providerType,omitempty"`
+}
+
+// FieldDiff lists every property that changed for a single field.
+type FieldDiff struct {
+	FieldName string         `json:"fieldName"`
+	Changes   []PropertyDiff `json:"changes"`
+}
+
+// Has returns true if the given kind of change is part of this field diff.
+func (d FieldDiff) Has(kind ChangeKind) bool {
+	for _, change := range d.Changes {
+		if change.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRetyped returns true if either Ampersand or provider type changed.
+func (d FieldDiff) IsRetyped() bool {
+	return d.Has(ChangeValueType) || d.Has(ChangeProviderType)
+}
+
+// PropertyDiff holds the old and new value of a single FieldMetadata property.
+// Boolean flags that were unknown (nil) are represented as nil.
+// For ChangeValues, AddedValues and RemovedValues list picklist option values.
+type PropertyDiff struct {
+	Kind          ChangeKind `json:"kind"`
+	Before        any        `json:"before,omitempty"`
+	After         any        `json:"after,omitempty"`
+	AddedValues   []string   `json:"addedValues,omitempty"`
+	RemovedValues []string   `json:"removedValues,omitempty"`
+}
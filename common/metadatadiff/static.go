@@ -0,0 +1,46 @@
+package metadatadiff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/staticschema"
+)
+
+// ErrInvalidSchemasFile is returned when static file content cannot be parsed.
+var ErrInvalidSchemasFile = errors.New("invalid schemas file")
+
+// FromStaticSchemas converts the content of a static schemas.json file into a snapshot
+// for the given module. Every object of the module is included.
+// Both formats of static files are supported, one that stores only display names of fields
+// and the extended one that stores full field metadata.
+func FromStaticSchemas(content []byte, moduleID common.ModuleID) (*common.ListObjectMetadataResult, error) {
+	var extended *staticschema.Metadata[staticschema.FieldMetadataMapV2, any]
+	if err := json.Unmarshal(content, &extended); err == nil {
+		return selectAll(extended, moduleID)
+	}
+
+	var basic *staticschema.Metadata[staticschema.FieldMetadataMapV1, any]
+	if err := json.Unmarshal(content, &basic); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchemasFile, err)
+	}
+
+	return selectAll(basic, moduleID)
+}
+
+func selectAll[F staticschema.FieldMetadataMap](
+	metadata *staticschema.Metadata[F, any], moduleID common.ModuleID,
+) (*common.ListObjectMetadataResult, error) {
+	if metadata == nil {
+		return nil, ErrInvalidSchemasFile
+	}
+
+	objectNames := metadata.ObjectNames().GetList(moduleID)
+	if len(objectNames) == 0 {
+		return common.NewListObjectMetadataResult(), nil
+	}
+
+	return metadata.Select(moduleID, objectNames)
+}
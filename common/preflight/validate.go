@@ -0,0 +1,195 @@
+// Package preflight validates WriteParams.RecordData against ObjectMetadata before it is sent to the provider.
+//
+// Without it, a misspelled field, a read-only field or a bad picklist value is only discovered
+// after a round trip, surfaced as a provider-specific error.
+// The checks are: unknown fields, read-only fields, required fields on create,
+// select values against FieldMetadata.Values and basic type compatibility.
+package preflight
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/coercion"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// Validate checks a record against object metadata.
+// Create mode additionally checks that required fields are present.
+// Field names are matched case-insensitively.
+// Returns nil if there are no violations, otherwise *ValidationError.
+func Validate(objectName string, metadata *common.ObjectMetadata, record map[string]any, isCreate bool) error {
+	schema := newSchema(metadata)
+	violations := make([]Violation, 0)
+
+	for name, value := range record {
+		field, known := schema.lookup(name)
+		if !known {
+			if !schema.isEmpty() {
+				violations = append(violations, Violation{
+					Field:   name,
+					Rule:    RuleUnknownField,
+					Message: "field is not part of the object",
+				})
+			}
+
+			continue
+		}
+
+		violations = append(violations, checkValue(name, field, value)...)
+	}
+
+	if isCreate {
+		violations = append(violations, schema.missingRequired(record)...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(violations, func(a, b Violation) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+
+	return &ValidationError{
+		ObjectName: objectName,
+		Violations: violations,
+	}
+}
+
+func checkValue(name string, field common.FieldMetadata, value any) []Violation {
+	if field.ReadOnly != nil && *field.ReadOnly {
+		return []Violation{{
+			Field:   name,
+			Rule:    RuleReadOnly,
+			Message: "field is read-only",
+		}}
+	}
+
+	if value == nil {
+		// Clearing a value is a valid operation regardless of type.
+		return nil
+	}
+
+	converted, err := coercion.Value(field.ValueType, value)
+	if err != nil {
+		return []Violation{{
+			Field:   name,
+			Rule:    RuleTypeMismatch,
+			Message: fmt.Sprintf("expected %v: %v", field.ValueType, err),
+		}}
+	}
+
+	if len(field.Values) == 0 {
+		return nil
+	}
+
+	var options []string
+
+	switch field.ValueType {
+	case common.ValueTypeSingleSelect:
+		options = []string{converted.(string)} // nolint:forcetypeassert
+	case common.ValueTypeMultiSelect:
+		options = converted.([]string) // nolint:forcetypeassert
+	default:
+		return nil
+	}
+
+	allowed := datautils.NewSetFromList(datautils.ForEach(field.Values, func(value common.FieldValue) string {
+		return value.Value
+	}))
+
+	var violations []Violation
+
+	for _, option := range options {
+		if !allowed.Has(option) {
+			violations = append(violations, Violation{
+				Field:   name,
+				Rule:    RuleInvalidOption,
+				Message: fmt.Sprintf("%q is not an allowed value", option),
+			})
+		}
+	}
+
+	return violations
+}
+
+type schema struct {
+	fields common.FieldsMetadata
+	// index maps lowercase field names to the original name.
+	index map[string]string
+}
+
+func newSchema(metadata *common.ObjectMetadata) schema {
+	fields := make(common.FieldsMetadata)
+
+	if metadata != nil {
+		fields = metadata.Fields
+
+		if len(fields) == 0 {
+			// Legacy metadata only knows field names, type related checks are skipped.
+			fields = make(common.FieldsMetadata)
+			for name, displayName := range metadata.FieldsMap {
+				fields.AddFieldWithDisplayOnly(name, displayName)
+			}
+		}
+	}
+
+	index := make(map[string]string, len(fields))
+	for name := range fields {
+		index[strings.ToLower(name)] = name
+	}
+
+	return schema{
+		fields: fields,
+		index:  index,
+	}
+}
+
+func (s schema) isEmpty() bool {
+	return len(s.fields) == 0
+}
+
+func (s schema) lookup(name string) (common.FieldMetadata, bool) {
+	original, ok := s.index[strings.ToLower(name)]
+	if !ok {
+		return common.FieldMetadata{}, false
+	}
+
+	return s.fields[original], true
+}
+
+func (s schema) missingRequired(record map[string]any) []Violation {
+	provided := datautils.NewStringSet()
+
+	for name, value := range record {
+		if value != nil {
+			provided.AddOne(strings.ToLower(name))
+		}
+	}
+
+	var violations []Violation
+
+	for name, field := range s.fields {
+		if field.IsRequired == nil || !*field.IsRequired {
+			continue
+		}
+
+		if field.ReadOnly != nil && *field.ReadOnly {
+			// System populated fields, ex: id, are often marked both required and read-only.
+			continue
+		}
+
+		if !provided.Has(strings.ToLower(name)) {
+			violations = append(violations, Violation{
+				Field:   name,
+				Rule:    RuleRequired,
+				Message: "field is required on create",
+			})
+		}
+	}
+
+	return violations
+}
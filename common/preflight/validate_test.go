@@ -0,0 +1,167 @@
+// nolint:revive,godoclint
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/goutils"
+	"github.com/go-test/deep"
+)
+
+func contactMetadata() *common.ObjectMetadata {
+	return common.NewObjectMetadata("Contacts", common.FieldsMetadata{
+		"Id": {
+			ValueType:  common.ValueTypeString,
+			ReadOnly:   goutils.Pointer(true),
+			IsRequired: goutils.Pointer(true),
+		},
+		"LastName": {
+			ValueType:  common.ValueTypeString,
+			IsRequired: goutils.Pointer(true),
+		},
+		"Age": {
+			ValueType: common.ValueTypeInt,
+		},
+		"Stage": {
+			ValueType: common.ValueTypeSingleSelect,
+			Values:    []common.FieldValue{{Value: "lead"}, {Value: "customer"}},
+		},
+		"Tags": {
+			ValueType: common.ValueTypeMultiSelect,
+			Values:    []common.FieldValue{{Value: "vip"}, {Value: "partner"}},
+		},
+		"CreatedDate": {
+			ValueType: common.ValueTypeDateTime,
+			ReadOnly:  goutils.Pointer(true),
+		},
+	})
+}
+
+func TestValidate(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		record   map[string]any
+		isCreate bool
+		expected []Violation
+	}{
+		{
+			name: "Valid create",
+			record: map[string]any{
+				"lastname": "Doe",
+				"Age":      "42",
+				"Stage":    "lead",
+				"Tags":     []any{"vip"},
+			},
+			isCreate: true,
+		},
+		{
+			name:   "Update may omit required fields",
+			record: map[string]any{"Age": nil},
+		},
+		{
+			name: "Every rule is violated",
+			record: map[string]any{
+				"Agee":        1,
+				"Age":         4.5,
+				"Stage":       "prospect",
+				"Tags":        "vip;unknown;other",
+				"CreatedDate": "2024-01-01",
+			},
+			isCreate: true,
+			expected: []Violation{
+				{Field: "Age", Rule: RuleTypeMismatch, Message: "expected int: value cannot be converted: 4.5 is not an integer"},
+				{Field: "Agee", Rule: RuleUnknownField, Message: "field is not part of the object"},
+				{Field: "CreatedDate", Rule: RuleReadOnly, Message: "field is read-only"},
+				{Field: "LastName", Rule: RuleRequired, Message: "field is required on create"},
+				{Field: "Stage", Rule: RuleInvalidOption, Message: `"prospect" is not an allowed value`},
+				{Field: "Tags", Rule: RuleInvalidOption, Message: `"unknown" is not an allowed value`},
+				{Field: "Tags", Rule: RuleInvalidOption, Message: `"other" is not an allowed value`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate("contacts", contactMetadata(), tt.record, tt.isCreate)
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, common.ErrCaller) {
+				t.Fatalf("expected caller error, got %v", err)
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected validation error, got %T", err)
+			}
+
+			if diff := deep.Equal(validationErr.Violations, tt.expected); diff != nil {
+				t.Fatalf("unexpected violations: %v", diff)
+			}
+		})
+	}
+}
+
+type fakeConnector struct {
+	writes int
+}
+
+func (f *fakeConnector) Write(context.Context, common.WriteParams) (*common.WriteResult, error) {
+	f.writes++
+
+	return &common.WriteResult{Success: true}, nil
+}
+
+func (f *fakeConnector) ListObjectMetadata(
+	_ context.Context, objectNames []string,
+) (*common.ListObjectMetadataResult, error) {
+	result := common.NewListObjectMetadataResult()
+	result.Result[objectNames[0]] = *contactMetadata()
+
+	return result, nil
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeConnector{}
+	writer := NewWriter(conn, 0)
+
+	_, err := writer.Write(t.Context(), common.WriteParams{
+		ObjectName: "contacts",
+		RecordId:   "003",
+		RecordData: map[string]any{"Stage": "prospect"},
+	})
+	if !errors.Is(err, common.ErrCaller) {
+		t.Fatalf("expected caller error, got %v", err)
+	}
+
+	if conn.writes != 0 {
+		t.Fatal("invalid payload must not reach the provider")
+	}
+
+	_, err = writer.Write(t.Context(), common.WriteParams{
+		ObjectName: "contacts",
+		RecordId:   "003",
+		RecordData: map[string]any{"Stage": "customer"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if conn.writes != 1 {
+		t.Fatal("valid payload must be written")
+	}
+}
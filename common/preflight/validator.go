@@ -0,0 +1,77 @@
+package preflight
+
+import (
+	"context"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/metadatacache"
+)
+
+// Validator checks write payloads using object metadata obtained from the connector.
+// Metadata is cached per object, see metadatacache.Cache.
+type Validator struct {
+	metadata *metadatacache.Cache
+}
+
+// NewValidator creates a validator. Zero TTL keeps metadata for the lifetime of the Validator.
+func NewValidator(source metadatacache.Source, ttl time.Duration) *Validator {
+	return &Validator{
+		metadata: metadatacache.New(source, ttl),
+	}
+}
+
+// Validate checks WriteParams.RecordData. Missing RecordId implies record creation.
+// Returns *ValidationError wrapping common.ErrCaller when the payload has violations.
+// Other errors originate from loading metadata.
+func (v *Validator) Validate(ctx context.Context, params common.WriteParams) error {
+	if err := params.ValidateParams(); err != nil {
+		return err
+	}
+
+	metadata, err := v.metadata.Get(ctx, params.ObjectName)
+	if err != nil {
+		return err
+	}
+
+	record, err := params.GetRecord()
+	if err != nil {
+		return err
+	}
+
+	return Validate(params.ObjectName, metadata, record, params.RecordId == "")
+}
+
+// Invalidate forgets cached metadata, forcing it to be fetched on the next validation.
+func (v *Validator) Invalidate(objectNames ...string) {
+	v.metadata.Invalidate(objectNames...)
+}
+
+// Connector is a connector capable of both writing and describing objects.
+type Connector interface {
+	Write(ctx context.Context, params common.WriteParams) (*common.WriteResult, error)
+	ListObjectMetadata(ctx context.Context, objectNames []string) (*common.ListObjectMetadataResult, error)
+}
+
+// Writer validates every payload before delegating Write to the underlying connector.
+type Writer struct {
+	Connector
+
+	validator *Validator
+}
+
+// NewWriter wraps the connector. Zero TTL keeps metadata for the lifetime of the Writer.
+func NewWriter(conn Connector, ttl time.Duration) *Writer {
+	return &Writer{
+		Connector: conn,
+		validator: NewValidator(conn, ttl),
+	}
+}
+
+func (w *Writer) Write(ctx context.Context, params common.WriteParams) (*common.WriteResult, error) {
+	if err := w.validator.Validate(ctx, params); err != nil {
+		return nil, err
+	}
+
+	return w.Connector.Write(ctx, params)
+}
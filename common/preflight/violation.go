@@ -0,0 +1,67 @@
+package preflight
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Rule names the check that a write payload failed.
+type Rule string
+
+const (
+	// RuleUnknownField means the field is not described by object metadata, likely a typo.
+	RuleUnknownField Rule = "unknownField"
+	// RuleReadOnly means the field cannot be modified.
+	RuleReadOnly Rule = "readOnly"
+	// RuleRequired means the field must be provided when creating a record.
+	RuleRequired Rule = "required"
+	// RuleInvalidOption means the value is not one of the allowed picklist values.
+	RuleInvalidOption Rule = "invalidOption"
+	// RuleTypeMismatch means the value cannot represent the field's ValueType.
+	RuleTypeMismatch Rule = "typeMismatch"
+)
+
+// Violation is a single problem found in the write payload.
+type Violation struct {
+	Field   string `json:"field"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%v: %v", v.Field, v.Message)
+}
+
+// ValidationError lists every violation found in the payload.
+// It wraps common.ErrCaller, the request should not be retried without changing the payload.
+type ValidationError struct {
+	ObjectName string      `json:"objectName"`
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for index, violation := range e.Violations {
+		messages[index] = violation.String()
+	}
+
+	return fmt.Sprintf("%v: invalid %v payload: %v",
+		common.ErrCaller, e.ObjectName, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return common.ErrCaller
+}
+
+// Has returns true if any violation is of the given rule.
+func (e *ValidationError) Has(rule Rule) bool {
+	for _, violation := range e.Violations {
+		if violation.Rule == rule {
+			return true
+		}
+	}
+
+	return false
+}
@@ -62,6 +62,11 @@ func logRequestWithoutBody(logger *slog.Logger, req *http.Request, method, id, f
 }
 
 func logResponseWithoutBody(logger *slog.Logger, res *http.Response, method, id, fullURL string) {
+	// There is no response when the request failed in transport, ex: connection closed mid-body.
+	if res == nil {
+		return
+	}
+
 	headers := redactSensitiveResponseHeaders(GetResponseHeaders(res))
 
 	logger = logger.With(
@@ -78,6 +83,10 @@ func logResponseWithoutBody(logger *slog.Logger, res *http.Response, method, id,
 }
 
 func logResponseWithBody(logger *slog.Logger, res *http.Response, method, id, fullURL string, body []byte) {
+	if res == nil {
+		return
+	}
+
 	headers := redactSensitiveResponseHeaders(GetResponseHeaders(res))
 
 	logger = logger.With(
@@ -162,6 +162,8 @@ type ReadParams struct {
 	//		Note: Only supported when reading Lead Activities (not other endpoints).
 	//		Example: "1,6,12" (for visitWebpage, fillOutForm, emailClicked)
 	//		Reference: https://developer.adobe.com/marketo-apis/api/mapi/#tag/Activities
+	//	* DeepMock: SQL-like condition over schema fields with an optional ORDER BY clause.
+	//		Example: "age >= 18 AND name LIKE 'A%' ORDER BY age DESC"
 	Filter string // optional
 
 	// AssociatedObjects specifies a list of related objects to fetch along with the main object.
@@ -172,6 +174,7 @@ type ReadParams struct {
 	//		Reference: https://docs.stripe.com/expand#how-it-works
 	//	* Capsule: Embeds objects in response.
 	//		Reference: https://developer.capsulecrm.com/v2/overview/reading-from-the-api
+	//	* DeepMock: Objects related through x-amp-ref schema fields, in either direction.
 	AssociatedObjects []string // optional
 
 	// PageSize specifies the # of records to request when making a read request.
@@ -273,6 +276,20 @@ type ReadResultRow struct {
 	Raw map[string]any `json:"raw"`
 	// RecordId is the ID of the record. Currently only populated for hubspot GetRecord and GetRecordsWithId function
 	Id string `json:"id,omitempty"`
+	// ConversionErrors lists fields whose values couldn't be converted to the type declared by object metadata.
+	// Populated only when typed reading is enabled, see the coercion package.
+	// Such fields keep the original provider value.
+	ConversionErrors []FieldConversionError `json:"conversionErrors,omitempty"`
+}
+
+// FieldConversionError describes a field value that doesn't match the declared ValueType.
+type FieldConversionError struct {
+	// Field is the lowercase field name as it appears in ReadResultRow.Fields.
+	Field string `json:"field"`
+	// ValueType is the type the value was expected to be converted to.
+	ValueType ValueType `json:"valueType"`
+	// Message explains why the conversion failed.
+	Message string `json:"message"`
 }
 
 // Association is a struct that represents an association between two objects.
@@ -53,36 +53,43 @@ type XMLHTTPResponse struct {
 // refresh the access token and retry the request. If errorHandler is nil, then the default error
 // handler is used. If not, the caller can inject their own error handling logic.
 func (c *XMLHTTPClient) Get(ctx context.Context, url string, headers ...Header) (*XMLHTTPResponse, error) {
-	res, body, err := c.HTTPClient.Get(ctx, url, addAcceptXMLHeader(headers)...) //nolint:bodyclose
+	res, err := c.contentClient().Get(ctx, url, addAcceptXMLHeader(headers)...)
 	if err != nil {
-		return nil, c.ErrorPostProcessor.handleError(err)
+		return nil, err
 	}
 
-	return parseXMLResponse(res, body)
+	return parseXMLResponse(res)
 }
 
 // Post sends XML request and receives XML as a response.
 func (c *XMLHTTPClient) Post(ctx context.Context,
 	url string, node *xquery.XML, headers ...Header,
 ) (*XMLHTTPResponse, error) {
-	data := []byte(node.RawXML())
-
-	res, body, err := c.HTTPClient.Post(ctx, url, data, addAcceptXMLHeader(headers)...) //nolint:bodyclose
+	res, err := c.contentClient().Post(ctx, url, XMLPayload(node), addAcceptXMLHeader(headers)...)
 	if err != nil {
-		return nil, c.ErrorPostProcessor.handleError(err)
+		return nil, err
 	}
 
-	return parseXMLResponse(res, body)
+	return parseXMLResponse(res)
+}
+
+// contentClient performs the exchange, XMLHTTPClient only enforces that the response is XML.
+func (c *XMLHTTPClient) contentClient() *ContentHTTPClient {
+	return &ContentHTTPClient{
+		HTTPClient:         c.HTTPClient,
+		ErrorPostProcessor: c.ErrorPostProcessor,
+		Decoders:           NewDecoders(XMLCodec{}),
+	}
 }
 
 // parseXMLResponse parses the given HTTP response and returns a XMLHTTPResponse.
-func parseXMLResponse(res *http.Response, body []byte) (*XMLHTTPResponse, error) {
-	if len(body) == 0 {
+func parseXMLResponse(res *ContentHTTPResponse) (*XMLHTTPResponse, error) {
+	if res.IsEmpty() {
 		// Empty XML response is not allowed
 		return nil, ErrNotXML
 	}
 	// Ensure the response is XML
-	ct := res.Header.Get("Content-Type")
+	ct := res.ContentType()
 	if len(ct) > 0 {
 		mimeType, _, err := mime.ParseMediaType(ct)
 		if err != nil {
@@ -95,18 +102,18 @@ func parseXMLResponse(res *http.Response, body []byte) (*XMLHTTPResponse, error)
 	}
 
 	// Unmarshall the response body into XML
-	xmlBody, err := xquery.NewXML(body)
+	xmlBody, err := xquery.NewXML(res.Bytes())
 	if err != nil {
-		headers := GetResponseHeaders(res)
+		headers := GetResponseHeaders(&http.Response{Header: res.Headers})
 
-		return nil, NewHTTPError(res.StatusCode, body, headers,
+		return nil, NewHTTPError(res.Code, res.Bytes(), headers,
 			fmt.Errorf("failed to unmarshall response body into XML: %w", err))
 	}
 
 	return &XMLHTTPResponse{
-		bodyBytes: body,
-		Code:      res.StatusCode,
-		Headers:   res.Header,
+		bodyBytes: res.Bytes(),
+		Code:      res.Code,
+		Headers:   res.Headers,
 		Body:      xmlBody,
 	}, nil
 }
@@ -96,6 +96,7 @@ import (
 	"github.com/amp-labs/connectors/providers/seismic"
 	"github.com/amp-labs/connectors/providers/sellsy"
 	"github.com/amp-labs/connectors/providers/servicenow"
+	"github.com/amp-labs/connectors/providers/shopify"
 	"github.com/amp-labs/connectors/providers/smartlead"
 	"github.com/amp-labs/connectors/providers/snapchatads"
 	"github.com/amp-labs/connectors/providers/solarwinds"
@@ -210,6 +211,7 @@ var connectorConstructors = map[providers.Provider]outputConstructorFunc{ // nol
 	providers.Seismic:                 wrapper(newSeismicConnector),
 	providers.Sellsy:                  wrapper(newSellsyConnector),
 	providers.ServiceNow:              wrapper(newServiceNowConnector),
+	providers.Shopify:                 wrapper(newShopifyConnector),
 	providers.Smartlead:               wrapper(newSmartleadConnector),
 	providers.SnapchatAds:             wrapper(newSnapchatAdsConnector),
 	providers.SolarWindsServiceDesk:   wrapper(newSolarWindsConnector),
@@ -904,3 +906,7 @@ func newAcuitySchedulingConnector(
 ) (*acuityscheduling.Connector, error) {
 	return acuityscheduling.NewConnector(params)
 }
+
+func newShopifyConnector(params common.ConnectorParams) (*shopify.Connector, error) {
+	return shopify.NewConnector(params)
+}
@@ -86,6 +86,48 @@ type BatchWriteConnector interface {
 	BatchWrite(ctx context.Context, params *common.BatchWriteParam) (*common.BatchWriteResult, error)
 }
 
+// CompositeWriteConnector writes several records, possibly of different objects, in a single transaction.
+// Later writes can use identifiers of records created by earlier ones.
+type CompositeWriteConnector interface {
+	Connector
+
+	// CompositeWrite writes all nodes or none of them.
+	// Node failures are reported in the result, while errors represent connector-level issues.
+	CompositeWrite(ctx context.Context, params *common.CompositeWriteParams) (*common.CompositeWriteResult, error)
+}
+
+// AssociationConnector manages links between records, ex: a contact belonging to a company.
+// Listed associations have the same shape as ReadResultRow.Associations returned by reads.
+type AssociationConnector interface {
+	Connector
+
+	// ListAssociations returns records of params.ToObject linked to the given record.
+	ListAssociations(ctx context.Context, params ListAssociationsParams) (*ListAssociationsResult, error)
+
+	// CreateAssociation links two records. Linking records which are already linked is not an error.
+	CreateAssociation(ctx context.Context, descriptor AssociationDescriptor) (*WriteResult, error)
+
+	// DeleteAssociation unlinks two records. The records themselves are kept.
+	// Unlinking records which are not linked is not an error.
+	DeleteAssociation(ctx context.Context, descriptor AssociationDescriptor) (*DeleteResult, error)
+}
+
+// FileConnector is an interface that extends the Connector interface with
+// the ability to upload and download files.
+// File content is streamed in both directions and is never fully held in memory.
+// Implementations use resumable or chunked uploads where the provider offers them for the stored object.
+// Salesforce ContentVersion and HubSpot Files accept a file only as one multipart request,
+// so both stream the file in a single request, and a failed upload has to be started over.
+type FileConnector interface {
+	Connector
+
+	// UploadFile reads params.Content until EOF and stores it as a file.
+	UploadFile(ctx context.Context, params FileUploadParams) (*FileUploadResult, error)
+
+	// DownloadFile opens the file for reading. The caller must close the returned content.
+	DownloadFile(ctx context.Context, params FileDownloadParams) (*FileDownloadResult, error)
+}
+
 // ObjectMetadataConnector is an interface that extends the Connector interface with
 // the ability to list object metadata.
 type ObjectMetadataConnector interface {
@@ -218,7 +260,19 @@ type (
 	BatchWriteType           = common.BatchWriteType
 	BatchWriteResult         = common.BatchWriteResult
 	BatchStatus              = common.BatchStatus
+	CompositeWriteParams     = common.CompositeWriteParams
+	CompositeWriteNode       = common.CompositeWriteNode
+	CompositeWriteResult     = common.CompositeWriteResult
 	ListObjectMetadataResult = common.ListObjectMetadataResult
+	Association              = common.Association
+	AssociationType          = common.AssociationType
+	AssociationDescriptor    = common.AssociationDescriptor
+	ListAssociationsParams   = common.ListAssociationsParams
+	ListAssociationsResult   = common.ListAssociationsResult
+	FileUploadParams         = common.FileUploadParams
+	FileUploadResult         = common.FileUploadResult
+	FileDownloadParams       = common.FileDownloadParams
+	FileDownloadResult       = common.FileDownloadResult
 
 	ErrorWithStatus = common.HTTPError //nolint:errname
 )
@@ -0,0 +1,192 @@
+package deepmock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// references maps object name -> field name -> name of the referenced object.
+// They are declared with x-amp-ref on a property holding an ID, or an array of IDs.
+type references map[string]map[string]string
+
+// extractReferences collects x-amp-ref declarations of all raw schemas.
+func extractReferences(schemas map[string][]byte) references {
+	refs := make(references)
+
+	for objectName, rawSchema := range schemas {
+		var schemaMap map[string]any
+		if err := json.Unmarshal(rawSchema, &schemaMap); err != nil {
+			continue
+		}
+
+		properties, ok := schemaMap["properties"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for fieldName, fieldDef := range properties {
+			target := referenceTarget(fieldDef)
+			if target == "" {
+				continue
+			}
+
+			if refs[objectName] == nil {
+				refs[objectName] = make(map[string]string)
+			}
+
+			refs[objectName][fieldName] = target
+		}
+	}
+
+	return refs
+}
+
+// referenceTarget returns x-amp-ref of a property, or of its items for arrays of IDs.
+func referenceTarget(fieldDef any) string {
+	fieldMap, ok := fieldDef.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	if target, ok := fieldMap["x-amp-ref"].(string); ok {
+		return target
+	}
+
+	if items, ok := fieldMap["items"].(map[string]any); ok {
+		if target, ok := items["x-amp-ref"].(string); ok {
+			return target
+		}
+	}
+
+	return ""
+}
+
+// fieldsReferencing returns fields of the object which point at the target object, sorted.
+func (r references) fieldsReferencing(objectName, target string) []string {
+	var fields []string
+
+	for field, referenced := range r[objectName] {
+		if referenced == target {
+			fields = append(fields, field)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return fields
+}
+
+// referenceIDs returns IDs held by a reference field, either a single ID or an array of them.
+func referenceIDs(value any) []string {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		ids := make([]string, 0, len(value))
+
+		for _, item := range value {
+			if item != nil {
+				ids = append(ids, fmt.Sprint(item))
+			}
+		}
+
+		return ids
+	default:
+		return []string{fmt.Sprint(value)}
+	}
+}
+
+// associate populates Associations of the rows with records of the associated objects.
+// Relationships work both ways: a contact with a companyId field pointing at companies is associated
+// with its company, and a company is associated with every contact pointing at it.
+// The association type is the name of the reference field.
+func (c *Connector) associate(objectName string, rows []common.ReadResultRow, associatedObjects []string) error {
+	for _, associatedObject := range associatedObjects {
+		forward := c.references.fieldsReferencing(objectName, associatedObject)
+		reverse := c.references.fieldsReferencing(associatedObject, objectName)
+
+		if len(forward) == 0 && len(reverse) == 0 {
+			return fmt.Errorf("%w: %s to %s", ErrUnknownAssociation, objectName, associatedObject)
+		}
+
+		var related []map[string]any
+
+		if len(reverse) != 0 {
+			var err error
+
+			related, err = c.storage.List(associatedObject, time.Time{}, time.Time{})
+			if err != nil {
+				return err
+			}
+		}
+
+		for index := range rows {
+			associations, err := c.associations(rows[index], associatedObject, forward, reverse, related)
+			if err != nil {
+				return err
+			}
+
+			if len(associations) == 0 {
+				continue
+			}
+
+			if rows[index].Associations == nil {
+				rows[index].Associations = make(map[string][]common.Association)
+			}
+
+			rows[index].Associations[associatedObject] = associations
+		}
+	}
+
+	return nil
+}
+
+func (c *Connector) associations(
+	row common.ReadResultRow, associatedObject string, forward, reverse []string, related []map[string]any,
+) ([]common.Association, error) {
+	var associations []common.Association
+
+	for _, field := range forward {
+		for _, id := range referenceIDs(row.Raw[field]) {
+			record, err := c.storage.Get(associatedObject, id)
+			if err != nil {
+				if errors.Is(err, ErrRecordNotFound) {
+					// Dangling references are skipped, the same way providers hide deleted records.
+					continue
+				}
+
+				return nil, err
+			}
+
+			associations = append(associations, common.Association{
+				ObjectId:        id,
+				AssociationType: field,
+				Raw:             record,
+			})
+		}
+	}
+
+	if row.Id == "" {
+		return associations, nil
+	}
+
+	for _, field := range reverse {
+		for _, record := range related {
+			if slices.Contains(referenceIDs(record[field]), row.Id) {
+				associations = append(associations, common.Association{
+					ObjectId:        c.storage.recordID(associatedObject, record),
+					AssociationType: field,
+					Raw:             maps.Clone(record),
+				})
+			}
+		}
+	}
+
+	return associations, nil
+}
@@ -0,0 +1,152 @@
+package deepmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCRMConnector(t *testing.T) *Connector {
+	t.Helper()
+
+	conn, err := NewConnector(WithRawSchemas(map[string][]byte{
+		"companies": []byte(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "string", "x-amp-id-field": true},
+				"name": {"type": "string"}
+			}
+		}`),
+		"contacts": []byte(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "string", "x-amp-id-field": true},
+				"name": {"type": "string"},
+				"companyId": {"type": "string", "x-amp-ref": "companies"},
+				"partnerIds": {"type": "array", "items": {"type": "string", "x-amp-ref": "companies"}}
+			}
+		}`),
+		"notes": []byte(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "string", "x-amp-id-field": true}
+			}
+		}`),
+	}))
+	require.NoError(t, err)
+
+	return conn
+}
+
+func TestAssociations(t *testing.T) {
+	t.Parallel()
+
+	conn := newCRMConnector(t)
+	ctx := context.Background()
+
+	result, err := conn.BatchWrite(ctx, &common.BatchWriteParam{
+		ObjectName: "companies",
+		Type:       common.BatchWriteTypeCreate,
+		Batch: common.BatchItems{
+			{Record: map[string]any{"id": "acme", "name": "Acme"}},
+			{Record: map[string]any{"id": "globex", "name": "Globex"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.BatchStatusSuccess, result.Status)
+
+	for _, contact := range []map[string]any{
+		{"id": "jane", "name": "Jane", "companyId": "acme", "partnerIds": []any{"globex", "deleted"}},
+		{"id": "john", "name": "John", "companyId": "acme"},
+		{"id": "kate", "name": "Kate"},
+	} {
+		_, err := conn.Write(ctx, common.WriteParams{ObjectName: "contacts", RecordData: contact})
+		require.NoError(t, err)
+	}
+
+	// Contacts point at companies, dangling references are skipped.
+	page, err := conn.Read(ctx, common.ReadParams{
+		ObjectName:        "contacts",
+		Fields:            datautils.NewStringSet("name"),
+		AssociatedObjects: []string{"companies"},
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 3)
+
+	jane := page.Data[0].Associations["companies"]
+	require.Len(t, jane, 2)
+	assert.Equal(t, "acme", jane[0].ObjectId)
+	assert.Equal(t, "companyId", jane[0].AssociationType)
+	assert.Equal(t, "Acme", jane[0].Raw["name"])
+	assert.Equal(t, "globex", jane[1].ObjectId)
+	assert.Equal(t, "partnerIds", jane[1].AssociationType)
+	assert.Nil(t, page.Data[2].Associations)
+
+	// Companies are associated with contacts pointing at them.
+	rows, err := conn.GetRecordsByIds(ctx, "companies", []string{"acme", "globex"}, []string{"name"}, []string{"contacts"})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	acme := rows[0].Associations["contacts"]
+	require.Len(t, acme, 2)
+	assert.Equal(t, []string{"jane", "john"}, []string{acme[0].ObjectId, acme[1].ObjectId})
+	assert.Equal(t, []common.Association{{
+		ObjectId:        "jane",
+		AssociationType: "partnerIds",
+		Raw:             rows[1].Associations["contacts"][0].Raw,
+	}}, rows[1].Associations["contacts"])
+
+	_, err = conn.Read(ctx, common.ReadParams{
+		ObjectName:        "contacts",
+		Fields:            datautils.NewStringSet("name"),
+		AssociatedObjects: []string{"notes"},
+	})
+	require.ErrorIs(t, err, ErrUnknownAssociation)
+}
+
+func TestBatchWrite(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	created, err := conn.BatchWrite(ctx, &common.BatchWriteParam{
+		ObjectName: "persons",
+		Type:       common.BatchWriteTypeCreate,
+		Batch: common.BatchItems{
+			{Record: map[string]any{"id": "a", "name": "A", "email": "a@example.com"}},
+			{Record: map[string]any{"name": "No Email"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, common.BatchStatusPartial, created.Status)
+	assert.Equal(t, 1, created.SuccessCount)
+	assert.Equal(t, 1, created.FailureCount)
+	require.Len(t, created.Results, 2)
+	assert.Equal(t, "a", created.Results[0].RecordId)
+	assert.False(t, created.Results[1].Success)
+	assert.Contains(t, created.Results[1].Errors[0], ErrValidationFailed.Error())
+
+	updated, err := conn.BatchWrite(ctx, &common.BatchWriteParam{
+		ObjectName: "persons",
+		Type:       common.BatchWriteTypeUpdate,
+		Batch: common.BatchItems{
+			{Record: map[string]any{"id": "a", "name": "Renamed"}},
+			{Record: map[string]any{"name": "Without ID"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, common.BatchStatusPartial, updated.Status)
+	assert.Equal(t, []any{"missing required parameter: record id"}, updated.Results[1].Errors)
+
+	stored, err := conn.storage.Get("persons", "a")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", stored["name"])
+	assert.Equal(t, "a@example.com", stored["email"])
+}
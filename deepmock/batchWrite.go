@@ -0,0 +1,68 @@
+package deepmock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// BatchWrite creates or updates records one by one, each record succeeds or fails on its own.
+// Updated records must hold their ID in the x-amp-id-field of the schema.
+// Results are in the order of the batch, failed records carry the message of the validation error.
+func (c *Connector) BatchWrite(ctx context.Context, params *common.BatchWriteParam) (*common.BatchWriteResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	objectName := string(params.ObjectName)
+
+	if _, exists := c.schemas.Get(objectName); !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, objectName)
+	}
+
+	if err := c.injectFault(ctx, operationWrite, objectName, false); err != nil {
+		return nil, err
+	}
+
+	results := make([]common.WriteResult, len(params.Batch))
+	successes := 0
+
+	for index, item := range params.Batch {
+		results[index] = c.batchWriteItem(objectName, params.IsUpdate(), item)
+
+		if results[index].Success {
+			successes++
+		}
+	}
+
+	return common.NewBatchWriteResult(results, successes, len(results), nil)
+}
+
+func (c *Connector) batchWriteItem(objectName string, update bool, item common.BatchItem) common.WriteResult {
+	writeParams := common.WriteParams{
+		ObjectName:   objectName,
+		RecordData:   item.Record,
+		Associations: item.Associations,
+	}
+
+	if update {
+		if item.Record[c.storage.idFields[ObjectName(objectName)]] == nil {
+			return common.WriteResult{
+				Errors: []any{fmt.Errorf("%w: record id", ErrMissingParam).Error()},
+			}
+		}
+
+		writeParams.RecordId = c.storage.recordID(objectName, item.Record)
+	}
+
+	result, err := c.write(writeParams)
+	if err != nil {
+		return common.WriteResult{
+			RecordId: writeParams.RecordId,
+			Errors:   []any{err.Error()},
+		}
+	}
+
+	return *result
+}
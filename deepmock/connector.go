@@ -3,9 +3,13 @@ package deepmock
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -52,11 +56,13 @@ const (
 
 // Connector is an in-memory mock connector with JSON schema validation.
 type Connector struct {
-	client    *common.JSONHTTPClient
-	params    *parameters
-	schemas   schemaRegistry
-	storage   *Storage
-	observers []func(action string, record map[string]any)
+	client        *common.JSONHTTPClient
+	params        *parameters
+	schemas       schemaRegistry
+	storage       *Storage
+	observers     []func(action string, record map[string]any)
+	subscriptions *subscriptions
+	references    references
 }
 
 // Compile-time interface checks.
@@ -64,8 +70,10 @@ var (
 	_ connectors.Connector               = (*Connector)(nil)
 	_ connectors.ReadConnector           = (*Connector)(nil)
 	_ connectors.WriteConnector          = (*Connector)(nil)
+	_ connectors.BatchWriteConnector     = (*Connector)(nil)
 	_ connectors.DeleteConnector         = (*Connector)(nil)
 	_ connectors.ObjectMetadataConnector = (*Connector)(nil)
+	_ connectors.SubscribeConnector      = (*Connector)(nil)
 )
 
 // NewConnector creates a new deepmock connector instance.
@@ -96,16 +104,27 @@ func NewConnector(opts ...Option) (*Connector, error) {
 
 	// Initialize storage with parsed schemas and special fields
 	storage := NewStorage(parsedSchemas, idFields, updatedFields)
+	storage.retention = params.tombstoneRetention
 
-	return &Connector{
+	conn := &Connector{
 		client: &common.JSONHTTPClient{
 			HTTPClient: params.Caller,
 		},
-		params:    params,
-		schemas:   parsedSchemas,
-		storage:   storage,
-		observers: params.observers,
-	}, nil
+		params:        params,
+		schemas:       parsedSchemas,
+		storage:       storage,
+		observers:     params.observers,
+		subscriptions: newSubscriptions(),
+		references:    extractReferences(finalSchemas),
+	}
+
+	if params.fixtures != nil {
+		if err := conn.loadFixtures(params.fixtures, params.fixturePatterns); err != nil {
+			return nil, fmt.Errorf("failed to load fixtures: %w", err)
+		}
+	}
+
+	return conn, nil
 }
 
 // String returns the connector name.
@@ -113,6 +132,11 @@ func (c *Connector) String() string {
 	return "deepmock"
 }
 
+// Storage returns the storage of the connector, ex: to snapshot records of a test.
+func (c *Connector) Storage() *Storage {
+	return c.storage
+}
+
 // JSONHTTPClient returns the JSON HTTP client.
 func (c *Connector) JSONHTTPClient() *common.JSONHTTPClient {
 	return c.client
@@ -129,25 +153,54 @@ func (c *Connector) Provider() providers.Provider {
 }
 
 // Read retrieves records for an object with pagination and filtering.
-//
+// ReadParams.Filter is a condition over schema fields, optionally followed by ORDER BY,
+// ex: `age >= 18 AND (name LIKE 'A%' OR email IS NULL) ORDER BY age DESC`.
+// AssociatedObjects lists objects related through x-amp-ref fields, in either direction.
+func (c *Connector) Read(ctx context.Context, params common.ReadParams) (*common.ReadResult, error) {
+	if err := c.injectFault(ctx, operationRead, params.ObjectName, params.NextPage != ""); err != nil {
+		return nil, err
+	}
+
+	return c.read(params)
+}
+
 //nolint:cyclop,funlen // Complexity from pagination, filtering, field selection logic
-func (c *Connector) Read(_ context.Context, params common.ReadParams) (*common.ReadResult, error) {
+func (c *Connector) read(params common.ReadParams) (*common.ReadResult, error) {
 	// Validate parameters
 	if err := params.ValidateParams(true); err != nil {
 		return nil, err
 	}
 
 	// Check if object schema exists
-	if _, exists := c.schemas.Get(params.ObjectName); !exists {
+	schema, exists := c.schemas.Get(params.ObjectName)
+	if !exists {
 		return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, params.ObjectName)
 	}
 
-	// Get records from storage with time filtering
-	records, err := c.storage.List(params.ObjectName, params.Since, params.Until)
+	filter, err := parseReadFilter(params.Filter, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get records from storage with time filtering.
+	// Deleted records are filtered by the time of deletion.
+	var records []map[string]any
+
+	if params.Deleted {
+		records, err = c.storage.ListDeleted(params.ObjectName, params.Since, params.Until)
+	} else {
+		records, err = c.storage.List(params.ObjectName, params.Since, params.Until)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to list records: %w", err)
 	}
 
+	records = slices.DeleteFunc(records, func(record map[string]any) bool {
+		return !filter.matches(record)
+	})
+	filter.sort(records)
+
 	// Parse pagination parameters
 	offset := 0
 
@@ -185,28 +238,17 @@ func (c *Connector) Read(_ context.Context, params common.ReadParams) (*common.R
 		// Build fields map with lowercase keys
 		// If specific fields are requested, only include those fields
 		// Otherwise, include all fields from the record
-		fields := make(map[string]any)
-
-		if len(params.Fields) > 0 {
-			// Field filtering: only include requested fields
-			for field := range params.Fields {
-				if value, exists := record[field]; exists {
-					fields[strings.ToLower(field)] = value
-				}
-			}
-		} else {
-			// No field filtering: include all fields
-			for key, value := range record {
-				fields[strings.ToLower(key)] = value
-			}
-		}
-
 		rows[index] = common.ReadResultRow{
-			Fields: fields,
+			Fields: selectFields(record, params.Fields.List()),
+			Id:     c.storage.recordID(params.ObjectName, record),
 			Raw:    record, // Always include full record in Raw
 		}
 	}
 
+	if err := c.associate(params.ObjectName, rows, params.AssociatedObjects); err != nil {
+		return nil, err
+	}
+
 	// Calculate next page token
 	var (
 		nextPage common.NextPageToken
@@ -227,9 +269,16 @@ func (c *Connector) Read(_ context.Context, params common.ReadParams) (*common.R
 }
 
 // Write creates or updates a record.
-//
+func (c *Connector) Write(ctx context.Context, params common.WriteParams) (*common.WriteResult, error) {
+	if err := c.injectFault(ctx, operationWrite, params.ObjectName, false); err != nil {
+		return nil, err
+	}
+
+	return c.write(params)
+}
+
 //nolint:cyclop,funlen,nestif // Complexity from create/update branching and ID/timestamp generation logic
-func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common.WriteResult, error) {
+func (c *Connector) write(params common.WriteParams) (*common.WriteResult, error) {
 	// Validate parameters
 	if err := params.ValidateParams(); err != nil {
 		return nil, err
@@ -248,8 +297,10 @@ func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common
 	}
 
 	var (
-		recordID    string
-		finalRecord map[string]any
+		recordID      string
+		finalRecord   map[string]any
+		updatedFields []string
+		eventType     = common.SubscriptionEventTypeCreate
 	)
 
 	// Determine operation (create vs update)
@@ -278,6 +329,7 @@ func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common
 		}
 
 		finalRecord = recordMap
+		updatedFields = slices.Sorted(maps.Keys(recordMap))
 	} else {
 		// UPDATE operation
 		recordID = params.RecordId
@@ -288,9 +340,12 @@ func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common
 			return nil, fmt.Errorf("failed to retrieve existing record: %w", err)
 		}
 
-		// Merge new data with existing
-		//nolint:modernize // Manual merge to preserve existing fields not in recordMap
+		// Merge new data with existing, remembering which fields have changed
 		for key, value := range recordMap {
+			if previous, exists := existing[key]; !exists || !reflect.DeepEqual(previous, value) {
+				updatedFields = append(updatedFields, key)
+			}
+
 			existing[key] = value
 		}
 
@@ -300,10 +355,14 @@ func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common
 			// Only auto-generate if not explicitly provided in the update data
 			if _, providedInUpdate := recordMap[updatedField]; !providedInUpdate {
 				existing[updatedField] = generateTimestamp(schema, updatedField)
+				updatedFields = append(updatedFields, updatedField)
 			}
 		}
 
 		finalRecord = existing
+		eventType = common.SubscriptionEventTypeUpdate
+
+		slices.Sort(updatedFields)
 	}
 
 	// Validate record against schema
@@ -334,6 +393,8 @@ func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common
 		}
 	}
 
+	c.emitWebhooks(eventType, params.ObjectName, recordID, recordCopy, updatedFields)
+
 	return &common.WriteResult{
 		Success:  true,
 		RecordId: recordID,
@@ -341,8 +402,16 @@ func (c *Connector) Write(_ context.Context, params common.WriteParams) (*common
 	}, nil
 }
 
-// Delete removes a record.
-func (c *Connector) Delete(_ context.Context, params connectors.DeleteParams) (*connectors.DeleteResult, error) {
+// Delete removes a record. The record remains readable with ReadParams.Deleted until retention expires.
+func (c *Connector) Delete(ctx context.Context, params connectors.DeleteParams) (*connectors.DeleteResult, error) {
+	if err := c.injectFault(ctx, operationDelete, params.ObjectName, false); err != nil {
+		return nil, err
+	}
+
+	return c.delete(params)
+}
+
+func (c *Connector) delete(params connectors.DeleteParams) (*connectors.DeleteResult, error) {
 	// Validate parameters
 	if err := params.ValidateParams(); err != nil {
 		return nil, err
@@ -369,16 +438,65 @@ func (c *Connector) Delete(_ context.Context, params connectors.DeleteParams) (*
 		c.sendRecordToObserver("delete", record, observe)
 	}
 
+	c.emitWebhooks(common.SubscriptionEventTypeDelete, params.ObjectName, params.RecordId, record, nil)
+
 	return &connectors.DeleteResult{
 		Success: true,
 	}, nil
 }
 
+// GetRecordsByIds returns stored records with the given IDs. Unknown IDs are skipped.
+// Associations are resolved the same way as AssociatedObjects of Read.
+func (c *Connector) GetRecordsByIds( //nolint:revive
+	ctx context.Context,
+	objectName string,
+	recordIds []string, //nolint:revive
+	fields []string,
+	associations []string,
+) ([]common.ReadResultRow, error) {
+	if err := c.injectFault(ctx, operationRead, objectName, false); err != nil {
+		return nil, err
+	}
+
+	if _, exists := c.schemas.Get(objectName); !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, objectName)
+	}
+
+	rows := make([]common.ReadResultRow, 0, len(recordIds))
+
+	for _, recordID := range recordIds {
+		record, err := c.storage.Get(objectName, recordID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		rows = append(rows, common.ReadResultRow{
+			Fields: selectFields(record, fields),
+			Id:     recordID,
+			Raw:    record,
+		})
+	}
+
+	if err := c.associate(objectName, rows, associations); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
 // ListObjectMetadata returns metadata for specified objects.
 func (c *Connector) ListObjectMetadata(
-	_ context.Context,
+	ctx context.Context,
 	objectNames []string,
 ) (*common.ListObjectMetadataResult, error) {
+	if err := c.injectFault(ctx, operationMetadata, "", false); err != nil {
+		return nil, err
+	}
+
 	if len(objectNames) == 0 {
 		return nil, fmt.Errorf("%w: objectNames", ErrMissingParam)
 	}
@@ -1084,6 +1202,28 @@ func extractSpecialFields(schemas map[string][]byte) (idFields, updatedFields ma
 	return idFields, updatedFields
 }
 
+// selectFields builds fields of a result row with lowercase keys.
+// If specific fields are requested, only those are included, otherwise all fields of the record.
+func selectFields(record map[string]any, requested []string) map[string]any {
+	fields := make(map[string]any)
+
+	if len(requested) == 0 {
+		for key, value := range record {
+			fields[strings.ToLower(key)] = value
+		}
+
+		return fields
+	}
+
+	for _, field := range requested {
+		if value, exists := record[field]; exists {
+			fields[strings.ToLower(field)] = value
+		}
+	}
+
+	return fields
+}
+
 func (c *Connector) sendRecordToObserver(
 	action string,
 	record map[string]any,
@@ -1692,6 +1692,138 @@ func TestDelete_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestDelete_SoftDelete(t *testing.T) {
+	t.Parallel()
+
+	schemas := map[string]*InputSchema{
+		"persons": testPersonSchema,
+	}
+	conn, err := NewConnector(WithSchemas(schemas))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	createResult, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{
+			"name":  "Soft Deleted",
+			"email": "soft@example.com",
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = conn.Delete(ctx, common.DeleteParams{
+		ObjectName: "persons",
+		RecordId:   createResult.RecordId,
+	})
+	require.NoError(t, err)
+
+	// Active records exclude the deleted one
+	readResult, err := conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, readResult.Data)
+
+	// Deleted records include it
+	readResult, err = conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+		Deleted:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, readResult.Data, 1)
+	assert.Equal(t, createResult.RecordId, readResult.Data[0].Raw["id"])
+	assert.Equal(t, "Soft Deleted", readResult.Data[0].Fields["name"])
+
+	// Deleting again fails
+	_, err = conn.Delete(ctx, common.DeleteParams{
+		ObjectName: "persons",
+		RecordId:   createResult.RecordId,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+
+	// Writing the same ID restores the record
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{
+			"id":    createResult.RecordId,
+			"name":  "Restored",
+			"email": "soft@example.com",
+		},
+	})
+	require.NoError(t, err)
+
+	readResult, err = conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+		Deleted:    true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, readResult.Data)
+}
+
+func TestRead_DeletedTimeFiltering(t *testing.T) {
+	t.Parallel()
+
+	schemas := map[string]*InputSchema{
+		"persons": testPersonSchema,
+	}
+	conn, err := NewConnector(WithSchemas(schemas), WithTombstoneRetention(time.Hour))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	conn.storage.now = func() time.Time { return clock }
+
+	ids := make([]string, 0, 3)
+
+	for _, name := range []string{"First", "Second", "Third"} {
+		result, err := conn.Write(ctx, common.WriteParams{
+			ObjectName: "persons",
+			RecordData: map[string]any{
+				"name":  name,
+				"email": strings.ToLower(name) + "@example.com",
+			},
+		})
+		require.NoError(t, err)
+
+		ids = append(ids, result.RecordId)
+	}
+
+	// Records are deleted 30 minutes apart, at 12:00, 12:30 and 13:00
+	for _, id := range ids {
+		_, err = conn.Delete(ctx, common.DeleteParams{ObjectName: "persons", RecordId: id})
+		require.NoError(t, err)
+
+		clock = clock.Add(30 * time.Minute)
+	}
+
+	// Since and Until apply to the time of deletion
+	result, err := conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+		Deleted:    true,
+		Since:      time.Date(2024, 1, 1, 12, 15, 0, 0, time.UTC),
+		Until:      time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Data, 1)
+	assert.Equal(t, "Second", result.Data[0].Fields["name"])
+
+	// At 13:30 the first tombstone is older than the retention period
+	result, err = conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+		Deleted:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Data, 2)
+	assert.Equal(t, "Second", result.Data[0].Fields["name"])
+	assert.Equal(t, "Third", result.Data[1].Fields["name"])
+}
+
 func TestListObjectMetadata(t *testing.T) {
 	t.Parallel()
 
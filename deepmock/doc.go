@@ -8,6 +8,8 @@
 //   - Thread-safe in-memory storage with deep copying to prevent mutations
 //   - Random record generation based on schema definitions
 //   - Full support for Read, Write, Delete, and ObjectMetadata operations
+//   - Webhook subscriptions with signed payloads for every Write and Delete
+//   - HTTP server speaking provider wire formats, for testing real connectors
 //
 // # Differences from Mock Connector
 //
@@ -63,6 +65,56 @@
 //     will be automatically updated on create and update operations. Supports string
 //     (RFC3339) and integer (Unix timestamp) types.
 //
+// # Deleted Records
+//
+// Delete keeps a tombstone of the record with the time of deletion. Reading with
+// ReadParams.Deleted returns deleted records only, Since and Until are then compared
+// with the deletion time. Tombstones are purged after the period configured by
+// WithTombstoneRetention. Writing a record with the ID of a deleted one restores it.
+//
+// # Webhooks
+//
+// The connector implements SubscribeConnector. Subscribe accepts a *SubscriptionRequest,
+// after which every matching Write and Delete is POSTed as a SubscriptionEvent to the
+// webhook URL, or to the handler configured by WithWebhookHandler. Payloads are signed
+// with the subscription secret in the WebhookSignatureHeader, VerifyWebhookMessage checks
+// the signature given *WebhookVerificationParams. Update events list the changed fields,
+// and are only sent when one of the watched fields has changed. Deliveries are asynchronous,
+// Close stops them once the connector is no longer needed.
+//
+// # Provider Servers
+//
+// Server starts an httptest.Server which serves the records of the connector in the
+// format of a provider, see Dialect. Real connectors of that provider are pointed at
+// the server using RedirectClient as their authenticated client. Records written by the
+// provider connector are stored by deepmock, and are read back on the next Read.
+// Served objects must declare an x-amp-id-field.
+//
+// # Filtering and Associations
+//
+// ReadParams.Filter is a SQL-like condition over schema fields with an optional ORDER BY clause,
+// ex: `age >= 18 AND name LIKE 'A%' ORDER BY age DESC`. Unknown fields are rejected with ErrInvalidFilter.
+//
+// A field declaring x-amp-ref holds the ID, or an array of IDs, of records of another object.
+// Read with AssociatedObjects and GetRecordsByIds return related records in ReadResultRow.Associations,
+// in both directions: contacts are associated with their company, and the company with its contacts.
+//
+// # Faults
+//
+// WithFaults makes calls fail the way providers do: latency, rate limits with Retry-After,
+// server errors, truncated bodies, expired cursors and expired tokens. A faults.Profile decides
+// which call fails, at random with a seed, every Nth call, or by a script per object.
+// Connector methods return the matching errors, ex: common.ErrCursorGone for reads of a next page.
+// Provider servers respond with the failing status instead, so provider connectors are tested end to end.
+// There, expired cursors fail requests carrying the provider's page token with the provider's own error.
+//
+// # Snapshots and Fixtures
+//
+// Storage.Snapshot writes every record, deleted ones included, as JSON lines of the form
+// {"object":"contacts","id":"c-1","record":{...}}. Storage.Restore replaces the storage with a snapshot.
+// WithFixtures seeds a new connector from files in the same format, the id may be omitted
+// when the record holds it in the x-amp-id-field. Datasets can be shared and bugs reproduced against a known state.
+//
 // # Thread Safety
 //
 // All storage operations are protected by RWMutex locks, making the connector safe
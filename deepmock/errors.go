@@ -53,4 +53,34 @@ var (
 
 	// ErrMissingDefs is returned when schema has $ref but no $defs.
 	ErrMissingDefs = errors.New("schema has $ref but no $defs")
+
+	// ErrSubscriptionNotFound is returned when a subscription ID doesn't exist.
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+
+	// ErrConnectorClosed is returned when a subscription is created after the connector was closed.
+	ErrConnectorClosed = errors.New("connector is closed")
+
+	// ErrInvalidSignature is returned when a webhook signature is missing or doesn't match the payload.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrUnknownDialect is returned when the server is asked for a provider format it doesn't speak.
+	ErrUnknownDialect = errors.New("unknown server dialect")
+
+	// ErrInvalidRequestBody is returned by the server when the request body cannot be decoded.
+	ErrInvalidRequestBody = errors.New("invalid request body")
+
+	// ErrInvalidCursor is returned by the server when the page token is malformed or expired.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrUnsupportedQuery is returned by the server for filters or queries it cannot evaluate.
+	ErrUnsupportedQuery = errors.New("unsupported query")
+
+	// ErrInvalidFilter is returned when ReadParams.Filter cannot be parsed or refers to unknown fields.
+	ErrInvalidFilter = errors.New("invalid filter")
+
+	// ErrUnknownAssociation is returned when objects are not related through an x-amp-ref field.
+	ErrUnknownAssociation = errors.New("unknown association")
+
+	// ErrInvalidSnapshot is returned when a snapshot or fixture file cannot be decoded.
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
 )
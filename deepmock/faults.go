@@ -0,0 +1,54 @@
+package deepmock
+
+import (
+	"context"
+	"time"
+
+	"github.com/amp-labs/connectors/common/faults"
+)
+
+// Operations reported to the fault profile as faults.Call.Method.
+// The path of the call is the object name, empty for metadata.
+const (
+	operationRead     = "read"
+	operationWrite    = "write"
+	operationDelete   = "delete"
+	operationMetadata = "metadata"
+)
+
+// injectFault returns the error of the next fault of the profile, if any.
+// Latency delays the call, expired cursors only fail reads of subsequent pages.
+func (c *Connector) injectFault(ctx context.Context, operation, objectName string, cursor bool) error {
+	if c.params.faults == nil {
+		return nil
+	}
+
+	fault := c.params.faults.Next(faults.Call{
+		Method: operation,
+		Path:   objectName,
+		Cursor: cursor,
+	})
+
+	switch fault.Kind {
+	case faults.KindLatency:
+		timer := time.NewTimer(fault.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case faults.KindCursorExpired:
+		if !cursor {
+			return nil
+		}
+
+		return fault.Err()
+	case faults.KindRateLimit, faults.KindServerError, faults.KindTruncatedBody, faults.KindTokenExpired:
+		return fault.Err()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,265 @@
+package deepmock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/faults"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/amp-labs/connectors/providers"
+	"github.com/amp-labs/connectors/providers/hubspot"
+	"github.com/amp-labs/connectors/providers/salesforce"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaults_EveryNth(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFaults(faults.EveryNth(2, faults.RateLimit(3*time.Second), faults.ServerError(0))),
+	)
+	require.NoError(t, err)
+
+	params := common.ReadParams{ObjectName: "persons", Fields: datautils.NewStringSet("name")}
+
+	_, err = conn.Read(context.Background(), params)
+	require.NoError(t, err)
+
+	_, err = conn.Read(context.Background(), params)
+	require.ErrorIs(t, err, common.ErrLimitExceeded)
+
+	var httpErr *common.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.Status)
+	assert.Equal(t, common.Headers{{Key: "Retry-After", Value: "3"}}, httpErr.Headers)
+
+	_, err = conn.Read(context.Background(), params)
+	require.NoError(t, err)
+
+	_, err = conn.Read(context.Background(), params)
+	require.ErrorIs(t, err, common.ErrServer)
+}
+
+func TestFaults_Script(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFaults(faults.Script(
+			faults.Rule{
+				Method: operationRead,
+				Path:   "persons",
+				Faults: []faults.Fault{faults.CursorExpired(), faults.CursorExpired()},
+			},
+			faults.Rule{
+				Method: operationWrite,
+				Path:   "persons",
+				Faults: []faults.Fault{faults.Pass(), faults.TokenExpired(), faults.TruncatedBody()},
+			},
+		)),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	write := func() error {
+		_, err := conn.Write(ctx, common.WriteParams{
+			ObjectName: "persons",
+			RecordData: map[string]any{"name": "Jane", "email": "jane@example.com"},
+		})
+
+		return err
+	}
+
+	require.NoError(t, write())
+	require.ErrorIs(t, write(), common.ErrAccessToken)
+	require.ErrorIs(t, write(), io.ErrUnexpectedEOF)
+	require.NoError(t, write())
+
+	// The first page has no cursor to expire, the next one does.
+	_, err = conn.Read(ctx, common.ReadParams{ObjectName: "persons", Fields: datautils.NewStringSet("name")})
+	require.NoError(t, err)
+
+	_, err = conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+		NextPage:   "100",
+	})
+	require.ErrorIs(t, err, common.ErrCursorGone)
+}
+
+func TestFaults_RandomIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	sequence := func(seed uint64) []faults.Kind {
+		profile := faults.Random(seed, 0.3, faults.RateLimit(time.Second), faults.ServerError(0))
+		kinds := make([]faults.Kind, 0, 200)
+
+		for range 200 {
+			kinds = append(kinds, profile.Next(faults.Call{}).Kind)
+		}
+
+		return kinds
+	}
+
+	first := sequence(42)
+	assert.Equal(t, first, sequence(42))
+	assert.NotEqual(t, first, sequence(7))
+
+	failed := 0
+
+	for _, kind := range first {
+		if kind != "" {
+			failed++
+		}
+	}
+
+	assert.InDelta(t, 60, failed, 20)
+}
+
+func TestFaults_Latency(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFaults(faults.EveryNth(1, faults.Latency(time.Hour))),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = conn.ListObjectMetadata(ctx, []string{"persons"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFaults_Server(t *testing.T) {
+	t.Parallel()
+
+	mock, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFaults(faults.Script(faults.Rule{
+			Method: http.MethodGet,
+			Path:   "/crm/v3/objects/persons",
+			Faults: []faults.Fault{faults.Pass(), faults.ServerError(http.StatusInternalServerError), faults.TruncatedBody()},
+		})),
+	)
+	require.NoError(t, err)
+
+	seedPersons(t, mock, 3, func(index int) int64 {
+		return int64(index)
+	})
+
+	server, err := mock.Server(DialectHubSpot)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	conn, err := hubspot.NewConnector(
+		hubspot.WithAuthenticatedClient(RedirectClient(server)),
+		hubspot.WithModule(providers.ModuleHubspotCRM),
+	)
+	require.NoError(t, err)
+
+	params := common.ReadParams{ObjectName: "persons", Fields: datautils.NewStringSet("name")}
+
+	result, err := conn.Read(context.Background(), params)
+	require.NoError(t, err)
+	assert.Len(t, result.Data, 3)
+
+	_, err = conn.Read(context.Background(), params)
+	require.ErrorIs(t, err, common.ErrServer)
+
+	_, err = conn.Read(context.Background(), params)
+	require.Error(t, err)
+
+	result, err = conn.Read(context.Background(), params)
+	require.NoError(t, err)
+	assert.Len(t, result.Data, 3)
+}
+
+func TestFaults_ServerCursorExpired(t *testing.T) {
+	t.Parallel()
+
+	mock, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFaults(faults.EveryNth(1, faults.CursorExpired())),
+	)
+	require.NoError(t, err)
+
+	seedPersons(t, mock, 2100, func(index int) int64 {
+		return int64(index)
+	})
+
+	server, err := mock.Server(DialectSalesforce)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	conn, err := salesforce.NewConnector(
+		salesforce.WithAuthenticatedClient(RedirectClient(server)),
+		salesforce.WithWorkspace("test-workspace"),
+		salesforce.WithModule(providers.ModuleSalesforceCRM),
+	)
+	require.NoError(t, err)
+
+	params := common.ReadParams{ObjectName: "persons", Fields: datautils.NewStringSet("Id")}
+
+	// The first page has no cursor to expire.
+	result, err := conn.Read(context.Background(), params)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.NextPage)
+
+	params.NextPage = result.NextPage
+
+	_, err = conn.Read(context.Background(), params)
+	require.ErrorIs(t, err, common.ErrCursorGone)
+}
+
+func TestFaults_HandlerKeepsExpiredToken(t *testing.T) {
+	t.Parallel()
+
+	handler := faults.Handler(
+		faults.Script(faults.Rule{Path: "/items", Faults: []faults.Fault{faults.Pass(), faults.TokenExpired()}}),
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	call := func(token string) int {
+		request := httptest.NewRequest(http.MethodGet, "/items", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, call("old"))
+	assert.Equal(t, http.StatusUnauthorized, call("old"))
+	// The script is over, yet the expired token stays rejected until it is refreshed.
+	assert.Equal(t, http.StatusUnauthorized, call("old"))
+	assert.Equal(t, http.StatusOK, call("new"))
+}
+
+func TestFaults_HandlerWithoutCursorPredicate(t *testing.T) {
+	t.Parallel()
+
+	handler := faults.Handler(
+		faults.EveryNth(1, faults.CursorExpired()),
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/items?page=2", nil))
+
+	// No request is known to carry a cursor, so none of them can expire.
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
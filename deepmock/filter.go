@@ -0,0 +1,586 @@
+package deepmock
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// recordFilter is a parsed ReadParams.Filter expression.
+//
+// The grammar is a small subset of SQL:
+//
+//	filter     = [ condition ] [ "ORDER BY" order { "," order } ]
+//	condition  = term { "OR" term }
+//	term       = factor { "AND" factor }
+//	factor     = "NOT" factor | "(" condition ")" | comparison
+//	comparison = field ( "=" | "!=" | "<>" | "<" | "<=" | ">" | ">=" | "LIKE" ) value
+//	           | field [ "NOT" ] "IN" "(" value { "," value } ")"
+//	           | field "IS" [ "NOT" ] "NULL"
+//	order      = field [ "ASC" | "DESC" ]
+//
+// Keywords are case-insensitive. Values are quoted strings, numbers, true, false or null.
+// Fields are schema properties, nested values are addressed with dots, ex: address.city.
+type recordFilter struct {
+	where   filterExpr
+	orderBy []filterOrder
+}
+
+type filterOrder struct {
+	field      string
+	descending bool
+}
+
+// filterExpr is a node of the condition tree.
+type filterExpr interface {
+	matches(record map[string]any) bool
+}
+
+type (
+	andExpr []filterExpr
+	orExpr  []filterExpr
+	notExpr struct{ expr filterExpr }
+)
+
+func (e andExpr) matches(record map[string]any) bool {
+	for _, expr := range e {
+		if !expr.matches(record) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e orExpr) matches(record map[string]any) bool {
+	for _, expr := range e {
+		if expr.matches(record) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e notExpr) matches(record map[string]any) bool {
+	return !e.expr.matches(record)
+}
+
+// comparisonExpr compares a field to values. Comparisons with missing or null fields are false,
+// except for IS NULL and !=, the same way SQL treats nulls in most providers.
+type comparisonExpr struct {
+	field    string
+	operator string
+	values   []any
+	pattern  *regexp.Regexp
+}
+
+func (e comparisonExpr) matches(record map[string]any) bool {
+	value := fieldValue(record, e.field)
+
+	switch e.operator {
+	case "IS NULL":
+		return value == nil
+	case "IS NOT NULL":
+		return value != nil
+	case "IN":
+		return slices.ContainsFunc(e.values, func(candidate any) bool { return valuesEqual(value, candidate) })
+	case "NOT IN":
+		return value != nil &&
+			!slices.ContainsFunc(e.values, func(candidate any) bool { return valuesEqual(value, candidate) })
+	case "LIKE":
+		return value != nil && e.pattern.MatchString(fmt.Sprint(value))
+	case "=":
+		return valuesEqual(value, e.values[0])
+	case "!=":
+		return !valuesEqual(value, e.values[0])
+	}
+
+	order, ok := compareValues(value, e.values[0])
+	if !ok {
+		return false
+	}
+
+	switch e.operator {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default: // ">="
+		return order >= 0
+	}
+}
+
+func valuesEqual(left, right any) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+
+	order, ok := compareValues(left, right)
+
+	return ok && order == 0
+}
+
+// fieldValue returns the value at a dotted path of the record, nil if it doesn't exist.
+func fieldValue(record map[string]any, path string) any {
+	var value any = record
+
+	for name := range strings.SplitSeq(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = object[name]
+	}
+
+	return value
+}
+
+// matches reports whether the record satisfies the condition of the filter.
+func (f *recordFilter) matches(record map[string]any) bool {
+	return f == nil || f.where == nil || f.where.matches(record)
+}
+
+// sort orders records by the ORDER BY clause, records are otherwise left in storage order.
+func (f *recordFilter) sort(records []map[string]any) {
+	if f == nil || len(f.orderBy) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(records, func(left, right map[string]any) int {
+		for _, order := range f.orderBy {
+			// Nulls are the smallest values, first in ascending order and last in descending.
+			result := compareForSort(fieldValue(left, order.field), fieldValue(right, order.field), true)
+			if order.descending {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	})
+}
+
+// parseReadFilter parses ReadParams.Filter over properties of the schema, nil if there is no filter.
+func parseReadFilter(text string, schema *jsonschema.Schema) (*recordFilter, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil // nolint:nilnil
+	}
+
+	var fields []string
+	if schema.Properties != nil {
+		fields = slices.Collect(maps.Keys(*schema.Properties))
+	}
+
+	return parseFilter(text, fields)
+}
+
+// parseFilter parses a filter expression. Fields must be properties of the object schema.
+func parseFilter(text string, fields []string) (*recordFilter, error) {
+	tokens, err := tokenizeFilter(text)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &filterParser{tokens: tokens, fields: fields}
+
+	filter := &recordFilter{}
+
+	if !parser.peekKeyword("ORDER") && !parser.done() {
+		filter.where, err = parser.condition()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if parser.acceptKeyword("ORDER") {
+		if !parser.acceptKeyword("BY") {
+			return nil, parser.errorf("expected BY after ORDER")
+		}
+
+		filter.orderBy, err = parser.orders()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !parser.done() {
+		return nil, parser.errorf("unexpected %q", parser.peek().text)
+	}
+
+	return filter, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+}
+
+//nolint:cyclop // A single pass over characters of the expression
+func tokenizeFilter(text string) ([]filterToken, error) {
+	var (
+		tokens []filterToken
+		runes  = []rune(text)
+	)
+
+	for index := 0; index < len(runes); {
+		char := runes[index]
+
+		switch {
+		case unicode.IsSpace(char):
+			index++
+		case char == '\'' || char == '"':
+			value, next, err := readQuoted(runes, index)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, filterToken{kind: tokenString, text: value})
+			index = next
+		case char == '(' || char == ')' || char == ',':
+			tokens = append(tokens, filterToken{kind: tokenSymbol, text: string(char)})
+			index++
+		case strings.ContainsRune("=!<>", char):
+			end := index + 1
+			if end < len(runes) && (runes[end] == '=' || (char == '<' && runes[end] == '>')) {
+				end++
+			}
+
+			operator := string(runes[index:end])
+			if operator == "!" {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, operator)
+			}
+
+			if operator == "<>" {
+				operator = "!="
+			}
+
+			tokens = append(tokens, filterToken{kind: tokenSymbol, text: operator})
+			index = end
+		case char == '-' || char == '.' || unicode.IsDigit(char):
+			end := index + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || strings.ContainsRune(".eE+-", runes[end])) {
+				end++
+			}
+
+			tokens = append(tokens, filterToken{kind: tokenNumber, text: string(runes[index:end])})
+			index = end
+		case unicode.IsLetter(char) || char == '_':
+			end := index + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) ||
+				runes[end] == '_' || runes[end] == '.') {
+				end++
+			}
+
+			tokens = append(tokens, filterToken{kind: tokenWord, text: string(runes[index:end])})
+			index = end
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, char)
+		}
+	}
+
+	return tokens, nil
+}
+
+// readQuoted reads a string literal, the quote character is escaped with a backslash.
+func readQuoted(runes []rune, start int) (string, int, error) {
+	var (
+		quote   = runes[start]
+		builder strings.Builder
+	)
+
+	for index := start + 1; index < len(runes); index++ {
+		switch runes[index] {
+		case '\\':
+			if index+1 < len(runes) {
+				index++
+				builder.WriteRune(runes[index])
+			}
+		case quote:
+			return builder.String(), index + 1, nil
+		default:
+			builder.WriteRune(runes[index])
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+}
+
+type filterParser struct {
+	tokens   []filterToken
+	position int
+	fields   []string
+}
+
+func (p *filterParser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+	if p.done() {
+		return filterToken{}
+	}
+
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() filterToken {
+	token := p.peek()
+	p.position++
+
+	return token
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	token := p.peek()
+
+	return token.kind == tokenWord && strings.EqualFold(token.text, keyword)
+}
+
+func (p *filterParser) acceptKeyword(keyword string) bool {
+	if p.peekKeyword(keyword) {
+		p.position++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *filterParser) acceptSymbol(symbol string) bool {
+	token := p.peek()
+	if token.kind == tokenSymbol && token.text == symbol {
+		p.position++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFilter, fmt.Sprintf(format, args...))
+}
+
+func (p *filterParser) condition() (filterExpr, error) {
+	return p.chain("OR", p.term, func(exprs []filterExpr) filterExpr { return orExpr(exprs) })
+}
+
+func (p *filterParser) term() (filterExpr, error) {
+	return p.chain("AND", p.factor, func(exprs []filterExpr) filterExpr { return andExpr(exprs) })
+}
+
+// chain parses operands joined by the keyword.
+func (p *filterParser) chain(
+	keyword string, operand func() (filterExpr, error), join func([]filterExpr) filterExpr,
+) (filterExpr, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []filterExpr{first}
+
+	for p.acceptKeyword(keyword) {
+		expr, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 1 {
+		return first, nil
+	}
+
+	return join(exprs), nil
+}
+
+func (p *filterParser) factor() (filterExpr, error) {
+	if p.acceptKeyword("NOT") {
+		expr, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{expr: expr}, nil
+	}
+
+	if p.acceptSymbol("(") {
+		expr, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.acceptSymbol(")") {
+			return nil, p.errorf("expected )")
+		}
+
+		return expr, nil
+	}
+
+	return p.comparison()
+}
+
+//nolint:cyclop // One branch per operator
+func (p *filterParser) comparison() (filterExpr, error) {
+	field, err := p.field()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.acceptKeyword("IS"):
+		operator := "IS NULL"
+		if p.acceptKeyword("NOT") {
+			operator = "IS NOT NULL"
+		}
+
+		if !p.acceptKeyword("NULL") {
+			return nil, p.errorf("expected NULL after IS")
+		}
+
+		return comparisonExpr{field: field, operator: operator}, nil
+	case p.acceptKeyword("IN"):
+		return p.list(field, "IN")
+	case p.acceptKeyword("NOT"):
+		if !p.acceptKeyword("IN") {
+			return nil, p.errorf("expected IN after NOT")
+		}
+
+		return p.list(field, "NOT IN")
+	case p.acceptKeyword("LIKE"):
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return comparisonExpr{field: field, operator: "LIKE", pattern: likePattern(fmt.Sprint(value))}, nil
+	}
+
+	operator := p.next()
+	if operator.kind != tokenSymbol || !slices.Contains([]string{"=", "!=", "<", "<=", ">", ">="}, operator.text) {
+		return nil, p.errorf("expected operator after %s", field)
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	return comparisonExpr{field: field, operator: operator.text, values: []any{value}}, nil
+}
+
+func (p *filterParser) list(field, operator string) (filterExpr, error) {
+	if !p.acceptSymbol("(") {
+		return nil, p.errorf("expected ( after %s", operator)
+	}
+
+	var values []any
+
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		if p.acceptSymbol(")") {
+			return comparisonExpr{field: field, operator: operator, values: values}, nil
+		}
+
+		if !p.acceptSymbol(",") {
+			return nil, p.errorf("expected , or ) in %s list", operator)
+		}
+	}
+}
+
+func (p *filterParser) field() (string, error) {
+	token := p.next()
+	if token.kind != tokenWord {
+		return "", p.errorf("expected field, got %q", token.text)
+	}
+
+	name, _, _ := strings.Cut(token.text, ".")
+	if !slices.Contains(p.fields, name) {
+		return "", p.errorf("unknown field %q", token.text)
+	}
+
+	return token.text, nil
+}
+
+func (p *filterParser) value() (any, error) {
+	token := p.next()
+
+	switch token.kind {
+	case tokenString:
+		return token.text, nil
+	case tokenNumber:
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", token.text)
+		}
+
+		return number, nil
+	case tokenWord:
+		switch strings.ToLower(token.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	case tokenSymbol:
+	}
+
+	return nil, p.errorf("expected value, got %q", token.text)
+}
+
+func (p *filterParser) orders() ([]filterOrder, error) {
+	var orders []filterOrder
+
+	for {
+		field, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+
+		order := filterOrder{field: field}
+
+		if p.acceptKeyword("DESC") {
+			order.descending = true
+		} else {
+			p.acceptKeyword("ASC")
+		}
+
+		orders = append(orders, order)
+
+		if !p.acceptSymbol(",") {
+			return orders, nil
+		}
+	}
+}
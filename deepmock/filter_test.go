@@ -0,0 +1,123 @@
+package deepmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead_Filter(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	seedPersons(t, conn, 10, func(index int) int64 {
+		return int64(1000 - index)
+	})
+
+	ids := func(filter string, pageSize int) []string {
+		t.Helper()
+
+		var result []string
+
+		params := common.ReadParams{
+			ObjectName: "persons",
+			Fields:     datautils.NewStringSet("id"),
+			Filter:     filter,
+			PageSize:   pageSize,
+		}
+
+		for {
+			page, err := conn.Read(context.Background(), params)
+			require.NoError(t, err)
+
+			for _, row := range page.Data {
+				result = append(result, row.Id)
+			}
+
+			if page.Done {
+				return result
+			}
+
+			params.NextPage = page.NextPage
+		}
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected []string
+	}{
+		{
+			name:     "Comparison",
+			filter:   "age >= 27",
+			expected: []string{"person-0007", "person-0008", "person-0009"},
+		},
+		{
+			name:     "Boolean operators and parentheses",
+			filter:   `(name = "Person 1" OR name = 'Person 2') AND NOT age = 21`,
+			expected: []string{"person-0002"},
+		},
+		{
+			name:     "In and like",
+			filter:   "id IN ('person-0003', 'person-0005') OR email LIKE 'PERSON9@%'",
+			expected: []string{"person-0003", "person-0005", "person-0009"},
+		},
+		{
+			name:     "Order by",
+			filter:   "age < 23 ORDER BY updated",
+			expected: []string{"person-0002", "person-0001", "person-0000"},
+		},
+		{
+			name:   "Order by descending across pages",
+			filter: "ORDER BY age DESC",
+			expected: []string{
+				"person-0009", "person-0008", "person-0007", "person-0006", "person-0005",
+				"person-0004", "person-0003", "person-0002", "person-0001", "person-0000",
+			},
+		},
+		{
+			name:     "Null checks",
+			filter:   "age IS NULL OR updated IS NOT NULL AND age > 28",
+			expected: []string{"person-0009"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, ids(tt.filter, 3))
+		})
+	}
+}
+
+func TestRead_InvalidFilter(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	for _, filter := range []string{
+		"unknown = 1",
+		"age >",
+		"age = 1 AND",
+		"(age = 1",
+		"name = 'open",
+		"age IN 1",
+		"ORDER age",
+		"age = 1 age = 2",
+	} {
+		_, err := conn.Read(context.Background(), common.ReadParams{
+			ObjectName: "persons",
+			Fields:     datautils.NewStringSet("id"),
+			Filter:     filter,
+		})
+		require.ErrorIs(t, err, ErrInvalidFilter, filter)
+	}
+}
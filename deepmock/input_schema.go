@@ -184,4 +184,7 @@ type InputSchema struct {
 	XAmpIdField *bool `json:"x-amp-id-field,omitempty"`
 	// When true, marks field as a timestamp indicating when resource was last modified.
 	XAmpUpdatedField *bool `json:"x-amp-updated-field,omitempty"`
+	// Name of the object whose ID this field holds, making the objects associated (e.g., "companies").
+	// On arrays of IDs it may be set either on the field or on its items.
+	XAmpRef string `json:"x-amp-ref,omitempty"`
 }
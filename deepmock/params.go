@@ -2,9 +2,12 @@ package deepmock
 
 import (
 	"fmt"
+	"io/fs"
 	"net/http"
+	"time"
 
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/faults"
 	"github.com/amp-labs/connectors/common/paramsbuilder"
 )
 
@@ -19,6 +22,15 @@ type parameters struct {
 	err error
 
 	observers []func(action string, record map[string]any)
+
+	tombstoneRetention time.Duration
+
+	webhookHandler http.Handler
+
+	faults faults.Profile
+
+	fixtures        fs.FS
+	fixturePatterns []string
 }
 
 // ValidateParams checks that all required parameters are present and valid.
@@ -47,6 +59,46 @@ func WithObserver(f func(action string, record map[string]any)) Option {
 	}
 }
 
+// WithTombstoneRetention sets how long deleted records can be read with ReadParams.Deleted.
+// Older tombstones are purged, the same way providers empty their recycle bins.
+// By default tombstones are kept for the lifetime of the connector.
+func WithTombstoneRetention(retention time.Duration) Option {
+	return func(p *parameters) {
+		p.tombstoneRetention = retention
+	}
+}
+
+// WithWebhookHandler delivers webhooks of subscriptions without a URL to the handler, in-process.
+// This avoids starting a server when tests consume events.
+func WithWebhookHandler(handler http.Handler) Option {
+	return func(p *parameters) {
+		p.webhookHandler = handler
+	}
+}
+
+// WithFaults injects failures decided by the profile into calls of the connector and its servers.
+// Connector methods return the errors a provider connector would report, ex: common.ErrLimitExceeded.
+// Servers respond with the failing status, so that the provider connector interprets it.
+// Use deterministic profiles, ex: faults.EveryNth or faults.Script, to test retries in unit tests.
+func WithFaults(profile faults.Profile) Option {
+	return func(p *parameters) {
+		p.faults = profile
+	}
+}
+
+// WithFixtures seeds the storage with records of fixture files matching the glob patterns.
+// Fixtures use the format of Storage.Snapshot, records are validated against their schemas.
+//
+// Example:
+//
+//	deepmock.WithFixtures(os.DirFS("testdata"), "crm/*.jsonl")
+func WithFixtures(fsys fs.FS, patterns ...string) Option {
+	return func(p *parameters) {
+		p.fixtures = fsys
+		p.fixturePatterns = patterns
+	}
+}
+
 // WithClient wraps an HTTP client in a JSONHTTPClient.
 func WithClient(client *http.Client) Option {
 	return func(params *parameters) {
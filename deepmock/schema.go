@@ -158,6 +158,7 @@ func filterRequiredFields(schemaMap map[string]any, structType reflect.Type) {
 // Use the jsonschema_extras struct tag to add custom x-amp-* extensions:
 //   - x-amp-id-field: Marks a field as the unique identifier
 //   - x-amp-updated-field: Marks a field as the last updated timestamp
+//   - x-amp-ref: Names the object whose ID the field holds, ex: x-amp-ref=companies
 //
 // Standard jsonschema tags are also supported:
 //   - required: Mark field as required
@@ -0,0 +1,345 @@
+package deepmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common/faults"
+)
+
+// Dialect is the REST wire format of a provider which the deepmock server speaks.
+// Real connectors of that provider can be pointed at the server, reading and writing records of the deepmock storage.
+type Dialect string
+
+const (
+	// DialectHubSpot serves the CRM objects API:
+	// records are listed as "results" and paginated with "paging.next.after".
+	DialectHubSpot Dialect = "hubspot"
+	// DialectSalesforce serves the REST API:
+	// SOQL queries return "records" and paginate with "nextRecordsUrl".
+	DialectSalesforce Dialect = "salesforce"
+)
+
+// dialectHandler serves the API of a provider.
+type dialectHandler interface {
+	http.Handler
+	// isCursor reports whether the request reads a page after the first one.
+	isCursor(r *http.Request) bool
+	// cursorExpired is the fault rejecting a page token with the provider's error.
+	cursorExpired() faults.Fault
+}
+
+// Handler returns an HTTP handler which serves records of the connector in the provider's format.
+// Faults configured with WithFaults are injected into responses.
+// Expired cursors only fail requests of subsequent pages, and respond with the error of the provider.
+func (c *Connector) Handler(dialect Dialect) (http.Handler, error) {
+	var handler dialectHandler
+
+	switch dialect {
+	case DialectHubSpot:
+		handler = newHubspotHandler(c)
+	case DialectSalesforce:
+		handler = newSalesforceHandler(c)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDialect, dialect)
+	}
+
+	if c.params.faults == nil {
+		return handler, nil
+	}
+
+	profile := dialectProfile{
+		profile: c.params.faults,
+		dialect: handler,
+	}
+
+	return faults.HandlerWithCursor(profile, handler, handler.isCursor), nil
+}
+
+// dialectProfile adapts faults of the profile to the dialect.
+type dialectProfile struct {
+	profile faults.Profile
+	dialect dialectHandler
+}
+
+func (p dialectProfile) Next(call faults.Call) faults.Fault {
+	fault := p.profile.Next(call)
+	if fault.Kind != faults.KindCursorExpired {
+		return fault
+	}
+
+	if fault.Status != 0 || fault.Body != nil {
+		// Response was customized by the caller.
+		return fault
+	}
+
+	return p.dialect.cursorExpired()
+}
+
+// dialectFault sets the status and the body of the fault to the error written by the dialect.
+func dialectFault(
+	fault faults.Fault, writeError func(w http.ResponseWriter, err serverError), err serverError,
+) faults.Fault {
+	recorder := newResponseRecorder()
+	writeError(recorder, err)
+
+	fault.Status = recorder.status
+	fault.Body = recorder.body.Bytes()
+
+	return fault
+}
+
+// responseRecorder is an http.ResponseWriter which keeps the response in memory.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+// Server starts a test server in the provider's format, sharing the storage of the connector.
+// Records written through the server are visible to the connector and the other way round.
+// The caller must close the server.
+//
+// Example:
+//
+//	server, _ := mock.Server(deepmock.DialectHubSpot)
+//	defer server.Close()
+//
+//	conn, _ := hubspot.NewConnector(
+//		hubspot.WithAuthenticatedClient(deepmock.RedirectClient(server)),
+//		hubspot.WithModule(providers.ModuleHubspotCRM),
+//	)
+func (c *Connector) Server(dialect Dialect) (*httptest.Server, error) {
+	handler, err := c.Handler(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return httptest.NewServer(handler), nil
+}
+
+// RedirectClient returns an HTTP client which sends every request to the server, regardless of the URL host.
+// It lets connectors with hardcoded provider URLs talk to the deepmock server.
+func RedirectClient(server *httptest.Server) *http.Client {
+	target, _ := url.Parse(server.URL)
+
+	return &http.Client{
+		Transport: &redirectTransport{
+			target: target,
+			base:   server.Client().Transport,
+		},
+	}
+}
+
+type redirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = t.target.Scheme
+	redirected.URL.Host = t.target.Host
+	redirected.Host = t.target.Host
+
+	return t.base.RoundTrip(redirected)
+}
+
+// serverError is an error of a dialect handler with the status code to respond with.
+// Code is the provider error code, when empty the dialect derives it from the status.
+type serverError struct {
+	status int
+	code   string
+	err    error
+}
+
+func (e serverError) Error() string {
+	return e.err.Error()
+}
+
+func (e serverError) Unwrap() error {
+	return e.err
+}
+
+func badRequest(code string, err error) serverError {
+	return serverError{status: http.StatusBadRequest, code: code, err: err}
+}
+
+// toServerError assigns a status code to errors of the connector.
+func toServerError(err error) serverError {
+	var target serverError
+	if errors.As(err, &target) {
+		return target
+	}
+
+	switch {
+	case errors.Is(err, ErrRecordNotFound), errors.Is(err, ErrSchemaNotFound):
+		return serverError{status: http.StatusNotFound, err: err}
+	default:
+		return serverError{status: http.StatusBadRequest, err: err}
+	}
+}
+
+// serve adapts a handler returning an error, which is written in the format of the dialect.
+func serve(
+	handle func(w http.ResponseWriter, r *http.Request) error,
+	writeError func(w http.ResponseWriter, err serverError),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := handle(w, r); err != nil {
+			writeError(w, toServerError(err))
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func readJSON(r *http.Request, target any) error {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		return badRequest("JSON_PARSER_ERROR", fmt.Errorf("%w: %w", ErrInvalidRequestBody, err))
+	}
+
+	return nil
+}
+
+// absoluteURL is the URL of the server with the given path and query.
+func absoluteURL(r *http.Request, path string, query url.Values) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	link := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
+
+	return link.String()
+}
+
+// page returns records between the offset and the page size, and the offset of the next page, if any.
+func page(records []map[string]any, offset, pageSize int) ([]map[string]any, int, bool) {
+	start := min(max(offset, 0), len(records))
+	end := min(start+pageSize, len(records))
+
+	return records[start:end], end, end < len(records)
+}
+
+// parseOffset converts a page token into a record offset.
+func parseOffset(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, badRequest("INVALID_CURSOR", fmt.Errorf("%w: %q", ErrInvalidCursor, token))
+	}
+
+	return offset, nil
+}
+
+// compareValues orders values the way a provider compares a field to a filter value.
+// Timestamps are compared as time, numbers numerically, anything else as text.
+// Returns false when one of the values is null.
+func compareValues(left, right any) (int, bool) {
+	if left == nil || right == nil {
+		return 0, false
+	}
+
+	leftText, rightText := fmt.Sprint(left), fmt.Sprint(right)
+
+	leftTime, leftIsTime := parseWireTime(leftText)
+	rightTime, rightIsTime := parseWireTime(rightText)
+
+	if leftIsTime && rightIsTime {
+		return leftTime.Compare(rightTime), true
+	}
+
+	leftNumber, leftErr := strconv.ParseFloat(leftText, 64)
+	rightNumber, rightErr := strconv.ParseFloat(rightText, 64)
+
+	if leftErr == nil && rightErr == nil {
+		switch {
+		case leftNumber < rightNumber:
+			return -1, true
+		case leftNumber > rightNumber:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	return strings.Compare(leftText, rightText), true
+}
+
+// compareForSort orders values for sorting, null values go first or last as requested.
+func compareForSort(left, right any, nullsFirst bool) int {
+	if order, ok := compareValues(left, right); ok {
+		return order
+	}
+
+	nullOrder := 1
+	if nullsFirst {
+		nullOrder = -1
+	}
+
+	switch {
+	case left == nil && right == nil:
+		return 0
+	case left == nil:
+		return nullOrder
+	default:
+		return -nullOrder
+	}
+}
+
+// nolint:gochecknoglobals
+var wireTimeLayouts = []string{
+	time.RFC3339Nano,
+	salesforceTimeLayout,
+}
+
+func parseWireTime(value string) (time.Time, bool) {
+	for _, layout := range wireTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,558 @@
+package deepmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/faults"
+	"github.com/google/uuid"
+)
+
+// HubSpot CRM objects API.
+// Property values are strings on the wire, record time is exposed as "hs_lastmodifieddate" and "lastmodifieddate".
+// Search stops at 10,000 records the same way the provider does.
+// https://developers.hubspot.com/docs/api/crm/understanding-the-crm
+
+const (
+	hubspotDefaultLimit  = 10
+	hubspotMaxLimit      = 100
+	hubspotMaxSearchSize = 200
+	hubspotSearchCeiling = 10000
+	hubspotTimeLayout    = "2006-01-02T15:04:05.000Z"
+
+	hubspotPropertyObjectID     = "hs_object_id"
+	hubspotPropertyLastModified = "hs_lastmodifieddate"
+	// Contacts use a property without prefix.
+	hubspotPropertyContactLastModified = "lastmodifieddate"
+)
+
+type hubspotHandler struct {
+	conn *Connector
+	mux  *http.ServeMux
+}
+
+func newHubspotHandler(conn *Connector) *hubspotHandler {
+	mux := http.NewServeMux()
+	handler := &hubspotHandler{conn: conn, mux: mux}
+
+	mux.Handle("GET /crm/v3/objects/{object}", serve(handler.list, handler.writeError))
+	mux.Handle("GET /crm/v3/objects/{object}/{$}", serve(handler.list, handler.writeError))
+	mux.Handle("POST /crm/v3/objects/{object}/search", serve(handler.search, handler.writeError))
+	mux.Handle("POST /crm/v3/objects/{object}", serve(handler.create, handler.writeError))
+	mux.Handle("GET /crm/v3/objects/{object}/{id}", serve(handler.get, handler.writeError))
+	mux.Handle("PATCH /crm/v3/objects/{object}/{id}", serve(handler.update, handler.writeError))
+	mux.Handle("DELETE /crm/v3/objects/{object}/{id}", serve(handler.delete, handler.writeError))
+
+	return handler
+}
+
+func (h *hubspotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// isCursor reports whether the request has the "after" token, in the query of lists or in the body of searches.
+func (h *hubspotHandler) isCursor(r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		return r.URL.Query().Get("after") != ""
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return false
+	}
+
+	var request hubspotSearchRequest
+
+	return json.Unmarshal(body, &request) == nil && textOf(request.After) != ""
+}
+
+// cursorExpired rejects the "after" token with a validation error, as HubSpot has no dedicated error for it.
+func (h *hubspotHandler) cursorExpired() faults.Fault {
+	return dialectFault(faults.CursorExpired(), h.writeError,
+		badRequest("", fmt.Errorf("%w: cursor expired", ErrInvalidCursor)))
+}
+
+type hubspotSearchRequest struct {
+	FilterGroups []hubspotFilterGroup `json:"filterGroups"`
+	Sorts        []hubspotSort        `json:"sorts"`
+	Properties   []string             `json:"properties"`
+	// Limit and After are sent either as numbers or as strings.
+	Limit any `json:"limit"`
+	After any `json:"after"`
+}
+
+type hubspotFilterGroup struct {
+	Filters []hubspotFilter `json:"filters"`
+}
+
+type hubspotFilter struct {
+	PropertyName string   `json:"propertyName"`
+	Operator     string   `json:"operator"`
+	Value        any      `json:"value"`
+	HighValue    any      `json:"highValue"`
+	Values       []string `json:"values"`
+}
+
+type hubspotSort struct {
+	PropertyName string `json:"propertyName"`
+	Direction    string `json:"direction"`
+}
+
+type hubspotWriteRequest struct {
+	Properties map[string]any `json:"properties"`
+}
+
+// list serves GET /crm/v3/objects/{object}.
+func (h *hubspotHandler) list(w http.ResponseWriter, r *http.Request) error {
+	objectName := r.PathValue("object")
+	query := r.URL.Query()
+
+	offset, err := parseOffset(query.Get("after"))
+	if err != nil {
+		return err
+	}
+
+	limit, err := hubspotLimit(query.Get("limit"), hubspotMaxLimit)
+	if err != nil {
+		return err
+	}
+
+	archived := query.Get("archived") == "true"
+
+	records, err := h.records(objectName, archived)
+	if err != nil {
+		return err
+	}
+
+	properties := hubspotRequestedProperties(query["properties"])
+	pageRecords, next, hasMore := page(records, offset, limit)
+
+	response := map[string]any{
+		"results": h.results(objectName, pageRecords, properties, archived),
+	}
+
+	if hasMore {
+		nextQuery := maps.Clone(query)
+		nextQuery.Set("after", strconv.Itoa(next))
+
+		response["paging"] = map[string]any{
+			"next": map[string]any{
+				"after": strconv.Itoa(next),
+				"link":  absoluteURL(r, r.URL.Path, nextQuery),
+			},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+
+	return nil
+}
+
+// search serves POST /crm/v3/objects/{object}/search.
+func (h *hubspotHandler) search(w http.ResponseWriter, r *http.Request) error {
+	objectName := r.PathValue("object")
+
+	var request hubspotSearchRequest
+	if err := readJSON(r, &request); err != nil {
+		return err
+	}
+
+	offset, err := parseOffset(textOf(request.After))
+	if err != nil {
+		return err
+	}
+
+	limit, err := hubspotLimit(textOf(request.Limit), hubspotMaxSearchSize)
+	if err != nil {
+		return err
+	}
+
+	if offset+limit > hubspotSearchCeiling {
+		return badRequest("VALIDATION_ERROR",
+			fmt.Errorf("%w: search is limited to %v records", ErrInvalidCursor, hubspotSearchCeiling))
+	}
+
+	records, err := h.records(objectName, false)
+	if err != nil {
+		return err
+	}
+
+	matching := make([]map[string]any, 0, len(records))
+
+	for _, record := range records {
+		properties := h.properties(objectName, record, nil)
+
+		ok, err := matchHubspotFilterGroups(request.FilterGroups, properties)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			matching = append(matching, record)
+		}
+	}
+
+	h.sort(objectName, matching, request.Sorts)
+
+	pageRecords, next, hasMore := page(matching, offset, limit)
+
+	response := map[string]any{
+		"total":   len(matching),
+		"results": h.results(objectName, pageRecords, request.Properties, false),
+	}
+
+	if hasMore {
+		response["paging"] = map[string]any{
+			"next": map[string]any{
+				"after": strconv.Itoa(next),
+			},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+
+	return nil
+}
+
+// get serves GET /crm/v3/objects/{object}/{id}.
+func (h *hubspotHandler) get(w http.ResponseWriter, r *http.Request) error {
+	objectName := r.PathValue("object")
+
+	if _, exists := h.conn.schemas.Get(objectName); !exists {
+		return fmt.Errorf("%w: %s", ErrSchemaNotFound, objectName)
+	}
+
+	record, err := h.conn.storage.Get(objectName, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	properties := hubspotRequestedProperties(r.URL.Query()["properties"])
+
+	writeJSON(w, http.StatusOK, h.result(objectName, record, properties, false))
+
+	return nil
+}
+
+// create serves POST /crm/v3/objects/{object}.
+func (h *hubspotHandler) create(w http.ResponseWriter, r *http.Request) error {
+	return h.write(w, r, "", http.StatusCreated)
+}
+
+// update serves PATCH /crm/v3/objects/{object}/{id}.
+func (h *hubspotHandler) update(w http.ResponseWriter, r *http.Request) error {
+	return h.write(w, r, r.PathValue("id"), http.StatusOK)
+}
+
+func (h *hubspotHandler) write(w http.ResponseWriter, r *http.Request, recordID string, status int) error {
+	objectName := r.PathValue("object")
+
+	var request hubspotWriteRequest
+	if err := readJSON(r, &request); err != nil {
+		return err
+	}
+
+	if request.Properties == nil {
+		request.Properties = make(map[string]any)
+	}
+
+	written, err := h.conn.write(common.WriteParams{
+		ObjectName: objectName,
+		RecordId:   recordID,
+		RecordData: request.Properties,
+	})
+	if err != nil {
+		return err
+	}
+
+	record, err := h.conn.storage.Get(objectName, written.RecordId)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, status, h.result(objectName, record, nil, false))
+
+	return nil
+}
+
+// delete serves DELETE /crm/v3/objects/{object}/{id}, the record is archived.
+func (h *hubspotHandler) delete(w http.ResponseWriter, r *http.Request) error {
+	if _, err := h.conn.delete(common.DeleteParams{
+		ObjectName: r.PathValue("object"),
+		RecordId:   r.PathValue("id"),
+	}); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// writeError responds in the format of https://developers.hubspot.com/docs/api/error-handling.
+func (h *hubspotHandler) writeError(w http.ResponseWriter, err serverError) {
+	category := err.code
+	if category == "" {
+		category = "VALIDATION_ERROR"
+		if err.status == http.StatusNotFound {
+			category = "OBJECT_NOT_FOUND"
+		}
+	}
+
+	writeJSON(w, err.status, map[string]any{
+		"status":        "error",
+		"message":       err.Error(),
+		"correlationId": uuid.New().String(),
+		"category":      category,
+	})
+}
+
+// records lists active or archived records of the object, ordered by ID.
+func (h *hubspotHandler) records(objectName string, archived bool) ([]map[string]any, error) {
+	if _, exists := h.conn.schemas.Get(objectName); !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, objectName)
+	}
+
+	if h.conn.storage.idFields[ObjectName(objectName)] == "" {
+		return nil, fmt.Errorf("%w: x-amp-id-field of %s", ErrMissingField, objectName)
+	}
+
+	if archived {
+		return h.conn.storage.ListDeleted(objectName, time.Time{}, time.Time{})
+	}
+
+	return h.conn.storage.List(objectName, time.Time{}, time.Time{})
+}
+
+func (h *hubspotHandler) results(
+	objectName string, records []map[string]any, requested []string, archived bool,
+) []map[string]any {
+	results := make([]map[string]any, len(records))
+	for index, record := range records {
+		results[index] = h.result(objectName, record, requested, archived)
+	}
+
+	return results
+}
+
+// result wraps the record into the HubSpot object format.
+func (h *hubspotHandler) result(
+	objectName string, record map[string]any, requested []string, archived bool,
+) map[string]any {
+	result := map[string]any{
+		"id":         h.conn.storage.recordID(objectName, record),
+		"properties": h.properties(objectName, record, requested),
+		"archived":   archived,
+	}
+
+	if updated, ok := h.conn.storage.UpdatedTime(objectName, record); ok {
+		result["createdAt"] = updated.UTC().Format(hubspotTimeLayout)
+		result["updatedAt"] = updated.UTC().Format(hubspotTimeLayout)
+	}
+
+	return result
+}
+
+// properties converts record fields into string properties.
+// When properties are requested, only those are returned along with the default ones.
+func (h *hubspotHandler) properties(
+	objectName string, record map[string]any, requested []string,
+) map[string]any {
+	all := make(map[string]any, len(record)+3) // nolint:mnd
+
+	for key, value := range record {
+		all[key] = hubspotPropertyValue(value)
+	}
+
+	all[hubspotPropertyObjectID] = h.conn.storage.recordID(objectName, record)
+
+	if updated, ok := h.conn.storage.UpdatedTime(objectName, record); ok {
+		modified := updated.UTC().Format(hubspotTimeLayout)
+		all[hubspotPropertyLastModified] = modified
+		all[hubspotPropertyContactLastModified] = modified
+	}
+
+	if len(requested) == 0 {
+		return all
+	}
+
+	properties := make(map[string]any, len(requested))
+
+	for _, name := range append(slices.Clone(requested), hubspotPropertyObjectID, hubspotPropertyLastModified) {
+		if value, exists := all[name]; exists {
+			properties[name] = value
+		} else {
+			properties[name] = nil
+		}
+	}
+
+	return properties
+}
+
+// sort orders records by the sorting rules, records without the property come last.
+func (h *hubspotHandler) sort(objectName string, records []map[string]any, sorts []hubspotSort) {
+	if len(sorts) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(records, func(left, right map[string]any) int {
+		leftProperties := h.properties(objectName, left, nil)
+		rightProperties := h.properties(objectName, right, nil)
+
+		for _, rule := range sorts {
+			order := compareForSort(leftProperties[rule.PropertyName], rightProperties[rule.PropertyName], false)
+			if strings.EqualFold(rule.Direction, "DESCENDING") {
+				order = -order
+			}
+
+			if order != 0 {
+				return order
+			}
+		}
+
+		return 0
+	})
+}
+
+// matchHubspotFilterGroups reports whether properties satisfy any group, filters of a group must all match.
+func matchHubspotFilterGroups(groups []hubspotFilterGroup, properties map[string]any) (bool, error) {
+	if len(groups) == 0 {
+		return true, nil
+	}
+
+	for _, group := range groups {
+		matches := true
+
+		for _, filter := range group.Filters {
+			ok, err := matchHubspotFilter(filter, properties)
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// nolint:cyclop
+func matchHubspotFilter(filter hubspotFilter, properties map[string]any) (bool, error) {
+	value := properties[filter.PropertyName]
+	order, comparable := compareValues(value, filter.Value)
+
+	switch filter.Operator {
+	case "EQ":
+		return comparable && order == 0, nil
+	case "NEQ":
+		return !comparable || order != 0, nil
+	case "LT":
+		return comparable && order < 0, nil
+	case "LTE":
+		return comparable && order <= 0, nil
+	case "GT":
+		return comparable && order > 0, nil
+	case "GTE":
+		return comparable && order >= 0, nil
+	case "BETWEEN":
+		high, highComparable := compareValues(value, filter.HighValue)
+
+		return comparable && highComparable && order >= 0 && high <= 0, nil
+	case "HAS_PROPERTY":
+		return value != nil, nil
+	case "NOT_HAS_PROPERTY":
+		return value == nil, nil
+	case "IN":
+		return slices.ContainsFunc(filter.Values, func(candidate string) bool {
+			order, ok := compareValues(value, candidate)
+
+			return ok && order == 0
+		}), nil
+	case "NOT_IN":
+		return !slices.ContainsFunc(filter.Values, func(candidate string) bool {
+			order, ok := compareValues(value, candidate)
+
+			return ok && order == 0
+		}), nil
+	default:
+		return false, badRequest("VALIDATION_ERROR",
+			fmt.Errorf("%w: operator %v", ErrUnsupportedQuery, filter.Operator))
+	}
+}
+
+func hubspotLimit(text string, maxLimit int) (int, error) {
+	if text == "" {
+		return hubspotDefaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(text)
+	if err != nil || limit <= 0 {
+		return 0, badRequest("VALIDATION_ERROR", fmt.Errorf("%w: limit %q", ErrUnsupportedQuery, text))
+	}
+
+	return min(limit, maxLimit), nil
+}
+
+// textOf formats a JSON number or string, null is empty.
+func textOf(value any) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// hubspotRequestedProperties splits repeated and comma separated "properties" query parameters.
+func hubspotRequestedProperties(values []string) []string {
+	properties := make([]string, 0, len(values))
+
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name != "" {
+				properties = append(properties, name)
+			}
+		}
+	}
+
+	return properties
+}
+
+// hubspotPropertyValue formats the value the way HubSpot returns properties, as text.
+func hubspotPropertyValue(value any) any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool, int, int64, json.Number:
+		return fmt.Sprint(value)
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+
+		return string(data)
+	}
+}
@@ -0,0 +1,717 @@
+package deepmock
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/faults"
+)
+
+// Salesforce REST API.
+// Queries are a subset of SOQL: selected fields, conditions joined with AND, ORDER BY, LIMIT and OFFSET.
+// Record time is exposed as "SystemModstamp" and "LastModifiedDate", deleted records are read with "IsDeleted = true".
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_query.htm
+
+const (
+	salesforceBatchSize  = 2000
+	salesforceTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+	salesforceFieldID           = "Id"
+	salesforceFieldIsDeleted    = "IsDeleted"
+	salesforceFieldModstamp     = "SystemModstamp"
+	salesforceFieldLastModified = "LastModifiedDate"
+)
+
+type salesforceHandler struct {
+	conn *Connector
+	mux  *http.ServeMux
+
+	mu sync.Mutex
+	// locators remember queries, so that next pages can be read with a query locator.
+	locators map[string]string
+}
+
+func newSalesforceHandler(conn *Connector) *salesforceHandler {
+	mux := http.NewServeMux()
+	handler := &salesforceHandler{
+		conn:     conn,
+		mux:      mux,
+		locators: make(map[string]string),
+	}
+
+	mux.Handle("GET /services/data/{version}/query", serve(handler.query, handler.writeError))
+	mux.Handle("GET /services/data/{version}/query/{cursor}", serve(handler.queryMore, handler.writeError))
+	mux.Handle("POST /services/data/{version}/sobjects/{object}", serve(handler.create, handler.writeError))
+	mux.Handle("GET /services/data/{version}/sobjects/{object}/{id}", serve(handler.get, handler.writeError))
+	mux.Handle("POST /services/data/{version}/sobjects/{object}/{id}", serve(handler.update, handler.writeError))
+	mux.Handle("PATCH /services/data/{version}/sobjects/{object}/{id}", serve(handler.update, handler.writeError))
+	mux.Handle("DELETE /services/data/{version}/sobjects/{object}/{id}", serve(handler.delete, handler.writeError))
+
+	return handler
+}
+
+func (h *salesforceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// isCursor reports whether the request reads the nextRecordsUrl of a query.
+func (h *salesforceHandler) isCursor(r *http.Request) bool {
+	_, cursor, found := strings.Cut(r.URL.Path, "/query/")
+
+	return found && cursor != ""
+}
+
+// cursorExpired rejects the query locator the way Salesforce does once it expires.
+func (h *salesforceHandler) cursorExpired() faults.Fault {
+	return dialectFault(faults.CursorExpired(), h.writeError,
+		badRequest("INVALID_QUERY_LOCATOR", fmt.Errorf("%w: query locator expired", ErrInvalidCursor)))
+}
+
+// query serves GET /services/data/{version}/query?q=SOQL.
+func (h *salesforceHandler) query(w http.ResponseWriter, r *http.Request) error {
+	text := r.URL.Query().Get("q")
+
+	h.mu.Lock()
+	locator := fmt.Sprintf("01g%015d", len(h.locators)+1)
+	h.locators[locator] = text
+	h.mu.Unlock()
+
+	return h.respondQuery(w, r, text, locator, 0)
+}
+
+// queryMore serves GET /services/data/{version}/query/{locator}-{offset}, the nextRecordsUrl.
+func (h *salesforceHandler) queryMore(w http.ResponseWriter, r *http.Request) error {
+	locator, offsetText, _ := strings.Cut(r.PathValue("cursor"), "-")
+
+	h.mu.Lock()
+	text, exists := h.locators[locator]
+	h.mu.Unlock()
+
+	offset, err := parseOffset(offsetText)
+	if !exists || err != nil {
+		return badRequest("INVALID_QUERY_LOCATOR",
+			fmt.Errorf("%w: %s", ErrInvalidCursor, r.PathValue("cursor")))
+	}
+
+	return h.respondQuery(w, r, text, locator, offset)
+}
+
+func (h *salesforceHandler) respondQuery(
+	w http.ResponseWriter, r *http.Request, text, locator string, offset int,
+) error {
+	query, err := parseSOQLQuery(text)
+	if err != nil {
+		return err
+	}
+
+	records, err := h.run(query)
+	if err != nil {
+		return err
+	}
+
+	pageRecords, next, hasMore := page(records, offset, salesforceBatchSize)
+
+	response := map[string]any{
+		"totalSize": len(records),
+		"done":      !hasMore,
+		"records":   h.project(query, pageRecords, r.PathValue("version")),
+	}
+
+	if hasMore {
+		response["nextRecordsUrl"] = fmt.Sprintf("/services/data/%s/query/%s-%d",
+			r.PathValue("version"), locator, next)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+
+	return nil
+}
+
+// run returns records matching the query, in the requested order, as Salesforce records.
+func (h *salesforceHandler) run(query *soqlQuery) ([]map[string]any, error) {
+	if _, exists := h.conn.schemas.Get(query.object); !exists {
+		return nil, badRequest("INVALID_TYPE",
+			fmt.Errorf("%w: sObject type '%s' is not supported", ErrSchemaNotFound, query.object))
+	}
+
+	var (
+		stored []map[string]any
+		err    error
+	)
+
+	if query.deleted {
+		stored, err = h.conn.storage.ListDeleted(query.object, time.Time{}, time.Time{})
+	} else {
+		stored, err = h.conn.storage.List(query.object, time.Time{}, time.Time{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]any, 0, len(stored))
+
+	for _, record := range stored {
+		sobject := h.sobject(query.object, record, query.deleted)
+
+		ok, err := query.matches(sobject)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			records = append(records, sobject)
+		}
+	}
+
+	query.sort(records)
+
+	start := min(query.offset, len(records))
+	records = records[start:]
+
+	if query.limit >= 0 && query.limit < len(records) {
+		records = records[:query.limit]
+	}
+
+	return records, nil
+}
+
+// project keeps selected fields of records and adds attributes.
+func (h *salesforceHandler) project(query *soqlQuery, records []map[string]any, version string) []map[string]any {
+	projected := make([]map[string]any, len(records))
+
+	for index, record := range records {
+		id := record[salesforceFieldID]
+		result := map[string]any{
+			"attributes": map[string]any{
+				"type": query.object,
+				"url":  fmt.Sprintf("/services/data/%s/sobjects/%s/%v", version, query.object, id),
+			},
+		}
+
+		if query.allFields {
+			for key, value := range record {
+				result[key] = value
+			}
+		}
+
+		for _, field := range query.fields {
+			key, value := lookupField(record, field)
+			result[key] = value
+		}
+
+		projected[index] = result
+	}
+
+	return projected
+}
+
+// get serves GET /services/data/{version}/sobjects/{object}/{id}.
+func (h *salesforceHandler) get(w http.ResponseWriter, r *http.Request) error {
+	objectName := r.PathValue("object")
+
+	if _, exists := h.conn.schemas.Get(objectName); !exists {
+		return fmt.Errorf("%w: %s", ErrSchemaNotFound, objectName)
+	}
+
+	record, err := h.conn.storage.Get(objectName, r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	sobject := h.sobject(objectName, record, false)
+	sobject["attributes"] = map[string]any{
+		"type": objectName,
+		"url":  r.URL.Path,
+	}
+
+	writeJSON(w, http.StatusOK, sobject)
+
+	return nil
+}
+
+// create serves POST /services/data/{version}/sobjects/{object}.
+func (h *salesforceHandler) create(w http.ResponseWriter, r *http.Request) error {
+	var recordData map[string]any
+	if err := readJSON(r, &recordData); err != nil {
+		return err
+	}
+
+	written, err := h.conn.write(common.WriteParams{
+		ObjectName: r.PathValue("object"),
+		RecordData: recordData,
+	})
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"id":      written.RecordId,
+		"success": true,
+		"errors":  []any{},
+	})
+
+	return nil
+}
+
+// update serves PATCH /services/data/{version}/sobjects/{object}/{id},
+// as well as POST with the "_HttpMethod=PATCH" override.
+func (h *salesforceHandler) update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method == http.MethodPost && r.URL.Query().Get("_HttpMethod") != http.MethodPatch {
+		return serverError{
+			status: http.StatusMethodNotAllowed,
+			code:   "METHOD_NOT_ALLOWED",
+			err:    fmt.Errorf("%w: HTTP Method 'POST' not allowed", ErrUnsupportedQuery),
+		}
+	}
+
+	var recordData map[string]any
+	if err := readJSON(r, &recordData); err != nil {
+		return err
+	}
+
+	if _, err := h.conn.write(common.WriteParams{
+		ObjectName: r.PathValue("object"),
+		RecordId:   r.PathValue("id"),
+		RecordData: recordData,
+	}); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// delete serves DELETE /services/data/{version}/sobjects/{object}/{id}, the record goes to the recycle bin.
+func (h *salesforceHandler) delete(w http.ResponseWriter, r *http.Request) error {
+	if _, err := h.conn.delete(common.DeleteParams{
+		ObjectName: r.PathValue("object"),
+		RecordId:   r.PathValue("id"),
+	}); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+// writeError responds with the list of errors.
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/errorcodes.htm
+func (h *salesforceHandler) writeError(w http.ResponseWriter, err serverError) {
+	code := err.code
+	if code == "" {
+		code = "INVALID_FIELD"
+		if err.status == http.StatusNotFound {
+			code = "NOT_FOUND"
+		}
+	}
+
+	writeJSON(w, err.status, []map[string]any{{
+		"message":   err.Error(),
+		"errorCode": code,
+	}})
+}
+
+// sobject converts a stored record into the Salesforce format with system fields.
+func (h *salesforceHandler) sobject(objectName string, record map[string]any, deleted bool) map[string]any {
+	idField := h.conn.storage.idFields[ObjectName(objectName)]
+	sobject := make(map[string]any, len(record)+4) // nolint:mnd
+
+	for key, value := range record {
+		if !strings.EqualFold(key, salesforceFieldID) || key == salesforceFieldID {
+			sobject[key] = value
+		}
+	}
+
+	if idField != "" {
+		sobject[salesforceFieldID] = h.conn.storage.recordID(objectName, record)
+	}
+
+	sobject[salesforceFieldIsDeleted] = deleted
+
+	if updated, ok := h.conn.storage.UpdatedTime(objectName, record); ok {
+		modified := updated.UTC().Format(salesforceTimeLayout)
+		sobject[salesforceFieldModstamp] = modified
+		sobject[salesforceFieldLastModified] = modified
+	}
+
+	return sobject
+}
+
+// lookupField finds the field regardless of case, as SOQL field names are case-insensitive.
+// Returns the name of the stored field, unknown fields are null.
+func lookupField(record map[string]any, field string) (string, any) {
+	if value, exists := record[field]; exists {
+		return field, value
+	}
+
+	for key, value := range record {
+		if strings.EqualFold(key, field) {
+			return key, value
+		}
+	}
+
+	return field, nil
+}
+
+// soqlQuery is a parsed SOQL statement.
+type soqlQuery struct {
+	fields     []string
+	allFields  bool
+	object     string
+	conditions []soqlCondition
+	deleted    bool
+	orderBy    []soqlOrder
+	limit      int
+	offset     int
+}
+
+type soqlCondition struct {
+	field    string
+	operator string
+	value    any
+}
+
+type soqlOrder struct {
+	field      string
+	descending bool
+	nullsFirst bool
+}
+
+// nolint:gochecknoglobals
+var (
+	soqlConditionPattern = regexp.MustCompile(
+		`(?is)^([\w.]+)\s*(<=|>=|!=|<>|=|<|>|\bNOT\s+IN\b|\bIN\b|\bLIKE\b)\s*(.+)$`)
+	soqlClauses = []string{" WHERE ", " ORDER BY ", " LIMIT ", " OFFSET "}
+)
+
+func malformedQuery(format string, args ...any) error {
+	return badRequest("MALFORMED_QUERY", fmt.Errorf("%w: "+format, append([]any{ErrUnsupportedQuery}, args...)...))
+}
+
+// parseSOQLQuery parses "SELECT fields FROM object [WHERE ...] [ORDER BY ...] [LIMIT n] [OFFSET n]".
+// nolint:cyclop,funlen
+func parseSOQLQuery(text string) (*soqlQuery, error) {
+	text = strings.Join(strings.Fields(text), " ")
+
+	if !strings.HasPrefix(strings.ToUpper(text), "SELECT ") {
+		return nil, malformedQuery("expected SELECT in %q", text)
+	}
+
+	rest := text[len("SELECT "):]
+
+	from := indexTopLevel(rest, " FROM ")
+	if from < 0 {
+		return nil, malformedQuery("expected FROM in %q", text)
+	}
+
+	query := &soqlQuery{limit: -1}
+
+	for _, item := range splitTopLevel(rest[:from], ",") {
+		item = strings.TrimSpace(item)
+
+		switch {
+		case strings.HasPrefix(item, "("):
+			// Child relationships are not supported, the subquery is ignored.
+		case strings.HasPrefix(strings.ToUpper(item), "FIELDS("):
+			query.allFields = true
+		default:
+			query.fields = append(query.fields, item)
+		}
+	}
+
+	rest = rest[from+len(" FROM "):]
+	query.object, rest, _ = strings.Cut(rest, " ")
+	clauses := splitClauses(" " + rest)
+
+	if where, ok := clauses[" WHERE "]; ok {
+		if indexTopLevel(" "+where+" ", " OR ") >= 0 || strings.HasPrefix(strings.ToUpper(where), "NOT ") {
+			return nil, malformedQuery("only conditions joined with AND are supported")
+		}
+
+		for _, part := range splitTopLevel(where, " AND ") {
+			condition, err := parseSOQLCondition(part)
+			if err != nil {
+				return nil, err
+			}
+
+			if strings.EqualFold(condition.field, salesforceFieldIsDeleted) {
+				query.deleted = condition.value == true
+
+				continue
+			}
+
+			query.conditions = append(query.conditions, condition)
+		}
+	}
+
+	if orderBy, ok := clauses[" ORDER BY "]; ok {
+		for _, item := range splitTopLevel(orderBy, ",") {
+			words := strings.Fields(strings.ToUpper(item))
+			order := soqlOrder{
+				field:      strings.Fields(item)[0],
+				descending: slices.Contains(words, "DESC"),
+			}
+			// Nulls go first in ascending order and last in descending, unless stated otherwise.
+			order.nullsFirst = !order.descending
+			if strings.Contains(strings.Join(words, " "), "NULLS LAST") {
+				order.nullsFirst = false
+			} else if strings.Contains(strings.Join(words, " "), "NULLS FIRST") {
+				order.nullsFirst = true
+			}
+
+			query.orderBy = append(query.orderBy, order)
+		}
+	}
+
+	for clause, target := range map[string]*int{" LIMIT ": &query.limit, " OFFSET ": &query.offset} {
+		value, ok := clauses[clause]
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || number < 0 {
+			return nil, malformedQuery("invalid%s%q", clause, value)
+		}
+
+		*target = number
+	}
+
+	return query, nil
+}
+
+func parseSOQLCondition(text string) (soqlCondition, error) {
+	match := soqlConditionPattern.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return soqlCondition{}, malformedQuery("unsupported condition %q", text)
+	}
+
+	operator := strings.ToUpper(strings.Join(strings.Fields(match[2]), " "))
+	valueText := strings.TrimSpace(match[3])
+
+	if operator == "IN" || operator == "NOT IN" {
+		if !strings.HasPrefix(valueText, "(") || !strings.HasSuffix(valueText, ")") {
+			return soqlCondition{}, malformedQuery("expected a list in %q", text)
+		}
+
+		values := make([]any, 0)
+		for _, item := range splitTopLevel(valueText[1:len(valueText)-1], ",") {
+			values = append(values, parseSOQLValue(strings.TrimSpace(item)))
+		}
+
+		return soqlCondition{field: match[1], operator: operator, value: values}, nil
+	}
+
+	return soqlCondition{field: match[1], operator: operator, value: parseSOQLValue(valueText)}, nil
+}
+
+// nolint:gochecknoglobals
+var soqlUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\'`, `'`,
+	`\"`, `"`,
+	`\n`, "\n",
+	`\r`, "\r",
+	`\t`, "\t",
+	`\b`, "\b",
+	`\f`, "\f",
+)
+
+// parseSOQLValue converts a literal. Numbers and dates are kept as text, they are compared by compareValues.
+func parseSOQLValue(text string) any {
+	switch {
+	case len(text) >= 2 && strings.HasPrefix(text, "'") && strings.HasSuffix(text, "'"):
+		return soqlUnescaper.Replace(text[1 : len(text)-1])
+	case strings.EqualFold(text, "null"):
+		return nil
+	case strings.EqualFold(text, "true"):
+		return true
+	case strings.EqualFold(text, "false"):
+		return false
+	default:
+		return text
+	}
+}
+
+func (q *soqlQuery) matches(record map[string]any) (bool, error) {
+	for _, condition := range q.conditions {
+		ok, err := condition.matches(record)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// nolint:cyclop
+func (c soqlCondition) matches(record map[string]any) (bool, error) {
+	_, value := lookupField(record, c.field)
+
+	equals := func(candidate any) bool {
+		if value == nil || candidate == nil {
+			return value == nil && candidate == nil
+		}
+
+		order, ok := compareValues(value, candidate)
+
+		return ok && order == 0
+	}
+
+	order, comparable := compareValues(value, c.value)
+
+	switch c.operator {
+	case "=":
+		return equals(c.value), nil
+	case "!=", "<>":
+		return !equals(c.value), nil
+	case "<":
+		return comparable && order < 0, nil
+	case "<=":
+		return comparable && order <= 0, nil
+	case ">":
+		return comparable && order > 0, nil
+	case ">=":
+		return comparable && order >= 0, nil
+	case "IN", "NOT IN":
+		values, _ := c.value.([]any)
+		found := slices.ContainsFunc(values, equals)
+
+		return found == (c.operator == "IN"), nil
+	case "LIKE":
+		pattern, ok := c.value.(string)
+		if !ok || value == nil {
+			return false, nil
+		}
+
+		return likePattern(pattern).MatchString(fmt.Sprint(value)), nil
+	default:
+		return false, malformedQuery("unsupported operator %q", c.operator)
+	}
+}
+
+func (q *soqlQuery) sort(records []map[string]any) {
+	if len(q.orderBy) == 0 {
+		return
+	}
+
+	slices.SortStableFunc(records, func(left, right map[string]any) int {
+		for _, order := range q.orderBy {
+			_, leftValue := lookupField(left, order.field)
+			_, rightValue := lookupField(right, order.field)
+
+			result := compareForSort(leftValue, rightValue, order.nullsFirst != order.descending)
+			if order.descending {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	})
+}
+
+// likePattern converts a LIKE pattern into a case-insensitive regular expression.
+func likePattern(pattern string) *regexp.Regexp {
+	var builder strings.Builder
+
+	builder.WriteString("(?is)^")
+
+	for _, char := range pattern {
+		switch char {
+		case '%':
+			builder.WriteString(".*")
+		case '_':
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	builder.WriteString("$")
+
+	return regexp.MustCompile(builder.String())
+}
+
+// splitClauses returns the text of each clause that is present, keyed by its keyword.
+func splitClauses(text string) map[string]string {
+	type position struct {
+		clause string
+		index  int
+	}
+
+	positions := make([]position, 0, len(soqlClauses))
+
+	for _, clause := range soqlClauses {
+		if index := indexTopLevel(text, clause); index >= 0 {
+			positions = append(positions, position{clause: clause, index: index})
+		}
+	}
+
+	slices.SortFunc(positions, func(a, b position) int { return a.index - b.index })
+
+	clauses := make(map[string]string, len(positions))
+
+	for index, current := range positions {
+		end := len(text)
+		if index+1 < len(positions) {
+			end = positions[index+1].index
+		}
+
+		clauses[current.clause] = strings.TrimSpace(text[current.index+len(current.clause) : end])
+	}
+
+	return clauses
+}
+
+// indexTopLevel finds the keyword, ignoring case, outside of quotes and parentheses.
+func indexTopLevel(text, keyword string) int {
+	depth, quoted := 0, false
+	upper, keyword := strings.ToUpper(text), strings.ToUpper(keyword)
+
+	for index := 0; index < len(text); index++ {
+		switch char := text[index]; {
+		case char == '\\' && quoted:
+			index++
+		case char == '\'':
+			quoted = !quoted
+		case quoted:
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(upper[index:], keyword):
+			return index
+		}
+	}
+
+	return -1
+}
+
+// splitTopLevel splits the text by the separator, ignoring case, outside of quotes and parentheses.
+func splitTopLevel(text, separator string) []string {
+	parts := make([]string, 0)
+
+	for {
+		index := indexTopLevel(text, separator)
+		if index < 0 {
+			return append(parts, text)
+		}
+
+		parts = append(parts, text[:index])
+		text = text[index+len(separator):]
+	}
+}
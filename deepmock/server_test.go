@@ -0,0 +1,245 @@
+package deepmock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/amp-labs/connectors/providers"
+	"github.com/amp-labs/connectors/providers/hubspot"
+	"github.com/amp-labs/connectors/providers/salesforce"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seedPersons(t *testing.T, conn *Connector, count int, updated func(index int) int64) {
+	t.Helper()
+
+	for index := range count {
+		_, err := conn.Write(context.Background(), common.WriteParams{
+			ObjectName: "persons",
+			RecordData: map[string]any{
+				"id":      fmt.Sprintf("person-%04d", index),
+				"name":    fmt.Sprintf("Person %d", index),
+				"email":   fmt.Sprintf("person%d@example.com", index),
+				"age":     20 + index%50,
+				"updated": updated(index),
+			},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func readAll(t *testing.T, conn interface {
+	Read(ctx context.Context, params common.ReadParams) (*common.ReadResult, error)
+}, params common.ReadParams,
+) ([]common.ReadResultRow, int) {
+	t.Helper()
+
+	rows := make([]common.ReadResultRow, 0)
+	pages := 0
+
+	for {
+		result, err := conn.Read(context.Background(), params)
+		require.NoError(t, err)
+
+		rows = append(rows, result.Data...)
+		pages++
+
+		if result.Done {
+			return rows, pages
+		}
+
+		params.NextPage = result.NextPage
+	}
+}
+
+func TestServer_HubSpot(t *testing.T) {
+	t.Parallel()
+
+	mock, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seedPersons(t, mock, 150, func(index int) int64 {
+		return baseTime.Add(time.Duration(index) * time.Hour).Unix()
+	})
+
+	server, err := mock.Server(DialectHubSpot)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	conn, err := hubspot.NewConnector(
+		hubspot.WithAuthenticatedClient(RedirectClient(server)),
+		hubspot.WithModule(providers.ModuleHubspotCRM),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Full read is paginated with paging.next.link
+	rows, pages := readAll(t, conn, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name", "age"),
+	})
+	require.Len(t, rows, 150)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, "person-0000", rows[0].Id)
+	assert.Equal(t, map[string]any{"name": "Person 0", "age": "20"}, rows[0].Fields)
+
+	// Incremental read uses the search endpoint with filters on the last modified date
+	rows, _ = readAll(t, conn, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+		Since:      baseTime.Add(100 * time.Hour),
+		Until:      baseTime.Add(109 * time.Hour),
+	})
+	require.Len(t, rows, 10)
+	assert.Equal(t, "person-0100", rows[0].Id)
+
+	// Records written by the connector are stored by deepmock
+	created, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "New Person", "email": "new@example.com"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.RecordId)
+
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordId:   created.RecordId,
+		RecordData: map[string]any{"name": "Renamed Person"},
+	})
+	require.NoError(t, err)
+
+	stored, err := mock.storage.Get("persons", created.RecordId)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed Person", stored["name"])
+	assert.Equal(t, "new@example.com", stored["email"])
+
+	// Invalid records are rejected as bad requests
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "No Email"},
+	})
+	require.ErrorIs(t, err, common.ErrBadRequest)
+}
+
+func TestServer_Salesforce(t *testing.T) {
+	t.Parallel()
+
+	mock, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seedPersons(t, mock, 2100, func(index int) int64 {
+		return baseTime.Add(time.Duration(index) * time.Minute).Unix()
+	})
+
+	server, err := mock.Server(DialectSalesforce)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	conn, err := salesforce.NewConnector(
+		salesforce.WithAuthenticatedClient(RedirectClient(server)),
+		salesforce.WithWorkspace("test-workspace"),
+		salesforce.WithModule(providers.ModuleSalesforceCRM),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Full read is paginated with nextRecordsUrl
+	rows, pages := readAll(t, conn, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("Id", "name"),
+	})
+	require.Len(t, rows, 2100)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, "person-0000", rows[0].Id)
+	assert.Equal(t, "Person 0", rows[0].Fields["name"])
+
+	// Incremental read filters on SystemModstamp, Since is exclusive
+	rows, _ = readAll(t, conn, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("Id"),
+		Since:      baseTime.Add(2000 * time.Minute),
+		Until:      baseTime.Add(2010 * time.Minute),
+	})
+	require.Len(t, rows, 10)
+	assert.Equal(t, "person-2001", rows[0].Id)
+
+	// Records written by the connector are stored by deepmock
+	created, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "New Person", "email": "new@example.com"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.RecordId)
+
+	updated, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordId:   created.RecordId,
+		RecordData: map[string]any{"name": "Renamed Person"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, created.RecordId, updated.RecordId)
+
+	result, err := conn.Query(ctx, salesforce.QueryParams{
+		Query: salesforce.NewSOQL("persons").
+			SelectFields([]string{"Id", "name", "email"}).
+			WhereField("name", "=", "Renamed Person"),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Data, 1)
+	assert.Equal(t, created.RecordId, result.Data[0].Id)
+	assert.Equal(t, "new@example.com", result.Data[0].Fields["email"])
+
+	// Deleted records are read from the recycle bin
+	require.NoError(t, mock.storage.Delete("persons", created.RecordId))
+
+	rows, _ = readAll(t, conn, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("Id"),
+		Deleted:    true,
+	})
+	require.Len(t, rows, 1)
+	assert.Equal(t, created.RecordId, rows[0].Id)
+
+	// Unknown query locator means the cursor is gone
+	_, err = conn.Read(ctx, common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("Id"),
+		NextPage:   "/services/data/v60.0/query/01g999-2000",
+	})
+	require.ErrorIs(t, err, common.ErrCursorGone)
+}
+
+func TestParseSOQLQuery(t *testing.T) {
+	t.Parallel()
+
+	query, err := parseSOQLQuery("SELECT Id,Name,(SELECT Id FROM Contacts) FROM Account " +
+		"WHERE Name LIKE 'Acme%' AND Industry IN ('Tech','O\\'Brien') AND IsDeleted = true " +
+		"ORDER BY Name DESC NULLS LAST,Id LIMIT 10 OFFSET 5")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Id", "Name"}, query.fields)
+	assert.Equal(t, "Account", query.object)
+	assert.True(t, query.deleted)
+	assert.Equal(t, 10, query.limit)
+	assert.Equal(t, 5, query.offset)
+	assert.Equal(t, []soqlCondition{
+		{field: "Name", operator: "LIKE", value: "Acme%"},
+		{field: "Industry", operator: "IN", value: []any{"Tech", "O'Brien"}},
+	}, query.conditions)
+	assert.Equal(t, []soqlOrder{
+		{field: "Name", descending: true},
+		{field: "Id", nullsFirst: true},
+	}, query.orderBy)
+
+	_, err = parseSOQLQuery("SELECT Id FROM Account WHERE Name = 'a' OR Name = 'b'")
+	require.ErrorIs(t, err, ErrUnsupportedQuery)
+}
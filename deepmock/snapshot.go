@@ -0,0 +1,239 @@
+package deepmock
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// maxSnapshotLineSize limits the size of a single record in a snapshot.
+const maxSnapshotLineSize = 16 * 1024 * 1024
+
+// snapshotEntry is a line of a snapshot, a single record of an object.
+//
+// Example:
+//
+//	{"object":"contacts","id":"c-1","record":{"id":"c-1","name":"Jane"}}
+//	{"object":"contacts","id":"c-2","record":{"id":"c-2","name":"John"},"deletedAt":"2024-01-02T15:04:05Z"}
+type snapshotEntry struct {
+	Object string `json:"object"`
+	// ID may be omitted when the record holds it in the x-amp-id-field.
+	ID     string         `json:"id,omitempty"`
+	Record map[string]any `json:"record"`
+	// DeletedAt is set for deleted records, which are restored as tombstones.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// Snapshot writes all records, including deleted ones, as JSON lines.
+// Records are grouped by object and ordered by ID, so snapshots of the same data are identical.
+func (s *Storage) Snapshot(writer io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeTombstones(s.now())
+
+	encoder := json.NewEncoder(writer)
+
+	objectNames := slices.Sorted(maps.Keys(s.data))
+	for _, objectName := range slices.Sorted(maps.Keys(s.tombstones)) {
+		if _, exists := s.data[objectName]; !exists {
+			objectNames = append(objectNames, objectName)
+		}
+	}
+
+	for _, objectName := range objectNames {
+		for _, recordID := range slices.Sorted(maps.Keys(s.data[objectName])) {
+			if err := encoder.Encode(snapshotEntry{
+				Object: string(objectName),
+				ID:     string(recordID),
+				Record: s.data[objectName][recordID],
+			}); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+		}
+
+		tombstones := slices.SortedFunc(maps.Values(s.tombstones[objectName]), func(a, b tombstone) int {
+			return cmp.Compare(a.recordID, b.recordID)
+		})
+
+		for _, entry := range tombstones {
+			if err := encoder.Encode(snapshotEntry{
+				Object:    string(objectName),
+				ID:        string(entry.recordID),
+				Record:    entry.record,
+				DeletedAt: &entry.deletedAt,
+			}); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Restore replaces all records with the ones of a snapshot.
+// The storage is left unchanged if the snapshot is invalid or refers to unknown objects.
+func (s *Storage) Restore(reader io.Reader) error {
+	entries, err := s.readSnapshot(reader)
+	if err != nil {
+		return err
+	}
+
+	return s.restore(entries)
+}
+
+// readSnapshot decodes entries of a snapshot, filling in IDs from the x-amp-id-field of records.
+func (s *Storage) readSnapshot(reader io.Reader) ([]snapshotEntry, error) {
+	var entries []snapshotEntry
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, maxSnapshotLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		// Numbers are kept as written until the ID is known, large integer IDs would be formatted as floats.
+		decoder := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		decoder.UseNumber()
+
+		var entry snapshotEntry
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidSnapshot, line, err)
+		}
+
+		if entry.Object == "" || entry.Record == nil {
+			return nil, fmt.Errorf("%w: line %d: object and record are required", ErrInvalidSnapshot, line)
+		}
+
+		if entry.ID == "" {
+			if entry.Record[s.idFields[ObjectName(entry.Object)]] == nil {
+				return nil, fmt.Errorf("%w: line %d: record has no id", ErrInvalidSnapshot, line)
+			}
+
+			entry.ID = s.recordID(entry.Object, entry.Record)
+		}
+
+		record, err := deepCopyRecord(entry.Record)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidSnapshot, line, err)
+		}
+
+		entry.Record = record
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	return entries, nil
+}
+
+// restore replaces all records with the entries.
+func (s *Storage) restore(entries []snapshotEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := make(map[ObjectName]map[RecordID]common.Record, len(s.data))
+	for objectName := range s.data {
+		data[objectName] = make(map[RecordID]common.Record)
+	}
+
+	tombstones := make(map[ObjectName]map[RecordID]tombstone)
+
+	for _, entry := range entries {
+		objectName := ObjectName(entry.Object)
+		if _, exists := data[objectName]; !exists {
+			return fmt.Errorf("%w: %s", ErrSchemaNotFound, entry.Object)
+		}
+
+		record := entry.Record
+
+		if entry.DeletedAt == nil {
+			data[objectName][RecordID(entry.ID)] = record
+
+			continue
+		}
+
+		if _, exists := tombstones[objectName]; !exists {
+			tombstones[objectName] = make(map[RecordID]tombstone)
+		}
+
+		tombstones[objectName][RecordID(entry.ID)] = tombstone{
+			recordID:  RecordID(entry.ID),
+			record:    record,
+			deletedAt: *entry.DeletedAt,
+		}
+	}
+
+	s.data = data
+	s.tombstones = tombstones
+
+	return nil
+}
+
+// loadFixtures restores records of fixture files matching the patterns.
+// Records of every object are validated against its schema.
+func (c *Connector) loadFixtures(fsys fs.FS, patterns []string) error {
+	var entries []snapshotEntry
+
+	for _, pattern := range patterns {
+		paths, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+
+		if len(paths) == 0 {
+			return fmt.Errorf("%w: no fixture files match %q", ErrInvalidSnapshot, pattern)
+		}
+
+		for _, path := range paths {
+			fileEntries, err := c.readFixture(fsys, path)
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, fileEntries...)
+		}
+	}
+
+	return c.storage.restore(entries)
+}
+
+func (c *Connector) readFixture(fsys fs.FS, path string) ([]snapshotEntry, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries, err := c.storage.readSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("fixture %s: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		schema, exists := c.schemas.Get(entry.Object)
+		if !exists {
+			return nil, fmt.Errorf("fixture %s: %w: %s", path, ErrSchemaNotFound, entry.Object)
+		}
+
+		if err := validateRecord(schema, entry.Record); err != nil {
+			return nil, fmt.Errorf("fixture %s: record %s: %w", path, entry.ID, err)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,122 @@
+package deepmock
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	source, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	seedPersons(t, source, 5, func(index int) int64 {
+		return int64(1700000000 + index)
+	})
+
+	_, err = source.Delete(context.Background(), common.DeleteParams{ObjectName: "persons", RecordId: "person-0003"})
+	require.NoError(t, err)
+
+	var snapshot bytes.Buffer
+	require.NoError(t, source.Storage().Snapshot(&snapshot))
+
+	lines := strings.Split(strings.TrimSpace(snapshot.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Contains(t, lines[0], `"object":"persons","id":"person-0000"`)
+	assert.Contains(t, lines[4], `"id":"person-0003"`)
+	assert.Contains(t, lines[4], `"deletedAt"`)
+
+	target, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	seedPersons(t, target, 1, func(int) int64 { return 0 })
+	require.NoError(t, target.Storage().Restore(bytes.NewReader(snapshot.Bytes())))
+
+	// Restored storage is identical, including deleted records.
+	var restored bytes.Buffer
+	require.NoError(t, target.Storage().Snapshot(&restored))
+	assert.Equal(t, snapshot.String(), restored.String())
+
+	deleted, err := target.Read(context.Background(), common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("id"),
+		Deleted:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, deleted.Data, 1)
+	assert.Equal(t, "person-0003", deleted.Data[0].Id)
+
+	// Invalid snapshots leave the storage unchanged.
+	err = target.Storage().Restore(strings.NewReader(`{"object":"unknown","id":"1","record":{}}`))
+	require.ErrorIs(t, err, ErrSchemaNotFound)
+
+	err = target.Storage().Restore(strings.NewReader("{not json}\n"))
+	require.ErrorIs(t, err, ErrInvalidSnapshot)
+
+	records, err := target.Storage().GetAll("persons")
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+}
+
+func TestWithFixtures(t *testing.T) {
+	t.Parallel()
+
+	fixtures := fstest.MapFS{
+		"crm/persons.jsonl": {Data: []byte(strings.Join([]string{
+			`{"object":"persons","record":{"id":"jane","name":"Jane","email":"jane@example.com","updated":1}}`,
+			``,
+			`{"object":"persons","record":{"id":"john","name":"John","email":"john@example.com","updated":2}}`,
+			`{"object":"persons","record":{"id":"gone","name":"Gone","email":"gone@example.com"},` +
+				`"deletedAt":"2024-01-02T00:00:00Z"}`,
+		}, "\n"))},
+		"crm/products.jsonl": {Data: []byte(
+			`{"object":"products","record":{"id":1000000,"name":"Widget","price":10,"category":"electronics"}}`,
+		)},
+		"invalid/persons.jsonl": {Data: []byte(`{"object":"persons","record":{"id":"nameless","age":20}}`)},
+	}
+
+	conn, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema, "products": testProductSchema}),
+		WithFixtures(fixtures, "crm/*.jsonl"),
+	)
+	require.NoError(t, err)
+
+	result, err := conn.Read(context.Background(), common.ReadParams{
+		ObjectName: "persons",
+		Fields:     datautils.NewStringSet("name"),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Data, 2)
+	assert.Equal(t, "jane", result.Data[0].Id)
+
+	deleted, err := conn.Storage().ListDeleted("persons", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+
+	// Integer IDs are not formatted as floats.
+	product, err := conn.Storage().Get("products", "1000000")
+	require.NoError(t, err)
+	assert.Equal(t, "Widget", product["name"])
+
+	_, err = NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFixtures(fixtures, "invalid/*.jsonl"),
+	)
+	require.ErrorIs(t, err, ErrValidationFailed)
+
+	_, err = NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithFixtures(fixtures, "missing/*.jsonl"),
+	)
+	require.ErrorIs(t, err, ErrInvalidSnapshot)
+}
@@ -1,9 +1,12 @@
 package deepmock
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -13,19 +16,32 @@ import (
 )
 
 // Storage provides thread-safe in-memory storage for records.
+// Deleted records are kept as tombstones, which can be listed until the retention period expires.
 type Storage struct {
 	mu            sync.RWMutex
 	data          map[ObjectName]map[RecordID]common.Record // objectName -> recordID -> record
+	tombstones    map[ObjectName]map[RecordID]tombstone     // objectName -> recordID -> deleted record
 	idFields      map[ObjectName]string                     // objectName -> ID field name
 	updatedFields map[ObjectName]string                     // objectName -> updated timestamp field name
+	retention     time.Duration                             // how long tombstones are kept, zero keeps them forever
+	now           func() time.Time
+}
+
+// tombstone is a deleted record together with the time of deletion.
+type tombstone struct {
+	recordID  RecordID
+	record    common.Record
+	deletedAt time.Time
 }
 
 // NewStorage creates a new Storage instance.
 func NewStorage(schemas schemaRegistry, idFields, updatedFields map[string]string) *Storage {
 	storage := &Storage{
 		data:          make(map[ObjectName]map[RecordID]common.Record),
+		tombstones:    make(map[ObjectName]map[RecordID]tombstone),
 		idFields:      make(map[ObjectName]string),
 		updatedFields: make(map[ObjectName]string),
+		now:           time.Now,
 	}
 
 	// Initialize object maps and convert string keys to typed keys
@@ -106,6 +122,9 @@ func (s *Storage) Store(objectName, recordID string, record map[string]any) erro
 
 	s.data[objName][RecordID(recordID)] = recordCopy
 
+	// Storing a deleted record brings it back.
+	delete(s.tombstones[objName], RecordID(recordID))
+
 	return nil
 }
 
@@ -157,28 +176,101 @@ func (s *Storage) GetAll(objectName string) ([]map[string]any, error) {
 	return copies, nil
 }
 
-// Delete removes a record by ID.
+// Delete removes a record by ID, leaving a tombstone with the deletion time.
 func (s *Storage) Delete(objectName, recordID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	objectData, exists := s.data[ObjectName(objectName)]
+	objName := ObjectName(objectName)
+
+	objectData, exists := s.data[objName]
 	if !exists {
 		return fmt.Errorf("%w: object %s", ErrRecordNotFound, objectName)
 	}
 
-	if _, exists := objectData[RecordID(recordID)]; !exists {
+	record, exists := objectData[RecordID(recordID)]
+	if !exists {
 		return fmt.Errorf("%w: record %s", ErrRecordNotFound, recordID)
 	}
 
 	delete(objectData, RecordID(recordID))
 
+	if _, exists := s.tombstones[objName]; !exists {
+		s.tombstones[objName] = make(map[RecordID]tombstone)
+	}
+
+	now := s.now()
+
+	s.tombstones[objName][RecordID(recordID)] = tombstone{
+		recordID:  RecordID(recordID),
+		record:    record,
+		deletedAt: now,
+	}
+
+	s.purgeTombstones(now)
+
 	return nil
 }
 
+// ListDeleted retrieves deleted records whose deletion time is within the time range.
+// Records are ordered by deletion time.
+func (s *Storage) ListDeleted(objectName string, since, until time.Time) ([]map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purgeTombstones(s.now())
+
+	deleted := make([]tombstone, 0)
+
+	for _, entry := range s.tombstones[ObjectName(objectName)] {
+		if !since.IsZero() && entry.deletedAt.Before(since) {
+			continue
+		}
+
+		if !until.IsZero() && entry.deletedAt.After(until) {
+			continue
+		}
+
+		deleted = append(deleted, entry)
+	}
+
+	slices.SortFunc(deleted, func(a, b tombstone) int {
+		return cmp.Or(a.deletedAt.Compare(b.deletedAt), cmp.Compare(a.recordID, b.recordID))
+	})
+
+	records := make([]map[string]any, len(deleted))
+	for index, entry := range deleted {
+		records[index] = entry.record
+	}
+
+	copies, err := deepCopyRecords(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy records: %w", err)
+	}
+
+	return copies, nil
+}
+
+// purgeTombstones removes tombstones older than the retention period.
+// Caller must hold the write lock.
+func (s *Storage) purgeTombstones(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+
+	expiry := now.Add(-s.retention)
+
+	for _, objectTombstones := range s.tombstones {
+		for recordID, entry := range objectTombstones {
+			if entry.deletedAt.Before(expiry) {
+				delete(objectTombstones, recordID)
+			}
+		}
+	}
+}
+
 // List retrieves records filtered by time range.
-//
-//nolint:cyclop,funlen,gocognit // Complex timestamp parsing and time range filtering
+// Records are ordered by ID, so consecutive pages of the same listing are stable.
 func (s *Storage) List(objectName string, since, until time.Time) ([]map[string]any, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -190,68 +282,31 @@ func (s *Storage) List(objectName string, since, until time.Time) ([]map[string]
 
 	// Get the updated field name for this object
 	updatedField := s.updatedFields[ObjectName(objectName)]
+	filtering := !since.IsZero() || !until.IsZero()
 
 	records := make([]map[string]any, 0)
 
-	for _, record := range objectData {
-		// If no updated field, include all records
-		if updatedField == "" {
+	for _, recordID := range slices.Sorted(maps.Keys(objectData)) {
+		record := objectData[recordID]
+
+		// If no updated field or no time range, include all records
+		if updatedField == "" || !filtering {
 			records = append(records, record)
 
 			continue
 		}
 
-		// Filter by timestamp if updated field exists
-		//nolint:nestif // Complexity from timestamp type checking and time range filtering
-		if updatedValue, exists := record[updatedField]; exists {
-			var recordTime time.Time
-
-			hasValidTimestamp := false
-
-			switch updatedVal := updatedValue.(type) {
-			case string:
-				// Try parsing as RFC3339
-				if parsedTime, err := time.Parse(time.RFC3339, updatedVal); err == nil {
-					recordTime = parsedTime
-					hasValidTimestamp = true
-				}
-			case int64:
-				// Unix timestamp
-				recordTime = time.Unix(updatedVal, 0)
-				hasValidTimestamp = true
-			case int:
-				// Unix timestamp as int
-				recordTime = time.Unix(int64(updatedVal), 0)
-				hasValidTimestamp = true
-			case float64:
-				// Unix timestamp as float
-				recordTime = time.Unix(int64(updatedVal), 0)
-				hasValidTimestamp = true
-			case json.Number:
-				// Handle json.Number type
-				if intVal, err := updatedVal.Int64(); err == nil {
-					recordTime = time.Unix(intVal, 0)
-					hasValidTimestamp = true
-				}
-			}
-
-			// If time filtering is active and timestamp is invalid, skip the record
-			if (!since.IsZero() || !until.IsZero()) && !hasValidTimestamp {
-				continue
-			}
+		// If updated field is missing or its timestamp is invalid, skip the record
+		recordTime, ok := parseRecordTime(record[updatedField])
+		if !ok {
+			continue
+		}
 
-			// Apply time range filtering only if we have a valid timestamp
-			if hasValidTimestamp {
-				if !since.IsZero() && recordTime.Before(since) {
-					continue
-				}
+		if !since.IsZero() && recordTime.Before(since) {
+			continue
+		}
 
-				if !until.IsZero() && recordTime.After(until) {
-					continue
-				}
-			}
-		} else if !since.IsZero() || !until.IsZero() {
-			// If updated field doesn't exist and time filtering is active, skip the record
+		if !until.IsZero() && recordTime.After(until) {
 			continue
 		}
 
@@ -267,6 +322,49 @@ func (s *Storage) List(objectName string, since, until time.Time) ([]map[string]
 	return copies, nil
 }
 
+// UpdatedTime returns the value of the updated timestamp field of the record.
+// Returns false if the object has no such field or the value is not a valid timestamp.
+func (s *Storage) UpdatedTime(objectName string, record map[string]any) (time.Time, bool) {
+	updatedField := s.updatedFields[ObjectName(objectName)]
+	if updatedField == "" {
+		return time.Time{}, false
+	}
+
+	return parseRecordTime(record[updatedField])
+}
+
+// recordID returns the value of the ID field of the record as text.
+func (s *Storage) recordID(objectName string, record map[string]any) string {
+	idField := s.idFields[ObjectName(objectName)]
+	if idField == "" {
+		return ""
+	}
+
+	return fmt.Sprint(record[idField])
+}
+
+// parseRecordTime converts a timestamp field value, either RFC3339 string or Unix seconds.
+func parseRecordTime(value any) (time.Time, bool) {
+	switch value := value.(type) {
+	case string:
+		parsedTime, err := time.Parse(time.RFC3339, value)
+
+		return parsedTime, err == nil
+	case int64:
+		return time.Unix(value, 0), true
+	case int:
+		return time.Unix(int64(value), 0), true
+	case float64:
+		return time.Unix(int64(value), 0), true
+	case json.Number:
+		intVal, err := value.Int64()
+
+		return time.Unix(intVal, 0), err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
 // generateID generates an ID based on the schema's ID field type.
 //
 // IMPORTANT: This function must be used in the Write logic to auto-generate IDs for
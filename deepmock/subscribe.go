@@ -0,0 +1,492 @@
+package deepmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/future"
+	"github.com/google/uuid"
+)
+
+const (
+	// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of the payload, keyed by the subscription secret.
+	WebhookSignatureHeader = "X-Deepmock-Signature"
+	// WebhookSubscriptionHeader carries the ID of the subscription that matched the event.
+	WebhookSubscriptionHeader = "X-Deepmock-Subscription"
+
+	webhookDeliveryTimeout = 10 * time.Second
+)
+
+// SubscriptionRequest is the provider specific part of common.SubscribeParams.Request.
+type SubscriptionRequest struct {
+	// WebhookURL receives POST requests with event payloads.
+	// When empty, events are delivered to the handler configured by WithWebhookHandler.
+	WebhookURL string `json:"webhookUrl,omitempty"`
+	// Secret signs payloads, see VerifyWebhookMessage.
+	Secret string `json:"secret"`
+}
+
+// SubscriptionResult is the provider specific part of common.SubscriptionResult.Result.
+type SubscriptionResult struct {
+	ID         string `json:"id"`
+	WebhookURL string `json:"webhookUrl,omitempty"`
+}
+
+// WebhookVerificationParams is the provider specific part of common.VerificationParams.Param.
+type WebhookVerificationParams struct {
+	Secret string
+}
+
+// subscription is a registered webhook with the events it listens to.
+type subscription struct {
+	id      string
+	request SubscriptionRequest
+	events  map[common.ObjectName]common.ObjectEvents
+	// queue delivers events of the subscription one at a time, in the order they happened.
+	// It outlives updates of the subscription and is stopped once the subscription is deleted.
+	queue *webhookQueue
+}
+
+// subscriptions holds active webhooks of the connector.
+type subscriptions struct {
+	mu     sync.RWMutex
+	items  map[string]subscription
+	client *http.Client
+	// closed is set by Connector.Close, no subscriptions are accepted afterwards.
+	closed bool
+}
+
+func newSubscriptions() *subscriptions {
+	return &subscriptions{
+		items:  make(map[string]subscription),
+		client: &http.Client{Timeout: webhookDeliveryTimeout},
+	}
+}
+
+// webhookDelivery is an event together with the subscription state at the time of the event.
+type webhookDelivery struct {
+	sub   subscription
+	event SubscriptionEvent
+}
+
+// webhookQueue is an unbounded FIFO of deliveries served by a single worker.
+// Producers never block, so operations emitting events are not slowed down by slow webhooks.
+type webhookQueue struct {
+	mu      sync.Mutex
+	pending []webhookDelivery
+	wake    chan struct{}
+	// ctx is canceled once the queue is closed, aborting the delivery in progress.
+	ctx    context.Context // nolint:containedctx
+	cancel context.CancelFunc
+	worker *future.Future[struct{}]
+}
+
+func newWebhookQueue(deliver func(context.Context, webhookDelivery)) *webhookQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	queue := &webhookQueue{
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	queue.worker = future.Go[struct{}](func() (struct{}, error) {
+		queue.run(deliver)
+
+		return struct{}{}, nil
+	})
+
+	return queue
+}
+
+func (q *webhookQueue) push(delivery webhookDelivery) {
+	q.mu.Lock()
+	q.pending = append(q.pending, delivery)
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+		// Worker is already notified.
+	}
+}
+
+// close stops the worker, deliveries which haven't started are dropped.
+func (q *webhookQueue) close() {
+	q.cancel()
+}
+
+// wait blocks until the worker of a closed queue has returned.
+func (q *webhookQueue) wait() {
+	_, _ = q.worker.Await()
+}
+
+func (q *webhookQueue) run(deliver func(context.Context, webhookDelivery)) {
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-q.wake:
+		}
+
+		for {
+			delivery, ok := q.pop()
+			if !ok {
+				break
+			}
+
+			select {
+			case <-q.ctx.Done():
+				return
+			default:
+				deliver(q.ctx, delivery)
+			}
+		}
+	}
+}
+
+func (q *webhookQueue) pop() (webhookDelivery, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return webhookDelivery{}, false
+	}
+
+	delivery := q.pending[0]
+	q.pending[0] = webhookDelivery{}
+	q.pending = q.pending[1:]
+
+	return delivery, true
+}
+
+func (c *Connector) EmptySubscriptionParams() *common.SubscribeParams {
+	return &common.SubscribeParams{
+		Request: &SubscriptionRequest{},
+	}
+}
+
+func (c *Connector) EmptySubscriptionResult() *common.SubscriptionResult {
+	return &common.SubscriptionResult{
+		Result: &SubscriptionResult{},
+	}
+}
+
+// Subscribe registers a webhook for the objects and events of the params.
+// From now on every Write and Delete matching the subscription is delivered as a signed SubscriptionEvent.
+func (c *Connector) Subscribe(
+	_ context.Context,
+	params common.SubscribeParams,
+) (*common.SubscriptionResult, error) {
+	request, err := c.validateSubscribeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := subscription{
+		id:      uuid.New().String(),
+		request: *request,
+		events:  cloneObjectEvents(params.SubscriptionEvents),
+		queue:   newWebhookQueue(c.deliverWebhook),
+	}
+
+	c.subscriptions.mu.Lock()
+	defer c.subscriptions.mu.Unlock()
+
+	if c.subscriptions.closed {
+		sub.queue.close()
+
+		return nil, ErrConnectorClosed
+	}
+
+	c.subscriptions.items[sub.id] = sub
+
+	return sub.result(), nil
+}
+
+// UpdateSubscription replaces the objects, events and webhook of a previously created subscription.
+func (c *Connector) UpdateSubscription(
+	_ context.Context,
+	params common.SubscribeParams,
+	previousResult *common.SubscriptionResult,
+) (*common.SubscriptionResult, error) {
+	request, err := c.validateSubscribeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if previousResult == nil {
+		return nil, fmt.Errorf("%w: previous subscription result", ErrMissingParam)
+	}
+
+	subscriptionID, err := subscriptionIDOf(*previousResult)
+	if err != nil {
+		return nil, err
+	}
+
+	c.subscriptions.mu.Lock()
+	defer c.subscriptions.mu.Unlock()
+
+	previous, exists := c.subscriptions.items[subscriptionID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSubscriptionNotFound, subscriptionID)
+	}
+
+	sub := subscription{
+		id:      subscriptionID,
+		request: *request,
+		events:  cloneObjectEvents(params.SubscriptionEvents),
+		queue:   previous.queue,
+	}
+
+	c.subscriptions.items[subscriptionID] = sub
+
+	return sub.result(), nil
+}
+
+// DeleteSubscription stops webhook deliveries of the subscription.
+func (c *Connector) DeleteSubscription(_ context.Context, previousResult common.SubscriptionResult) error {
+	subscriptionID, err := subscriptionIDOf(previousResult)
+	if err != nil {
+		return err
+	}
+
+	c.subscriptions.mu.Lock()
+	defer c.subscriptions.mu.Unlock()
+
+	sub, exists := c.subscriptions.items[subscriptionID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSubscriptionNotFound, subscriptionID)
+	}
+
+	delete(c.subscriptions.items, subscriptionID)
+	sub.queue.close()
+
+	return nil
+}
+
+// Close stops webhook deliveries of all subscriptions and waits for their workers to return.
+// Deliveries in progress are aborted, queued ones are dropped. Subscribe fails after Close.
+func (c *Connector) Close() error {
+	c.subscriptions.mu.Lock()
+
+	c.subscriptions.closed = true
+	queues := make([]*webhookQueue, 0, len(c.subscriptions.items))
+
+	for id, sub := range c.subscriptions.items {
+		sub.queue.close()
+		queues = append(queues, sub.queue)
+		delete(c.subscriptions.items, id)
+	}
+
+	c.subscriptions.mu.Unlock()
+
+	// Workers are awaited without the lock, a delivery may be emitting events of its own.
+	for _, queue := range queues {
+		queue.wait()
+	}
+
+	return nil
+}
+
+// VerifyWebhookMessage checks the signature of a payload sent by the connector.
+// Returns (true, nil) if signature verification succeeds, otherwise (false, error).
+func (c *Connector) VerifyWebhookMessage(
+	_ context.Context,
+	request *common.WebhookRequest,
+	params *common.VerificationParams,
+) (bool, error) {
+	if request == nil || params == nil {
+		return false, fmt.Errorf("%w: request and params cannot be nil", ErrMissingParam)
+	}
+
+	verificationParams, err := common.AssertType[*WebhookVerificationParams](params.Param)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrMissingParam, err)
+	}
+
+	signature := request.Headers.Get(WebhookSignatureHeader)
+	if signature == "" {
+		return false, fmt.Errorf("%w: missing %s header", ErrInvalidSignature, WebhookSignatureHeader)
+	}
+
+	if !verifySignature(verificationParams.Secret, request.Body, signature) {
+		return false, fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+
+	return true, nil
+}
+
+func (c *Connector) validateSubscribeParams(params common.SubscribeParams) (*SubscriptionRequest, error) {
+	request, err := common.AssertType[*SubscriptionRequest](params.Request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMissingParam, err)
+	}
+
+	if request.WebhookURL == "" && c.params.webhookHandler == nil {
+		return nil, fmt.Errorf("%w: webhook URL or handler", ErrMissingParam)
+	}
+
+	if len(params.SubscriptionEvents) == 0 {
+		return nil, fmt.Errorf("%w: subscription events", ErrMissingParam)
+	}
+
+	for objectName := range params.SubscriptionEvents {
+		if _, exists := c.schemas.Get(objectName.String()); !exists {
+			return nil, fmt.Errorf("%w: %s", ErrSchemaNotFound, objectName)
+		}
+	}
+
+	return request, nil
+}
+
+func (s subscription) result() *common.SubscriptionResult {
+	return &common.SubscriptionResult{
+		Result: &SubscriptionResult{
+			ID:         s.id,
+			WebhookURL: s.request.WebhookURL,
+		},
+		ObjectEvents: cloneObjectEvents(s.events),
+		Status:       common.SubscriptionStatusSuccess,
+	}
+}
+
+// matches reports whether the event of the object is subscribed to.
+// Updates are delivered only if one of the watched fields has changed.
+func (s subscription) matches(
+	objectName string, eventType common.SubscriptionEventType, updatedFields []string,
+) bool {
+	events, exists := s.events[common.ObjectName(objectName)]
+	if !exists || !slices.Contains(events.Events, eventType) {
+		return false
+	}
+
+	if eventType != common.SubscriptionEventTypeUpdate || events.WatchFieldsAll || len(events.WatchFields) == 0 {
+		return true
+	}
+
+	for _, field := range updatedFields {
+		if slices.Contains(events.WatchFields, field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func subscriptionIDOf(result common.SubscriptionResult) (string, error) {
+	previous, err := common.AssertType[*SubscriptionResult](result.Result)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMissingParam, err)
+	}
+
+	if previous.ID == "" {
+		return "", fmt.Errorf("%w: subscription ID", ErrMissingParam)
+	}
+
+	return previous.ID, nil
+}
+
+func cloneObjectEvents(events map[common.ObjectName]common.ObjectEvents) map[common.ObjectName]common.ObjectEvents {
+	cloned := make(map[common.ObjectName]common.ObjectEvents, len(events))
+
+	for objectName, objectEvents := range events {
+		cloned[objectName] = common.ObjectEvents{
+			Events:            slices.Clone(objectEvents.Events),
+			WatchFields:       slices.Clone(objectEvents.WatchFields),
+			WatchFieldsAll:    objectEvents.WatchFieldsAll,
+			PassThroughEvents: slices.Clone(objectEvents.PassThroughEvents),
+		}
+	}
+
+	return cloned
+}
+
+// emitWebhooks queues the event for every matching subscription.
+// Each subscription receives its events in order, one delivery at a time.
+// Deliveries are asynchronous, failures are logged and never affect the operation that caused the event.
+func (c *Connector) emitWebhooks(
+	eventType common.SubscriptionEventType,
+	objectName, recordID string,
+	record map[string]any,
+	updatedFields []string,
+) {
+	c.subscriptions.mu.RLock()
+	defer c.subscriptions.mu.RUnlock()
+
+	for _, sub := range c.subscriptions.items {
+		if !sub.matches(objectName, eventType, updatedFields) {
+			continue
+		}
+
+		recordCopy, err := deepCopyRecord(record)
+		if err != nil {
+			slog.Warn("deepCopyRecord failed", "error", err)
+
+			continue
+		}
+
+		sub.queue.push(webhookDelivery{
+			sub:   sub,
+			event: newSubscriptionEvent(sub.id, eventType, objectName, recordID, recordCopy, updatedFields),
+		})
+	}
+}
+
+func (c *Connector) deliverWebhook(ctx context.Context, delivery webhookDelivery) {
+	sub, event := delivery.sub, delivery.event
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		slog.Warn("failed to marshal webhook payload", "subscription", sub.id, "error", err)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookDeliveryTimeout)
+	defer cancel()
+
+	target := sub.request.WebhookURL
+	if target == "" {
+		target = "/"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		slog.Warn("failed to create webhook request", "subscription", sub.id, "error", err)
+
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSubscriptionHeader, sub.id)
+	req.Header.Set(WebhookSignatureHeader, computeSignature(sub.request.Secret, body))
+
+	if sub.request.WebhookURL == "" {
+		// The response of an in-process handler is of no interest.
+		c.params.webhookHandler.ServeHTTP(newResponseRecorder(), req)
+
+		return
+	}
+
+	rsp, err := c.subscriptions.client.Do(req)
+	if err != nil {
+		slog.Warn("failed to deliver webhook", "subscription", sub.id, "error", err)
+
+		return
+	}
+
+	_ = rsp.Body.Close()
+
+	if rsp.StatusCode >= http.StatusBadRequest {
+		slog.Warn("webhook was rejected", "subscription", sub.id, "status", rsp.StatusCode)
+	}
+}
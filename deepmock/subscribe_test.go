@@ -0,0 +1,338 @@
+package deepmock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookSink collects webhook requests delivered by the connector.
+type webhookSink chan *common.WebhookRequest
+
+func (s webhookSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s <- &common.WebhookRequest{
+		Headers: r.Header.Clone(),
+		Body:    body,
+		URL:     r.URL.String(),
+		Method:  r.Method,
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s webhookSink) next(t *testing.T) *common.WebhookRequest {
+	t.Helper()
+
+	select {
+	case request := <-s:
+		return request
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+
+		return nil
+	}
+}
+
+func decodeEvent(t *testing.T, request *common.WebhookRequest) SubscriptionEvent {
+	t.Helper()
+
+	var event SubscriptionEvent
+	require.NoError(t, json.Unmarshal(request.Body, &event))
+
+	return event
+}
+
+func TestSubscribe_InProcessHandler(t *testing.T) {
+	t.Parallel()
+
+	sink := make(webhookSink, 10)
+
+	conn, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithWebhookHandler(sink),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	result, err := conn.Subscribe(ctx, common.SubscribeParams{
+		Request: &SubscriptionRequest{Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"persons": {
+				Events: []common.SubscriptionEventType{
+					common.SubscriptionEventTypeCreate,
+					common.SubscriptionEventTypeUpdate,
+					common.SubscriptionEventTypeDelete,
+				},
+				WatchFields: []string{"name"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, common.SubscriptionStatusSuccess, result.Status)
+
+	verification := &common.VerificationParams{Param: &WebhookVerificationParams{Secret: "secret"}}
+
+	// Create
+	created, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "Alice", "email": "alice@example.com"},
+	})
+	require.NoError(t, err)
+
+	request := sink.next(t)
+	valid, err := conn.VerifyWebhookMessage(ctx, request, verification)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	event := decodeEvent(t, request)
+	eventType, err := event.EventType()
+	require.NoError(t, err)
+	assert.Equal(t, common.SubscriptionEventTypeCreate, eventType)
+
+	recordID, err := event.RecordId()
+	require.NoError(t, err)
+	assert.Equal(t, created.RecordId, recordID)
+
+	updatedFields, err := event.UpdatedFields()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email", "id", "name", "updated"}, updatedFields)
+
+	// Update of a field which is not watched produces no event
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordId:   created.RecordId,
+		RecordData: map[string]any{"email": "alice@example.org"},
+	})
+	require.NoError(t, err)
+
+	// Update of a watched field
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordId:   created.RecordId,
+		RecordData: map[string]any{"name": "Alicia", "email": "alice@example.org"},
+	})
+	require.NoError(t, err)
+
+	event = decodeEvent(t, sink.next(t))
+	eventType, err = event.EventType()
+	require.NoError(t, err)
+	assert.Equal(t, common.SubscriptionEventTypeUpdate, eventType)
+
+	updatedFields, err = event.UpdatedFields()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "updated"}, updatedFields)
+
+	// Delete
+	_, err = conn.Delete(ctx, common.DeleteParams{ObjectName: "persons", RecordId: created.RecordId})
+	require.NoError(t, err)
+
+	event = decodeEvent(t, sink.next(t))
+	eventType, err = event.EventType()
+	require.NoError(t, err)
+	assert.Equal(t, common.SubscriptionEventTypeDelete, eventType)
+
+	objectName, err := event.ObjectName()
+	require.NoError(t, err)
+	assert.Equal(t, "persons", objectName)
+
+	// Unsubscribe
+	require.NoError(t, conn.DeleteSubscription(ctx, *result))
+	require.ErrorIs(t, conn.DeleteSubscription(ctx, *result), ErrSubscriptionNotFound)
+}
+
+func TestSubscribe_WebhookURL(t *testing.T) {
+	t.Parallel()
+
+	sink := make(webhookSink, 10)
+	server := httptest.NewServer(sink)
+	t.Cleanup(server.Close)
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	result, err := conn.Subscribe(ctx, common.SubscribeParams{
+		Request: &SubscriptionRequest{WebhookURL: server.URL + "/hooks", Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"persons": {Events: []common.SubscriptionEventType{common.SubscriptionEventTypeUpdate}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Move the subscription to creations
+	_, err = conn.UpdateSubscription(ctx, common.SubscribeParams{
+		Request: &SubscriptionRequest{WebhookURL: server.URL + "/hooks", Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"persons": {Events: []common.SubscriptionEventType{common.SubscriptionEventTypeCreate}},
+		},
+	}, result)
+	require.NoError(t, err)
+
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "Bob", "email": "bob@example.com"},
+	})
+	require.NoError(t, err)
+
+	request := sink.next(t)
+	assert.Equal(t, "/hooks", request.URL)
+	assert.Equal(t, http.MethodPost, request.Method)
+
+	subscriptionResult, ok := result.Result.(*SubscriptionResult)
+	require.True(t, ok)
+	assert.Equal(t, subscriptionResult.ID, request.Headers.Get(WebhookSubscriptionHeader))
+
+	// Signature made with another secret is rejected
+	valid, err := conn.VerifyWebhookMessage(ctx, request, &common.VerificationParams{
+		Param: &WebhookVerificationParams{Secret: "other"},
+	})
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	assert.False(t, valid)
+}
+
+func TestSubscribe_DeliversInOrder(t *testing.T) {
+	t.Parallel()
+
+	const records = 20
+
+	sink := make(webhookSink, records)
+	server := httptest.NewServer(sink)
+	t.Cleanup(server.Close)
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = conn.Subscribe(ctx, common.SubscribeParams{
+		Request: &SubscriptionRequest{WebhookURL: server.URL, Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"persons": {Events: []common.SubscriptionEventType{common.SubscriptionEventTypeCreate}},
+		},
+	})
+	require.NoError(t, err)
+
+	written := make([]string, records)
+
+	for index := range written {
+		result, err := conn.Write(ctx, common.WriteParams{
+			ObjectName: "persons",
+			RecordData: map[string]any{"name": "Person", "email": "person@example.com"},
+		})
+		require.NoError(t, err)
+
+		written[index] = result.RecordId
+	}
+
+	for _, expected := range written {
+		recordID, err := decodeEvent(t, sink.next(t)).RecordId()
+		require.NoError(t, err)
+		assert.Equal(t, expected, recordID)
+	}
+}
+
+func TestSubscribe_InvalidParams(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Neither webhook URL nor handler
+	_, err = conn.Subscribe(ctx, common.SubscribeParams{
+		Request: &SubscriptionRequest{Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"persons": {Events: []common.SubscriptionEventType{common.SubscriptionEventTypeCreate}},
+		},
+	})
+	require.ErrorIs(t, err, ErrMissingParam)
+
+	// Unknown object
+	_, err = conn.Subscribe(ctx, common.SubscribeParams{
+		Request: &SubscriptionRequest{WebhookURL: "http://localhost/hooks", Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"companies": {Events: []common.SubscriptionEventType{common.SubscriptionEventTypeCreate}},
+		},
+	})
+	require.ErrorIs(t, err, ErrSchemaNotFound)
+}
+
+func TestGetRecordsByIds(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnector(WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	created, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "Carol", "email": "carol@example.com"},
+	})
+	require.NoError(t, err)
+
+	rows, err := conn.GetRecordsByIds(ctx, "persons", []string{created.RecordId, "missing"}, []string{"name"}, nil)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, created.RecordId, rows[0].Id)
+	assert.Equal(t, map[string]any{"name": "Carol"}, rows[0].Fields)
+}
+
+func TestSubscribe_Close(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	// The handler holds the delivery until it is aborted.
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	})
+
+	conn, err := NewConnector(
+		WithSchemas(map[string]*InputSchema{"persons": testPersonSchema}),
+		WithWebhookHandler(handler),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	params := common.SubscribeParams{
+		Request: &SubscriptionRequest{Secret: "secret"},
+		SubscriptionEvents: map[common.ObjectName]common.ObjectEvents{
+			"persons": {Events: []common.SubscriptionEventType{common.SubscriptionEventTypeCreate}},
+		},
+	}
+
+	_, err = conn.Subscribe(ctx, params)
+	require.NoError(t, err)
+
+	_, err = conn.Write(ctx, common.WriteParams{
+		ObjectName: "persons",
+		RecordData: map[string]any{"name": "Alice", "email": "alice@example.com"},
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	// Close returns once the worker has stopped, the pending delivery is aborted.
+	require.NoError(t, conn.Close())
+
+	_, err = conn.Subscribe(ctx, params)
+	require.ErrorIs(t, err, ErrConnectorClosed)
+}
@@ -0,0 +1,141 @@
+package deepmock
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/google/uuid"
+)
+
+// SubscriptionEvent is the webhook payload sent on Write and Delete.
+type SubscriptionEvent map[string]any
+
+var (
+	_ common.SubscriptionEvent       = SubscriptionEvent{}
+	_ common.SubscriptionUpdateEvent = SubscriptionEvent{}
+)
+
+func newSubscriptionEvent(
+	subscriptionID string,
+	eventType common.SubscriptionEventType,
+	objectName, recordID string,
+	record map[string]any,
+	updatedFields []string,
+) SubscriptionEvent {
+	fields := make([]any, len(updatedFields))
+	for index, field := range updatedFields {
+		fields[index] = field
+	}
+
+	return SubscriptionEvent{
+		"id":             uuid.New().String(),
+		"subscriptionId": subscriptionID,
+		"eventName":      objectName + "." + string(eventType),
+		"eventType":      string(eventType),
+		"objectName":     objectName,
+		"recordId":       recordID,
+		"timestamp":      time.Now().UTC().Format(time.RFC3339Nano),
+		"updatedFields":  fields,
+		"record":         record,
+	}
+}
+
+func (evt SubscriptionEvent) EventType() (common.SubscriptionEventType, error) {
+	eventType, err := evt.asMap().GetString("eventType")
+	if err != nil {
+		return common.SubscriptionEventTypeOther, err
+	}
+
+	switch common.SubscriptionEventType(eventType) {
+	case common.SubscriptionEventTypeCreate,
+		common.SubscriptionEventTypeUpdate,
+		common.SubscriptionEventTypeDelete:
+		return common.SubscriptionEventType(eventType), nil
+	default:
+		return common.SubscriptionEventTypeOther, nil
+	}
+}
+
+func (evt SubscriptionEvent) RawEventName() (string, error) {
+	return evt.asMap().GetString("eventName")
+}
+
+func (evt SubscriptionEvent) ObjectName() (string, error) {
+	return evt.asMap().GetString("objectName")
+}
+
+// Workspace returns an empty string as there is no workspace concept in deepmock.
+func (evt SubscriptionEvent) Workspace() (string, error) {
+	return "", nil
+}
+
+func (evt SubscriptionEvent) RecordId() (string, error) {
+	return evt.asMap().GetString("recordId")
+}
+
+func (evt SubscriptionEvent) EventTimeStampNano() (int64, error) {
+	timestamp, err := evt.asMap().GetString("timestamp")
+	if err != nil {
+		return 0, err
+	}
+
+	eventTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing timestamp: %w", err)
+	}
+
+	return eventTime.UnixNano(), nil
+}
+
+func (evt SubscriptionEvent) RawMap() (map[string]any, error) {
+	return maps.Clone(evt), nil
+}
+
+// UpdatedFields returns fields that were set by Write. Created records list every field, deletions none.
+func (evt SubscriptionEvent) UpdatedFields() ([]string, error) {
+	value, err := evt.asMap().Get("updatedFields")
+	if err != nil {
+		return nil, err
+	}
+
+	switch fields := value.(type) {
+	case []string:
+		return fields, nil
+	case []any:
+		updatedFields := make([]string, len(fields))
+
+		for index, field := range fields {
+			name, ok := field.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: expected %T, got %T", ErrInvalidType, name, field)
+			}
+
+			updatedFields[index] = name
+		}
+
+		return updatedFields, nil
+	default:
+		return nil, fmt.Errorf("%w: expected %T, got %T", ErrInvalidType, fields, value)
+	}
+}
+
+// asMap returns the event as a StringMap.
+func (evt SubscriptionEvent) asMap() common.StringMap {
+	return common.StringMap(evt)
+}
+
+func computeSignature(secret string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func verifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(computeSignature(secret, body)))
+}
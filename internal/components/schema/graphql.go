@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/graphql"
+)
+
+// GraphQLSchemaProvider implements Provider using an introspected GraphQL schema.
+// Objects are root query fields, described by the type of records they return.
+// The schema is loaded once for all objects, connectors usually cache it via graphql.Introspector.
+type GraphQLSchemaProvider struct {
+	load        func(ctx context.Context) (*graphql.Schema, error)
+	displayName func(objectName string) string
+}
+
+func NewGraphQLSchemaProvider(
+	load func(ctx context.Context) (*graphql.Schema, error),
+	displayName func(objectName string) string,
+) *GraphQLSchemaProvider {
+	return &GraphQLSchemaProvider{
+		load:        load,
+		displayName: displayName,
+	}
+}
+
+func (p *GraphQLSchemaProvider) ListObjectMetadata(
+	ctx context.Context,
+	objects []string,
+) (*common.ListObjectMetadataResult, error) {
+	if len(objects) == 0 {
+		return nil, common.ErrMissingObjects
+	}
+
+	if slices.Contains(objects, "") {
+		return nil, fmt.Errorf("%w: object name cannot be empty", common.ErrMissingObjects)
+	}
+
+	schema, err := p.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := common.NewListObjectMetadataResult()
+
+	for _, objectName := range objects {
+		metadata, err := schema.ObjectMetadata(objectName, p.displayName(objectName))
+		if err != nil {
+			result.AppendError(objectName, err)
+
+			continue
+		}
+
+		result.Result[objectName] = *metadata
+	}
+
+	return result, nil
+}
+
+func (p *GraphQLSchemaProvider) SchemaSource() string {
+	return "GraphQLSchemaProvider"
+}
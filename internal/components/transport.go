@@ -5,12 +5,14 @@ import (
 	"github.com/amp-labs/connectors/providers"
 )
 
-// Transport
-// TODO: Add support for XML, CSV, etc.
+// Transport provides HTTP clients which share authentication and error handling.
+// JSONHTTPClient covers the common case, ContentHTTPClient speaks any format via codecs
+// (XML, CSV, NDJSON, url-encoded and multipart forms).
 type Transport struct {
 	ProviderContext
 
-	json *common.JSONHTTPClient
+	json    *common.JSONHTTPClient
+	content *common.ContentHTTPClient
 }
 
 // NewTransport
@@ -26,19 +28,26 @@ func NewTransport(
 		return nil, err
 	}
 
+	httpClient := &common.HTTPClient{
+		Base:   providerContext.ProviderInfo().BaseURL,
+		Client: params.AuthenticatedClient,
+
+		// ErrorHandler is set to a default, but can be overridden using options.
+		ErrorHandler: common.InterpretError,
+
+		// No ResponseHandler is set, but can be overridden using options.
+	}
+
 	return &Transport{
 		ProviderContext: *providerContext,
 		json: &common.JSONHTTPClient{
-			HTTPClient: &common.HTTPClient{
-				Base:   providerContext.ProviderInfo().BaseURL,
-				Client: params.AuthenticatedClient,
-
-				// ErrorHandler is set to a default, but can be overridden using options.
-				ErrorHandler: common.InterpretError,
-
-				// No ResponseHandler is set, but can be overridden using options.
-			},
+			HTTPClient:         httpClient,
+			ErrorPostProcessor: common.ErrorPostProcessor{},
+		},
+		content: &common.ContentHTTPClient{
+			HTTPClient:         httpClient,
 			ErrorPostProcessor: common.ErrorPostProcessor{},
+			Decoders:           common.DefaultDecoders(),
 		},
 	}, nil
 }
@@ -64,3 +73,7 @@ func (t *Transport) SetErrorHandler(handler common.ErrorHandler) {
 
 func (t *Transport) JSONHTTPClient() *common.JSONHTTPClient { return t.json }
 func (t *Transport) HTTPClient() *common.HTTPClient         { return t.json.HTTPClient }
+
+// ContentHTTPClient shares the underlying HTTPClient with JSONHTTPClient.
+// Register custom decoders via ContentHTTPClient().Decoders.Register.
+func (t *Transport) ContentHTTPClient() *common.ContentHTTPClient { return t.content }
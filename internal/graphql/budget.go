@@ -0,0 +1,251 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/spyzhov/ajson"
+)
+
+// maxBudgetDelay is the longest a query waits for the budget to be restored.
+// Budgets restored later, ex: hourly windows, fail fast with common.ErrLimitExceeded instead.
+const maxBudgetDelay = time.Minute
+
+// Budget tracks the query cost budget of a GraphQL provider, which limits queries by complexity points
+// rather than request count. Costs reported by responses are used to estimate the points a read costs,
+// shrinking pages and delaying queries so the budget is not exhausted.
+//
+// Reads call Reserve or Wait before sending a query, and Observe with every response.
+// Cost per record is tracked per object, as nested selections make some objects costlier than others.
+type Budget struct {
+	cost CostFunc
+	now  func() time.Time
+
+	mutex      sync.Mutex
+	state      *Cost
+	observedAt time.Time
+	perRecord  map[string]float64
+}
+
+// Reservation is a read accounted for by the budget, returned by Reserve and Wait.
+// Every query has its own reservation, so concurrent reads of an object don't mix their costs.
+type Reservation struct {
+	objectName string
+	pageSize   int
+}
+
+// PageSize is the number of records the read may request.
+func (r *Reservation) PageSize() int {
+	return r.pageSize
+}
+
+type reservationKey struct{}
+
+// WithReservation attaches the reservation to the context of a request,
+// so that the response is observed against the read which sent it.
+func WithReservation(ctx context.Context, reservation *Reservation) context.Context {
+	return context.WithValue(ctx, reservationKey{}, reservation)
+}
+
+// ReservationFrom returns the reservation attached by WithReservation, nil if none.
+func ReservationFrom(ctx context.Context) *Reservation {
+	reservation, _ := ctx.Value(reservationKey{}).(*Reservation)
+
+	return reservation
+}
+
+// NewBudget creates a Budget reading costs reported by a provider.
+func NewBudget(cost CostFunc) *Budget {
+	return &Budget{
+		cost:      cost,
+		now:       time.Now,
+		perRecord: make(map[string]float64),
+	}
+}
+
+// Reserve returns the page size of the next read, delaying it until the budget can afford the query.
+// Pages shrink down to a quarter of the maximum size when the budget runs low, smaller pages are not worth
+// the extra queries, so the read waits for the budget to be restored instead.
+// Until the first cost is observed pages have the maximum size.
+func (b *Budget) Reserve(ctx context.Context, objectName string, maxPageSize int) (*Reservation, error) {
+	b.mutex.Lock()
+
+	pageSize := maxPageSize
+
+	if b.state != nil {
+		if perRecord := b.perRecord[objectName]; perRecord > 0 {
+			minPageSize := max(1, maxPageSize/4) // nolint:mnd
+			affordable := math.Floor(b.available(b.now()) / perRecord)
+			pageSize = int(min(float64(maxPageSize), max(float64(minPageSize), affordable)))
+		}
+	}
+
+	delay, err := b.reserve(objectName, pageSize)
+
+	b.mutex.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Sleep(ctx, delay); err != nil {
+		return nil, err
+	}
+
+	return &Reservation{objectName: objectName, pageSize: pageSize}, nil
+}
+
+// Wait delays a read of the fixed page size until the budget can afford the query.
+// It suits providers paginated by page numbers, where the page size cannot change between pages.
+func (b *Budget) Wait(ctx context.Context, objectName string, pageSize int) (*Reservation, error) {
+	b.mutex.Lock()
+	delay, err := b.reserve(objectName, pageSize)
+	b.mutex.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Sleep(ctx, delay); err != nil {
+		return nil, err
+	}
+
+	return &Reservation{objectName: objectName, pageSize: pageSize}, nil
+}
+
+// reserve deducts the estimated cost of a read from the budget, returning how long to wait for it.
+// The deduction keeps concurrent reads from spending the same points, the next response corrects it.
+func (b *Budget) reserve(objectName string, pageSize int) (time.Duration, error) {
+	if b.state == nil {
+		return 0, nil
+	}
+
+	now := b.now()
+	available := b.available(now)
+
+	// Every query costs at least a point, even when the provider reported no cost, ex: throttled queries.
+	estimate := max(1, b.state.Actual)
+	if perRecord := b.perRecord[objectName]; perRecord > 0 {
+		estimate = perRecord * float64(pageSize)
+	}
+
+	delay := b.delay(estimate, available, now)
+	if delay > maxBudgetDelay {
+		return 0, fmt.Errorf("%w: query cost budget is restored in %s", common.ErrLimitExceeded, delay.Round(time.Second))
+	}
+
+	b.state.Remaining = available - estimate
+	b.observedAt = now
+
+	return delay, nil
+}
+
+// available returns the points expected to be left in the budget at the given time.
+func (b *Budget) available(now time.Time) float64 {
+	switch {
+	case b.state.RestoreRate > 0:
+		restored := b.state.Remaining + b.state.RestoreRate*now.Sub(b.observedAt).Seconds()
+		if b.state.Limit > 0 {
+			return min(b.state.Limit, restored)
+		}
+
+		return restored
+	case !b.state.ResetAt.IsZero() && !now.Before(b.state.ResetAt):
+		if b.state.Limit > 0 {
+			return b.state.Limit
+		}
+
+		// The size of the budget is unknown, a reset restores at least the points spent so far.
+		return math.Inf(1)
+	default:
+		return b.state.Remaining
+	}
+}
+
+// delay returns how long it takes for the budget to afford the cost.
+func (b *Budget) delay(cost, available float64, now time.Time) time.Duration {
+	if available >= cost {
+		return 0
+	}
+
+	switch {
+	case b.state.RestoreRate > 0:
+		return seconds((cost - available) / b.state.RestoreRate)
+	case !b.state.ResetAt.IsZero():
+		return max(0, b.state.ResetAt.Sub(now))
+	default:
+		// The provider doesn't say when points are restored, the query is sent and may be throttled.
+		return 0
+	}
+}
+
+// Observe records the cost reported by a response to the reserved read, which returned the given number of records.
+// The cost per record of the object is learned from reads returning records, responses to other queries,
+// ex: mutations, only update the state of the budget and are observed with a nil reservation.
+// It returns common.ErrLimitExceeded when the provider throttled the query.
+func (b *Budget) Observe(reservation *Reservation, records int, headers http.Header, body *ajson.Node) error {
+	now := b.now()
+
+	cost, ok := b.cost(headers, body, now)
+	if !ok {
+		return nil
+	}
+
+	b.mutex.Lock()
+
+	b.state = &cost
+	b.observedAt = now
+
+	if reservation != nil && records > 0 && cost.Actual > 0 && !cost.Throttled {
+		b.perRecord[reservation.objectName] = cost.Actual / float64(records)
+	}
+
+	b.mutex.Unlock()
+
+	if cost.Throttled {
+		return fmt.Errorf("%w: query cost %v exceeds the remaining budget of %v points",
+			common.ErrLimitExceeded, cost.Actual, cost.Remaining)
+	}
+
+	return nil
+}
+
+// ErrorHandler observes costs reported by failed responses before passing them to the next handler.
+// Throttled queries are reported as common.ErrLimitExceeded rather than a generic failure.
+func (b *Budget) ErrorHandler(next func(*http.Response, []byte) error) func(*http.Response, []byte) error {
+	return func(rsp *http.Response, body []byte) error {
+		// Error bodies are not necessarily JSON, costs may be reported in headers anyway.
+		node, err := ajson.Unmarshal(body)
+		if err != nil {
+			node = nil
+		}
+
+		if err := b.Observe(nil, 0, rsp.Header, node); err != nil {
+			return common.NewHTTPError(rsp.StatusCode, body, common.GetResponseHeaders(rsp), err)
+		}
+
+		return next(rsp, body)
+	}
+}
+
+// Sleep waits for the delay, returning early with the error of the canceled context.
+func Sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/spyzhov/ajson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shopifyResponse(t *testing.T, body string) *ajson.Node {
+	t.Helper()
+
+	node, err := ajson.Unmarshal([]byte(body))
+	require.NoError(t, err)
+
+	return node
+}
+
+func TestBudget_Reserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	budget := NewBudget(ShopifyCost)
+	budget.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Nothing is known about the budget before the first response.
+	reservation, err := budget.Reserve(ctx, "products", 250)
+	require.NoError(t, err)
+	assert.Equal(t, 250, reservation.PageSize())
+
+	// A page of 250 products costs 500 points, 2 per record.
+	require.NoError(t, budget.Observe(reservation, 250, nil, shopifyResponse(t, `{"extensions": {"cost": {
+		"requestedQueryCost": 752, "actualQueryCost": 500,
+		"throttleStatus": {"maximumAvailable": 2000, "currentlyAvailable": 300, "restoreRate": 100}}}}`)))
+
+	// 300 points afford 150 products.
+	reservation, err = budget.Reserve(ctx, "products", 250)
+	require.NoError(t, err)
+	assert.Equal(t, 150, reservation.PageSize())
+
+	// The budget is spent, after a second 100 points are restored, enough for the smallest page.
+	now = now.Add(time.Second)
+
+	reservation, err = budget.Reserve(ctx, "products", 250)
+	require.NoError(t, err)
+	assert.Equal(t, 62, reservation.PageSize())
+
+	// Shrunk pages wait for the budget to be restored.
+	budget.mutex.Lock()
+	budget.state.Remaining = 0
+	budget.mutex.Unlock()
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = budget.Reserve(canceled, "products", 250)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBudget_Throttled(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	budget := NewBudget(LinearCost)
+	budget.now = func() time.Time { return now }
+
+	headers := http.Header{}
+	headers.Set("X-Complexity", "0")
+	headers.Set("X-RateLimit-Complexity-Limit", "3000000")
+	headers.Set("X-RateLimit-Complexity-Remaining", "0")
+	headers.Set("X-RateLimit-Complexity-Reset", "1704210245000") // 40 minutes later
+
+	handler := budget.ErrorHandler(func(*http.Response, []byte) error {
+		return common.ErrBadRequest
+	})
+
+	err := handler(&http.Response{StatusCode: http.StatusBadRequest, Header: headers},
+		[]byte(`{"errors": [{"message": "Rate limit exceeded", "extensions": {"code": "RATELIMITED"}}]}`))
+	require.ErrorIs(t, err, common.ErrLimitExceeded)
+
+	// Budgets restored much later fail fast instead of blocking.
+	_, err = budget.Reserve(context.Background(), "issues", 100)
+	require.ErrorIs(t, err, common.ErrLimitExceeded)
+
+	// After the reset the budget is full again.
+	now = now.Add(time.Hour)
+
+	reservation, err := budget.Reserve(context.Background(), "issues", 100)
+	require.NoError(t, err)
+	assert.Equal(t, 100, reservation.PageSize())
+
+	// Other errors are passed to the next handler.
+	err = handler(&http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}, []byte(`not json`))
+	require.ErrorIs(t, err, common.ErrBadRequest)
+}
+
+func TestBudget_CostPerReturnedRecord(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	budget := NewBudget(ShopifyCost)
+	budget.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Two reads of the same object are in flight, each keeps its own reservation.
+	first, err := budget.Reserve(ctx, "products", 250)
+	require.NoError(t, err)
+
+	second, err := budget.Reserve(ctx, "products", 100)
+	require.NoError(t, err)
+
+	// The short last page of the second read returned 10 products for 40 points, 4 per record.
+	require.NoError(t, budget.Observe(second, 10, nil, shopifyResponse(t, `{"extensions": {"cost": {
+		"requestedQueryCost": 302, "actualQueryCost": 40,
+		"throttleStatus": {"maximumAvailable": 2000, "currentlyAvailable": 400, "restoreRate": 100}}}}`)))
+
+	budget.mutex.Lock()
+	assert.InDelta(t, 4.0, budget.perRecord["products"], 0.001)
+	budget.mutex.Unlock()
+
+	// The first read is not affected by the page size of the second one.
+	require.NoError(t, budget.Observe(first, 250, nil, shopifyResponse(t, `{"extensions": {"cost": {
+		"requestedQueryCost": 752, "actualQueryCost": 500,
+		"throttleStatus": {"maximumAvailable": 2000, "currentlyAvailable": 300, "restoreRate": 100}}}}`)))
+
+	budget.mutex.Lock()
+	assert.InDelta(t, 2.0, budget.perRecord["products"], 0.001)
+	budget.mutex.Unlock()
+
+	// Responses without records don't change the cost per record.
+	require.NoError(t, budget.Observe(first, 0, nil, shopifyResponse(t, `{"extensions": {"cost": {
+		"requestedQueryCost": 752, "actualQueryCost": 3,
+		"throttleStatus": {"maximumAvailable": 2000, "currentlyAvailable": 300, "restoreRate": 100}}}}`)))
+
+	budget.mutex.Lock()
+	assert.InDelta(t, 2.0, budget.perRecord["products"], 0.001)
+	budget.mutex.Unlock()
+
+	// The reservation travels with the request.
+	assert.Same(t, first, ReservationFrom(WithReservation(ctx, first)))
+	assert.Nil(t, ReservationFrom(ctx))
+}
+
+func TestRecordCount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 2, RecordCount("issues", shopifyResponse(t, `{"data": {"issues": {"nodes": [{}, {}]}}}`)))
+	assert.Equal(t, 1, RecordCount("issues", shopifyResponse(t, `{"data": {"issues": {"edges": [{"node": {}}]}}}`)))
+	assert.Equal(t, 3, RecordCount("boards", shopifyResponse(t, `{"data": {"boards": [{}, {}, {}]}}`)))
+	assert.Equal(t, 0, RecordCount("boards", shopifyResponse(t, `{"errors": [{"message": "Throttled"}]}`)))
+}
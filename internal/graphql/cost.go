@@ -0,0 +1,194 @@
+package graphql
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+// MondayComplexitySelection requests the cost of a Monday query next to its records.
+// It is added to read queries via Request.Select, so MondayCost can read the budget.
+const MondayComplexitySelection = "complexity { query before after reset_in_x_seconds }"
+
+// Cost is the query cost reported by a provider, measured in points of its budget.
+type Cost struct {
+	// Actual is the number of points the query was charged.
+	// Throttled queries report the points they would have been charged.
+	Actual float64
+	// Remaining is the number of points left in the budget after the query.
+	Remaining float64
+	// Limit is the size of the budget, zero when the provider doesn't report it.
+	Limit float64
+	// RestoreRate is the number of points restored every second, for budgets refilled gradually.
+	RestoreRate float64
+	// ResetAt is when the budget is refilled at once, for budgets reset periodically.
+	ResetAt time.Time
+	// Throttled is set when the query was rejected for exceeding the budget.
+	Throttled bool
+}
+
+// CostFunc reads the cost of a query from a response.
+// The body may be nil when the response is not JSON.
+// It returns false when the response doesn't report the cost.
+type CostFunc func(headers http.Header, body *ajson.Node, now time.Time) (Cost, bool)
+
+// MondayCost reads the complexity selected with MondayComplexitySelection.
+// Throttled queries are rejected with a COMPLEXITY_BUDGET_EXHAUSTED error.
+//
+// Example:
+//
+//	{"data": {"complexity": {"query": 2001, "before": 5000000, "after": 4997999, "reset_in_x_seconds": 60}}}
+//	{"errors": [{"message": "Complexity budget exhausted", "extensions": {
+//	  "code": "COMPLEXITY_BUDGET_EXHAUSTED", "complexity": 30001, "complexity_budget_left": 29998,
+//	  "complexity_budget_limit": 1000000, "retry_in_seconds": 60}}]}
+func MondayCost(headers http.Header, body *ajson.Node, now time.Time) (Cost, bool) {
+	if body == nil {
+		return Cost{}, false
+	}
+
+	if extensions := errorExtensions(body, "COMPLEXITY_BUDGET_EXHAUSTED", "ComplexityException"); extensions != nil {
+		return Cost{
+			Actual:    number(extensions, "complexity"),
+			Remaining: number(extensions, "complexity_budget_left"),
+			Limit:     number(extensions, "complexity_budget_limit"),
+			ResetAt:   now.Add(seconds(number(extensions, "retry_in_seconds"))),
+			Throttled: true,
+		}, true
+	}
+
+	complexity, err := jsonquery.New(body, "data").ObjectOptional("complexity")
+	if err != nil || complexity == nil {
+		return Cost{}, false
+	}
+
+	return Cost{
+		Actual:    number(complexity, "query"),
+		Remaining: number(complexity, "after"),
+		ResetAt:   now.Add(seconds(number(complexity, "reset_in_x_seconds"))),
+	}, true
+}
+
+// LinearCost reads the complexity rate limit headers of Linear.
+// The reset header holds UTC epoch milliseconds, throttled queries fail with a RATELIMITED error.
+// https://linear.app/developers/rate-limiting
+func LinearCost(headers http.Header, body *ajson.Node, now time.Time) (Cost, bool) {
+	throttled := errorExtensions(body, "RATELIMITED") != nil
+
+	remaining, err := strconv.ParseFloat(headers.Get("X-RateLimit-Complexity-Remaining"), 64)
+	if err != nil {
+		if throttled {
+			return Cost{Throttled: true}, true
+		}
+
+		return Cost{}, false
+	}
+
+	cost := Cost{
+		Remaining: remaining,
+		Throttled: throttled,
+	}
+
+	cost.Actual, _ = strconv.ParseFloat(headers.Get("X-Complexity"), 64)
+	cost.Limit, _ = strconv.ParseFloat(headers.Get("X-RateLimit-Complexity-Limit"), 64)
+
+	if reset, err := strconv.ParseInt(headers.Get("X-RateLimit-Complexity-Reset"), 10, 64); err == nil {
+		cost.ResetAt = time.UnixMilli(reset)
+	}
+
+	return cost, true
+}
+
+// ShopifyCost reads the cost extension of the Shopify Admin API, a leaky bucket restored every second.
+// Throttled queries have no actual cost and fail with a THROTTLED error.
+// https://shopify.dev/docs/api/usage/rate-limits#graphql-admin-api-rate-limits
+//
+// Example:
+//
+//	{"extensions": {"cost": {"requestedQueryCost": 101, "actualQueryCost": 46,
+//	  "throttleStatus": {"maximumAvailable": 2000, "currentlyAvailable": 1954, "restoreRate": 100}}}}
+func ShopifyCost(headers http.Header, body *ajson.Node, now time.Time) (Cost, bool) {
+	if body == nil {
+		return Cost{}, false
+	}
+
+	cost, err := jsonquery.New(body, "extensions").ObjectOptional("cost")
+	if err != nil || cost == nil {
+		return Cost{}, false
+	}
+
+	throttleStatus, err := jsonquery.New(cost).ObjectOptional("throttleStatus")
+	if err != nil || throttleStatus == nil {
+		return Cost{}, false
+	}
+
+	actual := number(cost, "actualQueryCost")
+	if actual == 0 {
+		actual = number(cost, "requestedQueryCost")
+	}
+
+	return Cost{
+		Actual:      actual,
+		Remaining:   number(throttleStatus, "currentlyAvailable"),
+		Limit:       number(throttleStatus, "maximumAvailable"),
+		RestoreRate: number(throttleStatus, "restoreRate"),
+		Throttled:   errorExtensions(body, "THROTTLED") != nil,
+	}, true
+}
+
+// errorExtensions returns extensions of the first GraphQL error having one of the codes.
+func errorExtensions(body *ajson.Node, codes ...string) *ajson.Node {
+	if body == nil {
+		return nil
+	}
+
+	errs, err := jsonquery.New(body).ArrayOptional("errors")
+	if err != nil {
+		return nil
+	}
+
+	for _, item := range errs {
+		extensions, err := jsonquery.New(item).ObjectOptional("extensions")
+		if err != nil || extensions == nil {
+			continue
+		}
+
+		code, err := jsonquery.New(extensions).StrWithDefault("code", "")
+		if err != nil {
+			continue
+		}
+
+		for _, expected := range codes {
+			if code == expected {
+				return extensions
+			}
+		}
+	}
+
+	return nil
+}
+
+// number returns a numeric property of an object, zero when it is missing or not a number.
+func number(node *ajson.Node, key string) float64 {
+	if node == nil || !node.HasKey(key) {
+		return 0
+	}
+
+	value, err := node.GetKey(key)
+	if err != nil || !value.IsNumeric() {
+		return 0
+	}
+
+	result, err := value.GetNumeric()
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
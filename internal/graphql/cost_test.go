@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spyzhov/ajson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostFuncs(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cost     CostFunc
+		headers  http.Header
+		body     string
+		expected Cost
+		reported bool
+	}{
+		{
+			name:     "Monday complexity",
+			cost:     MondayCost,
+			body:     `{"data": {"complexity": {"query": 2001, "before": 5000000, "after": 4997999, "reset_in_x_seconds": 60}}}`,
+			expected: Cost{Actual: 2001, Remaining: 4997999, ResetAt: now.Add(time.Minute)},
+			reported: true,
+		},
+		{
+			name: "Monday budget exhausted",
+			cost: MondayCost,
+			body: `{"errors": [{"message": "Complexity budget exhausted", "extensions": {
+				"code": "COMPLEXITY_BUDGET_EXHAUSTED", "complexity": 30001, "complexity_budget_left": 29998,
+				"complexity_budget_limit": 1000000, "retry_in_seconds": 30}}]}`,
+			expected: Cost{
+				Actual: 30001, Remaining: 29998, Limit: 1000000, ResetAt: now.Add(30 * time.Second), Throttled: true,
+			},
+			reported: true,
+		},
+		{
+			name:     "Monday without complexity",
+			cost:     MondayCost,
+			body:     `{"data": {"boards": []}}`,
+			reported: false,
+		},
+		{
+			name: "Linear headers",
+			cost: LinearCost,
+			headers: http.Header{
+				"X-Complexity":                      []string{"120"},
+				"X-Ratelimit-Complexity-Limit":      []string{"3000000"},
+				"X-Ratelimit-Complexity-Remaining":  []string{"2999880"},
+				"X-Ratelimit-Complexity-Reset":      []string{"1704207845000"},
+				"X-Ratelimit-Requests-Remaining":    []string{"1499"},
+				"X-Ratelimit-Endpoint-Requests-Max": []string{"1500"},
+			},
+			body: `{"data": {}}`,
+			expected: Cost{
+				Actual: 120, Remaining: 2999880, Limit: 3000000, ResetAt: time.UnixMilli(1704207845000),
+			},
+			reported: true,
+		},
+		{
+			name:     "Linear rate limited without headers",
+			cost:     LinearCost,
+			headers:  http.Header{},
+			body:     `{"errors": [{"message": "Rate limit exceeded", "extensions": {"code": "RATELIMITED"}}]}`,
+			expected: Cost{Throttled: true},
+			reported: true,
+		},
+		{
+			name: "Shopify throttled",
+			cost: ShopifyCost,
+			body: `{"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED"}}],
+				"extensions": {"cost": {"requestedQueryCost": 752, "actualQueryCost": null,
+				"throttleStatus": {"maximumAvailable": 2000, "currentlyAvailable": 10, "restoreRate": 100}}}}`,
+			expected: Cost{Actual: 752, Remaining: 10, Limit: 2000, RestoreRate: 100, Throttled: true},
+			reported: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := ajson.Unmarshal([]byte(tt.body))
+			require.NoError(t, err)
+
+			cost, reported := tt.cost(tt.headers, body, now)
+			assert.Equal(t, tt.reported, reported)
+			assert.Equal(t, tt.expected, cost)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// ErrInvalidSelection is returned when a stored selection set cannot be parsed.
+var ErrInvalidSelection = errors.New("invalid selection set")
+
+// ReadFields loads the selection set stored in the file and lists its fields, see SelectionFields.
+// Providers store the fields every record of an object is read with, which requested fields extend.
+// A missing file lists no fields, so objects without a stored selection are read with requested fields only.
+func ReadFields(queryFS embed.FS, path string) ([]string, error) {
+	data, err := queryFS.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	fields, err := SelectionFields(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return fields, nil
+}
+
+// SelectionFields lists fields of a selection set as dotted paths, ex: `{ id state { name } }`
+// lists "id" and "state.name". Passed to Schema.Read, the paths select the same fields again.
+// Only field names and nested selection sets are understood, comments and commas are skipped.
+func SelectionFields(selection string) ([]string, error) {
+	parser := &selectionParser{tokens: tokenizeSelection(selection)}
+
+	if parser.next() != "{" {
+		return nil, fmt.Errorf("%w: expected {", ErrInvalidSelection)
+	}
+
+	fields, err := parser.selectionSet("")
+	if err != nil {
+		return nil, err
+	}
+
+	if token := parser.next(); token != "" {
+		return nil, fmt.Errorf("%w: unexpected %q after the selection set", ErrInvalidSelection, token)
+	}
+
+	return fields, nil
+}
+
+type selectionParser struct {
+	tokens []string
+}
+
+func (p *selectionParser) next() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+
+	token := p.tokens[0]
+	p.tokens = p.tokens[1:]
+
+	return token
+}
+
+func (p *selectionParser) peek() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+
+	return p.tokens[0]
+}
+
+// selectionSet lists fields up to the closing brace, the opening one is already consumed.
+func (p *selectionParser) selectionSet(prefix string) ([]string, error) {
+	var fields []string
+
+	for {
+		token := p.next()
+
+		switch {
+		case token == "}":
+			return fields, nil
+		case token == "":
+			return nil, fmt.Errorf("%w: missing }", ErrInvalidSelection)
+		case !isName(token):
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidSelection, token)
+		}
+
+		if p.peek() != "{" {
+			fields = append(fields, prefix+token)
+
+			continue
+		}
+
+		p.next()
+
+		nested, err := p.selectionSet(prefix + token + ".")
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, nested...)
+	}
+}
+
+// tokenizeSelection splits the selection set into braces and words.
+func tokenizeSelection(selection string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+
+	flush := func() {
+		if word.Len() != 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, line := range strings.Split(selection, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+
+		for _, char := range line {
+			switch {
+			case char == '{' || char == '}':
+				flush()
+				tokens = append(tokens, string(char))
+			case char == ',' || char == ' ' || char == '\t' || char == '\r':
+				flush()
+			default:
+				word.WriteRune(char)
+			}
+		}
+
+		flush()
+	}
+
+	return tokens
+}
+
+func isName(token string) bool {
+	for index, char := range token {
+		isLetter := char == '_' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+		if !isLetter && (index == 0 || char < '0' || char > '9') {
+			return false
+		}
+	}
+
+	return token != ""
+}
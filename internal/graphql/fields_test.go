@@ -0,0 +1,57 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectionFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		selection string
+		expected  []string
+	}{
+		{
+			name:      "Flat fields",
+			selection: "{ id title }",
+			expected:  []string{"id", "title"},
+		},
+		{
+			name: "Nested objects, comments and commas",
+			selection: `# Fields every job is read with.
+{
+  id,
+  client {
+    name
+    billingAddress { city country }
+  }
+  jobNumber # shown to customers
+}
+`,
+			expected: []string{
+				"id", "client.name", "client.billingAddress.city", "client.billingAddress.country", "jobNumber",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fields, err := SelectionFields(tt.selection)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+
+	invalid := []string{"", "id title", "{ id", "{ id } title", "{ issues(first: 10) { id } }", "{ ...Issue }"}
+
+	for _, selection := range invalid {
+		_, err := SelectionFields(selection)
+		require.ErrorIs(t, err, ErrInvalidSelection, selection)
+	}
+}
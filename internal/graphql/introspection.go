@@ -0,0 +1,324 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ErrIntrospection is returned when the schema of a GraphQL API cannot be introspected.
+var ErrIntrospection = errors.New("graphql introspection failed")
+
+// TypeKind is a kind of GraphQL type as reported by introspection.
+type TypeKind string
+
+const (
+	KindScalar      TypeKind = "SCALAR"
+	KindObject      TypeKind = "OBJECT"
+	KindInterface   TypeKind = "INTERFACE"
+	KindUnion       TypeKind = "UNION"
+	KindEnum        TypeKind = "ENUM"
+	KindInputObject TypeKind = "INPUT_OBJECT"
+	KindList        TypeKind = "LIST"
+	KindNonNull     TypeKind = "NON_NULL"
+)
+
+// IntrospectionQuery fetches every type of the schema.
+// Type references are unwrapped four levels deep, which covers wrappers such as [Type!]!.
+const IntrospectionQuery = `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    types {
+      kind
+      name
+      fields(includeDeprecated: true) {
+        name
+        description
+        isDeprecated
+        args { name type { ...TypeRef } }
+        type { ...TypeRef }
+      }
+      enumValues(includeDeprecated: true) { name }
+    }
+  }
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+      }
+    }
+  }
+}`
+
+// Schema is a GraphQL schema obtained via introspection.
+type Schema struct {
+	QueryType    *NamedType `json:"queryType"`
+	MutationType *NamedType `json:"mutationType"`
+	Types        []Type     `json:"types"`
+
+	// index is built once, schemas are shared by concurrent reads.
+	indexOnce sync.Once
+	index     map[string]*Type
+}
+
+type NamedType struct {
+	Name string `json:"name"`
+}
+
+// Type is an object, interface, enum or scalar type of the schema.
+type Type struct {
+	Kind       TypeKind    `json:"kind"`
+	Name       string      `json:"name"`
+	Fields     []Field     `json:"fields"`
+	EnumValues []EnumValue `json:"enumValues"`
+}
+
+type Field struct {
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	IsDeprecated bool         `json:"isDeprecated"`
+	Args         []InputValue `json:"args"`
+	Type         TypeRef      `json:"type"`
+}
+
+type InputValue struct {
+	Name string  `json:"name"`
+	Type TypeRef `json:"type"`
+}
+
+type EnumValue struct {
+	Name string `json:"name"`
+}
+
+// TypeRef references a type, possibly wrapped into LIST and NON_NULL.
+type TypeRef struct {
+	Kind   TypeKind `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+// Named returns the innermost type stripped of LIST and NON_NULL wrappers.
+func (r TypeRef) Named() TypeRef {
+	for r.OfType != nil && (r.Kind == KindList || r.Kind == KindNonNull) {
+		r = *r.OfType
+	}
+
+	return r
+}
+
+// IsList reports whether the type is a list, possibly a non-null one.
+func (r TypeRef) IsList() bool {
+	if r.Kind == KindNonNull && r.OfType != nil {
+		return r.OfType.IsList()
+	}
+
+	return r.Kind == KindList
+}
+
+// String formats the type in GraphQL notation, ex: [String!]!.
+// The result can be used to declare variables of an operation.
+func (r TypeRef) String() string {
+	switch {
+	case r.Kind == KindNonNull && r.OfType != nil:
+		return r.OfType.String() + "!"
+	case r.Kind == KindList && r.OfType != nil:
+		return "[" + r.OfType.String() + "]"
+	default:
+		return r.Name
+	}
+}
+
+// Type returns a type of the schema by name.
+func (s *Schema) Type(name string) (*Type, bool) {
+	s.indexOnce.Do(s.buildIndex)
+
+	typ, ok := s.index[name]
+
+	return typ, ok
+}
+
+// QueryField returns a field of the root query type, ex: "issues" in `query { issues { ... } }`.
+func (s *Schema) QueryField(name string) (*Field, bool) {
+	if s.QueryType == nil {
+		return nil, false
+	}
+
+	queryType, ok := s.Type(s.QueryType.Name)
+	if !ok {
+		return nil, false
+	}
+
+	return queryType.Field(name)
+}
+
+func (s *Schema) buildIndex() {
+	s.index = make(map[string]*Type, len(s.Types))
+	for index := range s.Types {
+		s.index[s.Types[index].Name] = &s.Types[index]
+	}
+}
+
+// Field returns a field of the type by name.
+// Names are matched exactly first, then case-insensitively, since read fields may be lowercased.
+func (t *Type) Field(name string) (*Field, bool) {
+	for index := range t.Fields {
+		if t.Fields[index].Name == name {
+			return &t.Fields[index], true
+		}
+	}
+
+	for index := range t.Fields {
+		if strings.EqualFold(t.Fields[index].Name, name) {
+			return &t.Fields[index], true
+		}
+	}
+
+	return nil, false
+}
+
+// HasRequiredArgs reports whether the field cannot be selected without arguments.
+func (f *Field) HasRequiredArgs() bool {
+	for _, arg := range f.Args {
+		if arg.Type.Kind == KindNonNull {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Arg returns an argument of the field by name.
+func (f *Field) Arg(name string) (*InputValue, bool) {
+	for index := range f.Args {
+		if f.Args[index].Name == name {
+			return &f.Args[index], true
+		}
+	}
+
+	return nil, false
+}
+
+// Introspector fetches the schema of a GraphQL API and caches it.
+// Only successful responses are cached, a failed introspection is retried on the next call.
+type Introspector struct {
+	client  *common.JSONHTTPClient
+	headers []common.Header
+
+	mutex   sync.Mutex
+	schemas map[string]*Schema
+	// pending holds introspections in progress, concurrent calls for the same URL wait for one request.
+	pending map[string]*introspection
+}
+
+// introspection is a request for a schema, done is closed once the schema or error is set.
+type introspection struct {
+	done   chan struct{}
+	schema *Schema
+	err    error
+}
+
+// NewIntrospector creates an Introspector sending the headers with every introspection query.
+func NewIntrospector(client *common.JSONHTTPClient, headers ...common.Header) *Introspector {
+	return &Introspector{
+		client:  client,
+		headers: headers,
+		schemas: make(map[string]*Schema),
+		pending: make(map[string]*introspection),
+	}
+}
+
+type introspectionResponse struct {
+	Data struct {
+		Schema *Schema `json:"__schema"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Schema returns the schema of the GraphQL endpoint, introspecting it on the first call.
+// Schemas are cached per URL, which keeps connectors pointed at different hosts apart.
+// The lock is not held while the schema is fetched, so cached schemas are served meanwhile.
+func (i *Introspector) Schema(ctx context.Context, url string) (*Schema, error) {
+	i.mutex.Lock()
+
+	if schema, ok := i.schemas[url]; ok {
+		i.mutex.Unlock()
+
+		return schema, nil
+	}
+
+	call, running := i.pending[url]
+	if !running {
+		call = &introspection{done: make(chan struct{})}
+		i.pending[url] = call
+	}
+
+	i.mutex.Unlock()
+
+	if !running {
+		call.schema, call.err = i.introspect(ctx, url)
+
+		i.mutex.Lock()
+		delete(i.pending, url)
+
+		if call.err == nil {
+			i.schemas[url] = call.schema
+		}
+
+		i.mutex.Unlock()
+		close(call.done)
+	}
+
+	select {
+	case <-call.done:
+		return call.schema, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (i *Introspector) introspect(ctx context.Context, url string) (*Schema, error) {
+	rsp, err := i.client.Post(ctx, url, map[string]string{"query": IntrospectionQuery}, i.headers...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospection, err)
+	}
+
+	body, err := common.UnmarshalJSON[introspectionResponse](rsp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospection, err)
+	}
+
+	if len(body.Errors) != 0 {
+		messages := make([]string, len(body.Errors))
+		for index, item := range body.Errors {
+			messages[index] = item.Message
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrIntrospection, strings.Join(messages, "; "))
+	}
+
+	if body.Data.Schema == nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntrospection, common.ErrMissingExpectedValues)
+	}
+
+	schema := body.Data.Schema
+	schema.indexOnce.Do(schema.buildIndex)
+
+	return schema, nil
+}
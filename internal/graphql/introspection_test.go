@@ -0,0 +1,147 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	var response introspectionResponse
+
+	require.NoError(t, json.Unmarshal(testutils.DataFromFile(t, "schema.json"), &response))
+
+	return response.Data.Schema
+}
+
+func TestIntrospector(t *testing.T) {
+	t.Parallel()
+
+	schemaResponse := testutils.DataFromFile(t, "schema.json")
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2025-01-20", r.Header.Get("X-Version"))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// The first introspection fails and must not be cached.
+		if calls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"errors": [{"message": "introspection is disabled"}]}`))
+
+			return
+		}
+
+		_, _ = w.Write(schemaResponse)
+	}))
+	defer server.Close()
+
+	introspector := NewIntrospector(
+		&common.JSONHTTPClient{HTTPClient: &common.HTTPClient{Client: server.Client()}},
+		common.Header{Key: "X-Version", Value: "2025-01-20"},
+	)
+
+	_, err := introspector.Schema(context.Background(), server.URL)
+	require.ErrorIs(t, err, ErrIntrospection)
+	assert.Contains(t, err.Error(), "introspection is disabled")
+
+	for range 3 {
+		schema, err := introspector.Schema(context.Background(), server.URL)
+		require.NoError(t, err)
+
+		issues, ok := schema.QueryField("issues")
+		require.True(t, ok)
+		assert.Equal(t, "IssueConnection!", issues.Type.String())
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIntrospector_ConcurrentCalls(t *testing.T) {
+	t.Parallel()
+
+	schemaResponse := testutils.DataFromFile(t, "schema.json")
+	release := make(chan struct{})
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if r.URL.Path == "/slow" {
+			<-release
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(schemaResponse)
+	}))
+	defer server.Close()
+
+	introspector := NewIntrospector(&common.JSONHTTPClient{HTTPClient: &common.HTTPClient{Client: server.Client()}})
+
+	_, err := introspector.Schema(context.Background(), server.URL+"/fast")
+	require.NoError(t, err)
+
+	var group sync.WaitGroup
+
+	for range 3 {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			schema, err := introspector.Schema(context.Background(), server.URL+"/slow")
+			assert.NoError(t, err)
+
+			// Types are looked up concurrently once the schema is shared.
+			_, ok := schema.Type("Issue")
+			assert.True(t, ok)
+		}()
+	}
+
+	// A slow introspection doesn't hold back schemas already cached.
+	schema, err := introspector.Schema(context.Background(), server.URL+"/fast")
+	require.NoError(t, err)
+	require.NotNil(t, schema)
+
+	close(release)
+	group.Wait()
+
+	assert.Equal(t, int32(2), calls.Load(), "concurrent calls share one introspection")
+}
+
+func TestTypeRef(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema(t)
+
+	issue, ok := schema.Type("Issue")
+	require.True(t, ok)
+
+	labelIDs, ok := issue.Field("labelids")
+	require.True(t, ok, "fields are matched case-insensitively")
+	assert.Equal(t, "labelIds", labelIDs.Name)
+	assert.Equal(t, "[String!]!", labelIDs.Type.String())
+	assert.True(t, labelIDs.Type.IsList())
+	assert.Equal(t, "String", labelIDs.Type.Named().Name)
+
+	state, ok := issue.Field("state")
+	require.True(t, ok)
+	assert.False(t, state.Type.IsList())
+
+	subscribers, ok := issue.Field("subscriberCount")
+	require.True(t, ok)
+	assert.True(t, subscribers.HasRequiredArgs())
+}
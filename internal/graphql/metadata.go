@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// ObjectMetadata describes records returned by a root query field using their introspected type.
+// Fields requiring arguments are skipped as they cannot be read without them.
+func (s *Schema) ObjectMetadata(rootField, displayName string) (*common.ObjectMetadata, error) {
+	recordType, err := s.RecordType(rootField)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recordType.Fields) == 0 {
+		return nil, fmt.Errorf(
+			"missing or empty fields for object: %s, error: %w",
+			rootField,
+			common.ErrMissingExpectedValues,
+		)
+	}
+
+	fields := make(common.FieldsMetadata, len(recordType.Fields))
+
+	for index := range recordType.Fields {
+		field := &recordType.Fields[index]
+		if field.HasRequiredArgs() {
+			continue
+		}
+
+		valueType, values := s.valueType(field.Type)
+
+		fields[field.Name] = common.FieldMetadata{
+			DisplayName:  field.Name,
+			ValueType:    valueType,
+			ProviderType: field.Type.Named().Name,
+			Values:       values,
+		}
+	}
+
+	return common.NewObjectMetadata(displayName, fields), nil
+}
+
+// valueType maps a GraphQL type to a ValueType.
+// Enums become select fields listing their values, lists of enums allow selecting many.
+func (s *Schema) valueType(ref TypeRef) (common.ValueType, []common.FieldValue) {
+	named := ref.Named()
+
+	switch named.Kind { // nolint:exhaustive
+	case KindEnum:
+		var values []common.FieldValue
+
+		if typ, ok := s.Type(named.Name); ok {
+			values = make([]common.FieldValue, len(typ.EnumValues))
+			for index, value := range typ.EnumValues {
+				values[index] = common.FieldValue{Value: value.Name, DisplayValue: value.Name}
+			}
+		}
+
+		if ref.IsList() {
+			return common.ValueTypeMultiSelect, values
+		}
+
+		return common.ValueTypeSingleSelect, values
+	case KindScalar:
+		if ref.IsList() {
+			return common.ValueTypeOther, nil
+		}
+
+		return scalarValueType(named.Name), nil
+	default:
+		return common.ValueTypeOther, nil
+	}
+}
+
+// scalarValueType maps built-in and common custom scalars.
+// Custom scalars differ among providers, ex: DateTime, ISO8601DateTime, TimelessDate.
+func scalarValueType(name string) common.ValueType {
+	switch strings.ToLower(name) {
+	case "string", "id":
+		return common.ValueTypeString
+	case "int", "long", "bigint":
+		return common.ValueTypeInt
+	case "float", "decimal":
+		return common.ValueTypeFloat
+	case "boolean":
+		return common.ValueTypeBoolean
+	case "date", "timelessdate", "iso8601date":
+		return common.ValueTypeDate
+	case "datetime", "iso8601datetime", "timestamp":
+		return common.ValueTypeDateTime
+	default:
+		return common.ValueTypeOther
+	}
+}
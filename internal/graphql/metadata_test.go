@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_ObjectMetadata(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema(t)
+
+	metadata, err := schema.ObjectMetadata("issues", "Issues")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Issues", metadata.DisplayName)
+	assert.NotContains(t, metadata.Fields, "subscriberCount", "fields requiring arguments are skipped")
+	assert.Equal(t, "title", metadata.FieldsMap["title"])
+
+	statusValues := []common.FieldValue{{Value: "todo", DisplayValue: "todo"}, {Value: "done", DisplayValue: "done"}}
+
+	expected := map[string]common.FieldMetadata{
+		"id":        {DisplayName: "id", ValueType: common.ValueTypeString, ProviderType: "ID"},
+		"estimate":  {DisplayName: "estimate", ValueType: common.ValueTypeInt, ProviderType: "Int"},
+		"priority":  {DisplayName: "priority", ValueType: common.ValueTypeFloat, ProviderType: "Float"},
+		"trashed":   {DisplayName: "trashed", ValueType: common.ValueTypeBoolean, ProviderType: "Boolean"},
+		"createdAt": {DisplayName: "createdAt", ValueType: common.ValueTypeDateTime, ProviderType: "DateTime"},
+		"dueDate":   {DisplayName: "dueDate", ValueType: common.ValueTypeDate, ProviderType: "TimelessDate"},
+		"labelIds":  {DisplayName: "labelIds", ValueType: common.ValueTypeOther, ProviderType: "String"},
+		"state":     {DisplayName: "state", ValueType: common.ValueTypeOther, ProviderType: "WorkflowState"},
+		"status": {
+			DisplayName: "status", ValueType: common.ValueTypeSingleSelect, ProviderType: "IssueStatus",
+			Values: statusValues,
+		},
+		"flags": {
+			DisplayName: "flags", ValueType: common.ValueTypeMultiSelect, ProviderType: "IssueStatus",
+			Values: statusValues,
+		},
+	}
+
+	for name, field := range expected {
+		assert.Equal(t, field, metadata.Fields[name], name)
+	}
+
+	_, err = schema.ObjectMetadata("unknown", "Unknown")
+	require.ErrorIs(t, err, common.ErrObjectNotSupported)
+}
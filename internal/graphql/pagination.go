@@ -0,0 +1,120 @@
+package graphql
+
+import (
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+// Records extracts records returned by a root query field.
+// The field may be a plain list, or a Relay connection holding records in nodes or edges.
+//
+// Example:
+//
+//	{"data": {"issues": {"nodes": [{"id": "1"}], "pageInfo": {...}}}}
+//	{"data": {"issues": {"edges": [{"node": {"id": "1"}}], "pageInfo": {...}}}}
+//	{"data": {"boards": [{"id": "1"}]}}
+func Records(rootField string) common.RecordsFunc {
+	return func(node *ajson.Node) ([]map[string]any, error) {
+		result, err := rootValue(node, rootField)
+		if err != nil || result == nil {
+			return nil, err
+		}
+
+		if result.IsArray() {
+			return jsonquery.Convertor.ArrayToMap(result.MustArray())
+		}
+
+		nodes, err := jsonquery.New(result).ArrayOptional("nodes")
+		if err != nil {
+			return nil, err
+		}
+
+		if nodes != nil {
+			return jsonquery.Convertor.ArrayToMap(nodes)
+		}
+
+		edges, err := jsonquery.New(result).ArrayOptional("edges")
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]*ajson.Node, 0, len(edges))
+
+		for _, edge := range edges {
+			record, err := jsonquery.New(edge).ObjectOptional("node")
+			if err != nil {
+				return nil, err
+			}
+
+			if record != nil {
+				records = append(records, record)
+			}
+		}
+
+		return jsonquery.Convertor.ArrayToMap(records)
+	}
+}
+
+// RecordCount returns the number of records returned by a root query field, see Records.
+// Records are counted without being converted, ex: to learn the cost per record of a read.
+func RecordCount(rootField string, node *ajson.Node) int {
+	result, err := rootValue(node, rootField)
+	if err != nil || result == nil {
+		return 0
+	}
+
+	if result.IsArray() {
+		return result.Size()
+	}
+
+	for _, key := range []string{"nodes", "edges"} {
+		if list, err := result.GetKey(key); err == nil && list.IsArray() {
+			return list.Size()
+		}
+	}
+
+	return 0
+}
+
+// NextPage returns the end cursor of a Relay connection when it has more pages.
+// Root fields without pageInfo are read in one page.
+func NextPage(rootField string) common.NextPageFunc {
+	return func(node *ajson.Node) (string, error) {
+		result, err := rootValue(node, rootField)
+		if err != nil || result == nil || !result.IsObject() {
+			return "", err
+		}
+
+		pageInfo, err := jsonquery.New(result).ObjectOptional("pageInfo")
+		if err != nil || pageInfo == nil {
+			return "", err
+		}
+
+		hasNextPage, err := jsonquery.New(pageInfo).BoolWithDefault("hasNextPage", false)
+		if err != nil || !hasNextPage {
+			return "", err
+		}
+
+		return jsonquery.New(pageInfo).StringRequired("endCursor")
+	}
+}
+
+// rootValue returns data.<rootField> of a response, nil when it is missing or null.
+func rootValue(node *ajson.Node, rootField string) (*ajson.Node, error) {
+	data, err := jsonquery.New(node).ObjectOptional("data")
+	if err != nil || data == nil || !data.HasKey(rootField) {
+		return nil, err
+	}
+
+	result, err := data.GetKey(rootField)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.IsNull() {
+		return nil, nil //nolint:nilnil
+	}
+
+	return result, nil
+}
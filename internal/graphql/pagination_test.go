@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/spyzhov/ajson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsAndNextPage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		rootField string
+		response  string
+		records   []map[string]any
+		nextPage  string
+	}{
+		{
+			name:      "Nodes with next page",
+			rootField: "issues",
+			response: `{"data": {"issues": {"nodes": [{"id": "1"}, {"id": "2"}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "abc"}}}}`,
+			records:  []map[string]any{{"id": "1"}, {"id": "2"}},
+			nextPage: "abc",
+		},
+		{
+			name:      "Edges on the last page",
+			rootField: "teams",
+			response: `{"data": {"teams": {"edges": [{"node": {"id": "t"}, "cursor": "c"}],
+				"pageInfo": {"hasNextPage": false, "endCursor": "c"}}}}`,
+			records: []map[string]any{{"id": "t"}},
+		},
+		{
+			name:      "Plain list",
+			rootField: "boards",
+			response:  `{"data": {"boards": [{"name": "Roadmap"}]}}`,
+			records:   []map[string]any{{"name": "Roadmap"}},
+		},
+		{
+			name:      "Null result",
+			rootField: "issues",
+			response:  `{"data": {"issues": null}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			node, err := ajson.Unmarshal([]byte(tt.response))
+			require.NoError(t, err)
+
+			records, err := Records(tt.rootField)(node)
+			require.NoError(t, err)
+			assert.Equal(t, tt.records, records)
+
+			nextPage, err := NextPage(tt.rootField)(node)
+			require.NoError(t, err)
+			assert.Equal(t, tt.nextPage, nextPage)
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package graphql
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+const (
+	// idField is selected for every record, so rows always have an identifier.
+	idField = "id"
+	// pageInfoSelection is selected for every Relay connection.
+	pageInfoSelection = "pageInfo { hasNextPage endCursor }"
+	// connectionMarker prefixes aliases of __typename, which tell nodes of nested connections apart in bulk queries.
+	connectionMarker = "bulkConnection"
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// connectionKind tells how a Relay connection holds its records.
+type connectionKind int
+
+const (
+	connectionNone connectionKind = iota
+	connectionNodes
+	connectionEdges
+)
+
+// Read builds a query selecting fields of records returned by a root query field.
+//
+// Fields may refer to nested objects using dots, ex: "state.name" selects `state { name }`.
+// Nested connections select their nodes, ex: "labels.name" selects `labels { nodes { name } }`.
+// Object fields without nested selection default to their id, or to every scalar when they have none.
+// Fields missing in the schema are passed through as written, so the provider reports them,
+// and fields created after the schema was cached can still be read.
+// No fields selects every scalar of the record.
+//
+// Variables are declared only for arguments of the root field, others are dropped.
+// This lets callers share variables, ex: a filter, among objects supporting different arguments.
+// When the root field is a Relay connection, its pageInfo is selected for pagination.
+func (s *Schema) Read(rootField string, fields []string, variables map[string]any) (*Request, error) {
+	field, ok := s.QueryField(rootField)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", common.ErrObjectNotSupported, rootField)
+	}
+
+	recordType, kind, err := s.recordType(field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	selection := selector{schema: s}.selection(recordType, newSelectionTree(fields))
+
+	switch kind {
+	case connectionNodes:
+		selection = "{ nodes " + selection + " " + pageInfoSelection + " }"
+	case connectionEdges:
+		selection = "{ edges { node " + selection + " } " + pageInfoSelection + " }"
+	case connectionNone:
+	}
+
+	declarations := make([]string, 0, len(variables))
+	arguments := make([]string, 0, len(variables))
+	declared := make(map[string]any, len(variables))
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		arg, ok := field.Arg(name)
+		if !ok {
+			continue
+		}
+
+		declarations = append(declarations, fmt.Sprintf("$%s: %s", name, arg.Type))
+		arguments = append(arguments, fmt.Sprintf("%s: $%s", name, name))
+		declared[name] = variables[name]
+	}
+
+	var query strings.Builder
+
+	query.WriteString("query Read")
+
+	if len(declarations) != 0 {
+		query.WriteString("(" + strings.Join(declarations, ", ") + ")")
+	}
+
+	query.WriteString(" { " + field.Name)
+
+	if len(arguments) != 0 {
+		query.WriteString("(" + strings.Join(arguments, ", ") + ")")
+	}
+
+	query.WriteString(" " + selection + " }")
+
+	if len(declared) == 0 {
+		declared = nil
+	}
+
+	return &Request{
+		Query:     query.String(),
+		Variables: declared,
+	}, nil
+}
+
+// Select adds a root field to the query built by Schema.Read, ex: the cost of the query next to records.
+func (r *Request) Select(selection string) {
+	r.Query = strings.TrimSuffix(r.Query, " }") + " " + selection + " }"
+}
+
+// RecordType returns the type of records returned by a root query field.
+// Lists return their items, while Relay connections return the type of their nodes.
+func (s *Schema) RecordType(rootField string) (*Type, error) {
+	field, ok := s.QueryField(rootField)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", common.ErrObjectNotSupported, rootField)
+	}
+
+	recordType, _, err := s.recordType(field.Type)
+
+	return recordType, err
+}
+
+func (s *Schema) recordType(ref TypeRef) (*Type, connectionKind, error) {
+	typ, ok := s.Type(ref.Named().Name)
+	if !ok {
+		return nil, connectionNone, fmt.Errorf("%w: unknown type %s", common.ErrMissingExpectedValues, ref.Named().Name)
+	}
+
+	if nodeType, kind := s.connectionNode(typ); nodeType != nil {
+		return nodeType, kind, nil
+	}
+
+	return typ, connectionNone, nil
+}
+
+// connectionNode returns the node type of a Relay connection, or nil when the type is not a connection.
+func (s *Schema) connectionNode(typ *Type) (*Type, connectionKind) {
+	if _, ok := typ.Field("pageInfo"); !ok {
+		return nil, connectionNone
+	}
+
+	if nodes, ok := typ.Field("nodes"); ok {
+		if nodeType, ok := s.Type(nodes.Type.Named().Name); ok {
+			return nodeType, connectionNodes
+		}
+	}
+
+	if edges, ok := typ.Field("edges"); ok {
+		if edgeType, ok := s.Type(edges.Type.Named().Name); ok {
+			if node, ok := edgeType.Field("node"); ok {
+				if nodeType, ok := s.Type(node.Type.Named().Name); ok {
+					return nodeType, connectionEdges
+				}
+			}
+		}
+	}
+
+	return nil, connectionNone
+}
+
+// selectionTree holds requested fields by name, nested fields are children of their parent.
+type selectionTree map[string]selectionTree
+
+func newSelectionTree(fields []string) selectionTree {
+	tree := make(selectionTree)
+
+	for _, field := range fields {
+		node := tree
+
+		for _, name := range strings.Split(field, ".") {
+			if name == "" {
+				continue
+			}
+
+			if node[name] == nil {
+				node[name] = make(selectionTree)
+			}
+
+			node = node[name]
+		}
+	}
+
+	return tree
+}
+
+// merge returns a tree holding fields of both trees.
+func (t selectionTree) merge(other selectionTree) selectionTree {
+	if t == nil {
+		return other
+	}
+
+	result := maps.Clone(t)
+	for name, children := range other {
+		result[name] = result[name].merge(children)
+	}
+
+	return result
+}
+
+// BulkSelection is the selection set of records of a bulk query, see Schema.EdgesSelection.
+type BulkSelection struct {
+	// Selection is the selection set, ex: `{ id labels { edges { node { bulkConnection0: __typename id } } } }`.
+	Selection string
+	// Connections maps the marker selected by nodes of every nested connection
+	// to the path of the connection within the parent node, ex: "bulkConnection0" to ["labels"].
+	Connections map[string][]string
+}
+
+// EdgesSelection renders the selection set of records returned by a root query field,
+// where nested connections select `edges { node { ... } }`.
+// This is the form bulk queries require, ex: Shopify bulk operations, which return nodes of nested
+// connections as separate records. Such nodes select their __typename under an alias marking the connection,
+// so that connections of the same node type are told apart. Markers are the same for the same fields.
+func (s *Schema) EdgesSelection(rootField string, fields []string) (*BulkSelection, error) {
+	recordType, err := s.RecordType(rootField)
+	if err != nil {
+		return nil, err
+	}
+
+	connections := make(map[string][]string)
+	selection := selector{schema: s, edges: true, connections: connections}.
+		selection(recordType, newSelectionTree(fields))
+
+	return &BulkSelection{
+		Selection:   selection,
+		Connections: connections,
+	}, nil
+}
+
+// Connection returns the path of the connection holding a node of a bulk query within its parent node.
+// The marker of the connection is removed from the node.
+func (b *BulkSelection) Connection(node map[string]any) ([]string, bool) {
+	for marker, path := range b.Connections {
+		if _, ok := node[marker]; ok {
+			delete(node, marker)
+
+			return path, true
+		}
+	}
+
+	return nil, false
+}
+
+// selector renders selection sets of types of the schema.
+type selector struct {
+	schema *Schema
+	// edges selects nested connections via edges rather than nodes.
+	edges bool
+	// connections collects markers of nested connections, in edges mode.
+	connections map[string][]string
+	// path is the position of the selection within the enclosing node, in edges mode.
+	path []string
+}
+
+// selection renders a selection set of the type, ex: `{ id name state { id } }`.
+func (s selector) selection(typ *Type, tree selectionTree) string {
+	if len(tree) == 0 {
+		return s.defaultSelection(typ, false)
+	}
+
+	selected := make(map[string]string, len(tree)+1)
+
+	if _, ok := typ.Field(idField); ok {
+		selected[idField] = idField
+	}
+
+	// Names differing only in case refer to the same field, their nested fields are merged.
+	fields := make(map[string]*Field, len(tree))
+	subtrees := make(map[string]selectionTree, len(tree))
+
+	for _, name := range slices.Sorted(maps.Keys(tree)) {
+		field, ok := typ.Field(name)
+		if !ok {
+			selected[name] = name + renderUnknown(tree[name])
+
+			continue
+		}
+
+		fields[field.Name] = field
+		subtrees[field.Name] = subtrees[field.Name].merge(tree[name])
+	}
+
+	// Fields are visited in order, so that markers of connections are stable.
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		selected[name] = name + s.fieldSelection(fields[name], subtrees[name])
+	}
+
+	return "{ " + strings.Join(sortedValues(selected), " ") + " }"
+}
+
+// fieldSelection renders the selection set of a field, which is empty for scalars and enums.
+func (s selector) fieldSelection(field *Field, children selectionTree) string {
+	typ, ok := s.schema.Type(field.Type.Named().Name)
+	if !ok || !typ.isComposite() {
+		return ""
+	}
+
+	if typ.Kind == KindUnion {
+		return " { __typename }"
+	}
+
+	if nodeType, kind := s.schema.connectionNode(typ); nodeType != nil {
+		if s.edges {
+			return s.markedConnection(field, nodeType, children)
+		}
+
+		nodes := s.nestedSelection(nodeType, children)
+
+		if kind == connectionEdges {
+			return " { edges { node " + nodes + " } }"
+		}
+
+		return " { nodes " + nodes + " }"
+	}
+
+	nested := s
+	if s.edges {
+		nested.path = append(slices.Clone(s.path), field.Name)
+	}
+
+	return " " + nested.nestedSelection(typ, children)
+}
+
+// markedConnection renders a nested connection of a bulk query, its nodes select the marker of the connection.
+// Nodes are parents of their own nested connections, so paths within them start over.
+func (s selector) markedConnection(field *Field, nodeType *Type, children selectionTree) string {
+	marker := fmt.Sprintf("%s%d", connectionMarker, len(s.connections))
+	s.connections[marker] = append(slices.Clone(s.path), field.Name)
+
+	nested := s
+	nested.path = nil
+
+	nodes := nested.nestedSelection(nodeType, children)
+
+	return " { edges { node " + strings.Replace(nodes, "{ ", "{ "+marker+": __typename ", 1) + " } }"
+}
+
+// nestedSelection renders fields of a nested object, defaulting to a reference by id.
+func (s selector) nestedSelection(typ *Type, children selectionTree) string {
+	if len(children) != 0 {
+		return s.selection(typ, children)
+	}
+
+	return s.defaultSelection(typ, true)
+}
+
+// defaultSelection selects every scalar and enum field of the type.
+// References select only the id when the type has one.
+func (s selector) defaultSelection(typ *Type, reference bool) string {
+	if _, ok := typ.Field(idField); ok && reference {
+		return "{ " + idField + " }"
+	}
+
+	var names []string
+
+	for index := range typ.Fields {
+		field := &typ.Fields[index]
+		if field.IsDeprecated || field.HasRequiredArgs() {
+			continue
+		}
+
+		if fieldType, ok := s.schema.Type(field.Type.Named().Name); ok && fieldType.isComposite() {
+			continue
+		}
+
+		names = append(names, field.Name)
+	}
+
+	if len(names) == 0 {
+		return "{ __typename }"
+	}
+
+	slices.Sort(names)
+
+	return "{ " + strings.Join(names, " ") + " }"
+}
+
+func (t *Type) isComposite() bool {
+	return t.Kind == KindObject || t.Kind == KindInterface || t.Kind == KindUnion
+}
+
+// renderUnknown renders nested fields of a field missing in the schema as written.
+func renderUnknown(tree selectionTree) string {
+	if len(tree) == 0 {
+		return ""
+	}
+
+	selected := make(map[string]string, len(tree))
+	for name, children := range tree {
+		selected[name] = name + renderUnknown(children)
+	}
+
+	return " { " + strings.Join(sortedValues(selected), " ") + " }"
+}
+
+func sortedValues(values map[string]string) []string {
+	result := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		result = append(result, values[key])
+	}
+
+	return result
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Read(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	schema := testSchema(t)
+
+	tests := []struct {
+		name      string
+		rootField string
+		fields    []string
+		variables map[string]any
+		expected  *Request
+	}{
+		{
+			name:      "Connection with nested objects and connections",
+			rootField: "issues",
+			fields:    []string{"title", "state.name", "labels", "labels.name", "customField"},
+			variables: map[string]any{"first": 100, "after": "cursor", "unknown": true},
+			expected: &Request{
+				Query: "query Read($after: String, $first: Int) { issues(after: $after, first: $first) " +
+					"{ nodes { customField id labels { nodes { id name } } state { id name } title } " +
+					"pageInfo { hasNextPage endCursor } } }",
+				Variables: map[string]any{"first": 100, "after": "cursor"},
+			},
+		},
+		{
+			name:      "Lowercase fields and default nested selection",
+			rootField: "issues",
+			fields:    []string{"duedate", "state", "labels"},
+			expected: &Request{
+				Query: "query Read { issues { nodes { dueDate id labels { nodes { id } } state { id } } " +
+					"pageInfo { hasNextPage endCursor } } }",
+			},
+		},
+		{
+			name:      "Fields differing in case merge their nested fields",
+			rootField: "issues",
+			fields:    []string{"state.id", "State.name", "STATE"},
+			expected: &Request{
+				Query: "query Read { issues { nodes { id state { id name } } " +
+					"pageInfo { hasNextPage endCursor } } }",
+			},
+		},
+		{
+			name:      "No fields select every scalar",
+			rootField: "issues",
+			expected: &Request{
+				Query: "query Read { issues { nodes { createdAt dueDate estimate flags id labelIds priority " +
+					"status title trashed } pageInfo { hasNextPage endCursor } } }",
+			},
+		},
+		{
+			name:      "Connection with edges",
+			rootField: "teams",
+			fields:    []string{"key"},
+			variables: map[string]any{"first": 10},
+			expected: &Request{
+				Query: "query Read($first: Int) { teams(first: $first) " +
+					"{ edges { node { id key } } pageInfo { hasNextPage endCursor } } }",
+				Variables: map[string]any{"first": 10},
+			},
+		},
+		{
+			name:      "List without id",
+			rootField: "boards",
+			fields:    []string{"name", "owner"},
+			variables: map[string]any{"limit": 200, "page": 2},
+			expected: &Request{
+				Query:     "query Read($limit: Int, $page: Int) { boards(limit: $limit, page: $page) { name owner { id } } }",
+				Variables: map[string]any{"limit": 200, "page": 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request, err := schema.Read(tt.rootField, tt.fields, tt.variables)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, request)
+		})
+	}
+
+	_, err := schema.Read("unknown", []string{"id"}, nil)
+	require.ErrorIs(t, err, common.ErrObjectNotSupported)
+}
+
+func TestSchema_EdgesSelection(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema(t)
+
+	selection, err := schema.EdgesSelection("issues", []string{"title", "labels.name", "state"})
+	require.NoError(t, err)
+	assert.Equal(t, "{ id labels { edges { node { bulkConnection0: __typename id name } } } state { id } title }",
+		selection.Selection)
+	assert.Equal(t, map[string][]string{"bulkConnection0": {"labels"}}, selection.Connections)
+
+	node := map[string]any{"id": "label-1", "bulkConnection0": "IssueLabel"}
+	path, ok := selection.Connection(node)
+	require.True(t, ok)
+	assert.Equal(t, []string{"labels"}, path)
+	assert.Equal(t, map[string]any{"id": "label-1"}, node)
+
+	_, ok = selection.Connection(map[string]any{"id": "issue-1"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,185 @@
+package attio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+)
+
+// Records are linked by record-reference attributes, ex: "company" of a person.
+// Association type ID is the slug of such attribute on the source object.
+
+var _ connectors.AssociationConnector = &Connector{}
+
+type recordReference struct {
+	TargetObject   string `json:"target_object"`
+	TargetRecordId string `json:"target_record_id"` // nolint:revive
+}
+
+// ListAssociations returns records referenced by the attribute of a record.
+// https://developers.attio.com/reference/get_v2-objects-object-records-record-id-attributes-attribute-values
+func (c *Connector) ListAssociations(
+	ctx context.Context, params common.ListAssociationsParams,
+) (*common.ListAssociationsResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	references, err := c.getReferences(ctx, params.FromObject, params.FromId, params.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	associations := make([]common.Association, 0, len(references))
+
+	for _, reference := range references {
+		if reference.TargetObject != params.ToObject {
+			continue
+		}
+
+		associations = append(associations, common.Association{
+			ObjectId:        reference.TargetRecordId,
+			AssociationType: params.Type.Id,
+			Raw: map[string]any{
+				"target_object":    reference.TargetObject,
+				"target_record_id": reference.TargetRecordId,
+			},
+		})
+	}
+
+	return &common.ListAssociationsResult{
+		Associations: associations,
+		Done:         true,
+	}, nil
+}
+
+// CreateAssociation adds the target record to the attribute of the source record.
+// Multiselect attributes keep existing references.
+// https://developers.attio.com/reference/patch_v2-objects-object-records-record-id
+func (c *Connector) CreateAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.WriteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	if descriptor.Type.Id == "" {
+		return nil, common.ErrMissingAssociationType
+	}
+
+	return c.writeReferences(ctx, c.Client.Patch, descriptor, []recordReference{{
+		TargetObject:   descriptor.ToObject,
+		TargetRecordId: descriptor.ToId,
+	}})
+}
+
+// DeleteAssociation removes the target record from the attribute of the source record,
+// keeping other references.
+// https://developers.attio.com/reference/put_v2-objects-object-records-record-id
+func (c *Connector) DeleteAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.DeleteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	references, err := c.getReferences(ctx, descriptor.FromObject, descriptor.FromId, descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]recordReference, 0, len(references))
+
+	for _, reference := range references {
+		if reference.TargetObject != descriptor.ToObject || reference.TargetRecordId != descriptor.ToId {
+			remaining = append(remaining, reference)
+		}
+	}
+
+	if len(remaining) != len(references) {
+		// Put overwrites all values of the attribute.
+		if _, err = c.writeReferences(ctx, c.Client.Put, descriptor, remaining); err != nil {
+			return nil, err
+		}
+	}
+
+	return &common.DeleteResult{Success: true}, nil
+}
+
+func (c *Connector) getReferences(
+	ctx context.Context, objectName, recordID string, typ common.AssociationType,
+) ([]recordReference, error) {
+	if typ.Id == "" {
+		return nil, common.ErrMissingAssociationType
+	}
+
+	url, err := c.getAttributeValuesURL(objectName, recordID, typ.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := common.UnmarshalJSON[struct {
+		Data []recordReference `json:"data"`
+	}](res)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	return output.Data, nil
+}
+
+func (c *Connector) writeReferences(
+	ctx context.Context, write common.WriteMethod,
+	descriptor common.AssociationDescriptor, references []recordReference,
+) (*common.WriteResult, error) {
+	url, err := c.getObjectWriteURL(descriptor.FromObject)
+	if err != nil {
+		return nil, err
+	}
+
+	url.AddPath(descriptor.FromId)
+
+	res, err := write(ctx, url.String(), map[string]any{
+		"data": map[string]any{
+			"values": map[string]any{
+				descriptor.Type.Id: references,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := res.Body()
+	if !ok {
+		return &common.WriteResult{Success: true, RecordId: descriptor.FromId}, nil
+	}
+
+	record, err := jsonquery.New(body).ObjectRequired("data")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := jsonquery.Convertor.ObjectToMap(record)
+	if err != nil {
+		return nil, fmt.Errorf("parsing record: %w", err)
+	}
+
+	return &common.WriteResult{
+		Success:  true,
+		RecordId: descriptor.FromId,
+		Data:     data,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package attio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+const (
+	testPersonID  = "0a9a5a4e-1c5a-4c4b-9f4e-3b1c0f1b2d3e"
+	testCompanyID = "bf071e1f-6035-429d-b874-d83ea64ea13b"
+)
+
+func TestListAssociations(t *testing.T) {
+	t.Parallel()
+
+	responseValues := testutils.DataFromFile(t, "associations/company-values.json")
+
+	tests := []testroutines.ListAssociations{
+		{
+			Name:         "Attribute is required",
+			Input:        common.ListAssociationsParams{FromObject: "people", FromId: testPersonID, ToObject: "companies"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingAssociationType},
+		},
+		{
+			Name: "Referenced records are listed",
+			Input: common.ListAssociationsParams{
+				FromObject: "people", FromId: testPersonID, ToObject: "companies",
+				Type: common.AssociationType{Id: "company"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.Path("/v2/objects/people/records/" + testPersonID + "/attributes/company/values"),
+				Then:  mockserver.Response(http.StatusOK, responseValues),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{
+					ObjectId:        testCompanyID,
+					AssociationType: "company",
+					Raw: map[string]any{
+						"target_object":    "companies",
+						"target_record_id": testCompanyID,
+					},
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDeleteAssociation(t *testing.T) {
+	t.Parallel()
+
+	responseValues := testutils.DataFromFile(t, "associations/company-values.json")
+
+	tests := []testroutines.DeleteAssociation{
+		{
+			Name: "Remaining references are written back",
+			Input: common.AssociationDescriptor{
+				FromObject: "people", FromId: testPersonID, ToObject: "companies", ToId: testCompanyID,
+				Type: common.AssociationType{Id: "company"},
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.MethodGET(),
+					Then: mockserver.Response(http.StatusOK, responseValues),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPUT(),
+						mockcond.Path("/v2/objects/people/records/" + testPersonID),
+						mockcond.Body(`{"data":{"values":{"company":[]}}}`),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"data":{"id":{"record_id":"`+testPersonID+`"}}}`),
+				}},
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
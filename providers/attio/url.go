@@ -30,3 +30,9 @@ func (c *Connector) getObjectReadURL(objName string) (*urlbuilder.URL, error) {
 func (c *Connector) getObjectWriteURL(objName string) (*urlbuilder.URL, error) {
 	return urlbuilder.New(c.BaseURL, apiVersion, "objects", objName, "records")
 }
+
+// Relative URL for listing values of a record attribute.
+func (c *Connector) getAttributeValuesURL(objName, recordID, attribute string) (*urlbuilder.URL, error) {
+	return urlbuilder.New(c.BaseURL, apiVersion, "objects", objName, "records", recordID,
+		"attributes", attribute, "values")
+}
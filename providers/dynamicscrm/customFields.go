@@ -0,0 +1,503 @@
+package dynamicscrm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+)
+
+// Column definitions are managed by Microsoft Dataverse metadata API.
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/create-update-column-definitions-using-web-api
+
+const (
+	languageCodeEnglish = 1033
+	// Choice values of custom columns start at the default publisher prefix value.
+	optionValueBase = 100000000
+
+	stringDefaultLength = 100
+	stringMaxLength     = 4000
+	memoDefaultLength   = 2000
+
+	requiredLevelNone        = "None"
+	requiredLevelApplication = "ApplicationRequired"
+)
+
+// attributeDefinition is an existing column as returned by the metadata API.
+//
+// nolint:tagliatelle
+type attributeDefinition struct {
+	MetadataId        string `json:"MetadataId"` // nolint:revive
+	LogicalName       string `json:"LogicalName"`
+	SchemaName        string `json:"SchemaName"`
+	AttributeTypeName struct {
+		Value string `json:"Value"`
+	} `json:"AttributeTypeName"`
+	DisplayName   userLabel `json:"DisplayName"`
+	Description   userLabel `json:"Description"`
+	RequiredLevel struct {
+		Value string `json:"Value"`
+	} `json:"RequiredLevel"`
+}
+
+// nolint:tagliatelle
+type userLabel struct {
+	UserLocalizedLabel *struct {
+		Label string `json:"Label"`
+	} `json:"UserLocalizedLabel"`
+}
+
+func (l userLabel) String() string {
+	if l.UserLocalizedLabel == nil {
+		return ""
+	}
+
+	return l.UserLocalizedLabel.Label
+}
+
+// attributePayload is a column definition sent to create a column, or the changes applied to an existing one.
+//
+// nolint:tagliatelle
+type attributePayload struct {
+	ODataType     string         `json:"@odata.type"`
+	SchemaName    string         `json:"SchemaName"`
+	DisplayName   label          `json:"DisplayName"`
+	Description   *label         `json:"Description,omitempty"`
+	RequiredLevel requiredLevel  `json:"RequiredLevel"`
+	MaxLength     *int           `json:"MaxLength,omitempty"`
+	FormatName    *formatName    `json:"FormatName,omitempty"`
+	Format        string         `json:"Format,omitempty"`
+	Precision     *int           `json:"Precision,omitempty"`
+	MinValue      *float64       `json:"MinValue,omitempty"`
+	MaxValue      *float64       `json:"MaxValue,omitempty"`
+	DefaultValue  any            `json:"DefaultValue,omitempty"`
+	OptionSet     map[string]any `json:"OptionSet,omitempty"`
+
+	// typeName is the value of AttributeTypeName once the column exists.
+	typeName string
+}
+
+// nolint:tagliatelle
+type label struct {
+	ODataType       string           `json:"@odata.type"`
+	LocalizedLabels []localizedLabel `json:"LocalizedLabels"`
+}
+
+// nolint:tagliatelle
+type localizedLabel struct {
+	ODataType    string `json:"@odata.type"`
+	Label        string `json:"Label"`
+	LanguageCode int    `json:"LanguageCode"`
+}
+
+// nolint:tagliatelle
+type requiredLevel struct {
+	Value                      string `json:"Value"`
+	CanBeChanged               bool   `json:"CanBeChanged"`
+	ManagedPropertyLogicalName string `json:"ManagedPropertyLogicalName"`
+}
+
+// nolint:tagliatelle
+type formatName struct {
+	Value string `json:"Value"`
+}
+
+// UpsertMetadata creates custom columns or updates them if they already exist.
+// Field names are schema names including the publisher prefix, ex: "new_Nickname".
+// Only display name, description and requirement level of existing columns are compared and updated.
+// Changed entities are published, so that new columns are usable right away.
+// A dry run compares definitions with existing columns the same way, but neither writes nor publishes.
+func (c *Connector) UpsertMetadata(
+	ctx context.Context, params *common.UpsertMetadataParams,
+) (*common.UpsertMetadataResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	result := &common.UpsertMetadataResult{
+		Success: true,
+		Fields:  make(map[string]map[string]common.FieldUpsertResult, len(params.Fields)),
+		DryRun:  params.DryRun,
+	}
+
+	for objectName, definitions := range params.Fields {
+		// EntityDefinitions API uses singular object names.
+		entityName := naming.NewSingularString(objectName).String()
+
+		fields, changed, err := c.upsertAttributes(ctx, entityName, definitions, params.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("object %s: %w", objectName, err)
+		}
+
+		if changed && !params.DryRun {
+			if err = c.publishEntity(ctx, entityName); err != nil {
+				return nil, fmt.Errorf("object %s: %w", objectName, err)
+			}
+		}
+
+		result.Fields[objectName] = fields
+	}
+
+	return result, nil
+}
+
+func (c *Connector) upsertAttributes(
+	ctx context.Context, entityName string, definitions []common.FieldDefinition, dryRun bool,
+) (map[string]common.FieldUpsertResult, bool, error) {
+	existing, err := c.fetchAttributeDefinitions(ctx, entityName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	fields := make(map[string]common.FieldUpsertResult, len(definitions))
+	changed := false
+
+	for _, definition := range definitions {
+		payload, warnings, err := newAttributePayload(definition)
+		if err != nil {
+			return nil, false, err
+		}
+
+		current, ok := existing[strings.ToLower(definition.FieldName)]
+		action := common.UpsertMetadataActionNone
+		metadata := map[string]any{}
+
+		switch {
+		case !ok:
+			action = common.UpsertMetadataActionCreate
+
+			if !dryRun {
+				metadata["MetadataId"], err = c.createAttribute(ctx, entityName, payload)
+			}
+		case current.AttributeTypeName.Value != payload.typeName:
+			warnings = append(warnings, fmt.Sprintf("column type cannot be changed from %s to %s, column is left as is",
+				current.AttributeTypeName.Value, payload.typeName))
+		case !current.matches(payload):
+			action = common.UpsertMetadataActionUpdate
+
+			if payload.OptionSet != nil {
+				warnings = append(warnings, "options of existing columns are not changed")
+			}
+
+			if !dryRun {
+				err = c.updateAttribute(ctx, entityName, current.LogicalName, payload)
+			}
+		}
+
+		if err != nil {
+			return nil, false, fmt.Errorf("field %s: %w", definition.FieldName, err)
+		}
+
+		if ok {
+			metadata["MetadataId"] = current.MetadataId
+			metadata["LogicalName"] = current.LogicalName
+		}
+
+		changed = changed || action != common.UpsertMetadataActionNone
+		fields[definition.FieldName] = common.FieldUpsertResult{
+			FieldName: definition.FieldName,
+			Action:    action,
+			Metadata:  metadata,
+			Warnings:  warnings,
+		}
+	}
+
+	return fields, changed, nil
+}
+
+// fetchAttributeDefinitions returns columns of the entity keyed by logical name.
+func (c *Connector) fetchAttributeDefinitions(
+	ctx context.Context, entityName string,
+) (map[string]attributeDefinition, error) {
+	url, err := c.getURL(fmt.Sprintf("EntityDefinitions(LogicalName='%v')/Attributes", entityName))
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("$select",
+		"MetadataId,LogicalName,SchemaName,AttributeTypeName,DisplayName,Description,RequiredLevel")
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[struct {
+		Value []attributeDefinition `json:"value"`
+	}](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]attributeDefinition)
+
+	if response != nil {
+		for _, attribute := range response.Value {
+			attributes[attribute.LogicalName] = attribute
+		}
+	}
+
+	return attributes, nil
+}
+
+// createAttribute returns the MetadataId of the new column.
+func (c *Connector) createAttribute(ctx context.Context, entityName string, payload *attributePayload) (string, error) {
+	url, err := c.getURL(fmt.Sprintf("EntityDefinitions(LogicalName='%v')/Attributes", entityName))
+	if err != nil {
+		return "", err
+	}
+
+	rsp, err := c.Client.Post(ctx, url.String(), payload)
+	if err != nil {
+		return "", err
+	}
+
+	// Response has no body, the location of the new column is in the header,
+	// ex: ".../EntityDefinitions(LogicalName='contact')/Attributes(00aa00aa-bb11-cc22-dd33-44ee44ee44ee)".
+	location := rsp.Headers.Get("OData-EntityId")
+
+	start := strings.LastIndex(location, "(")
+	if start == -1 || !strings.HasSuffix(location, ")") {
+		return "", nil
+	}
+
+	return location[start+1 : len(location)-1], nil
+}
+
+// updateAttribute replaces the column definition, since the metadata API has no partial updates of columns.
+// The current definition is read first, so that only display name, description and requirement level change.
+func (c *Connector) updateAttribute(
+	ctx context.Context, entityName, logicalName string, payload *attributePayload,
+) error {
+	path := fmt.Sprintf("EntityDefinitions(LogicalName='%v')/Attributes(LogicalName='%v')", entityName, logicalName)
+
+	// Properties specific to the column type are returned only when the column is cast to its type.
+	typedURL, err := c.getURL(path + "/" + payload.ODataType)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := c.Client.Get(ctx, typedURL.String())
+	if err != nil {
+		return err
+	}
+
+	definition, err := common.UnmarshalJSON[map[string]any](rsp)
+	if err != nil {
+		return err
+	}
+
+	if definition == nil {
+		return fmt.Errorf("%w: definition of column %s", common.ErrMissingExpectedValues, logicalName)
+	}
+
+	delete(*definition, "@odata.context")
+
+	description := newLabel("")
+	if payload.Description != nil {
+		description = *payload.Description
+	}
+
+	(*definition)["@odata.type"] = payload.ODataType
+	(*definition)["DisplayName"] = payload.DisplayName
+	(*definition)["Description"] = description
+	(*definition)["RequiredLevel"] = payload.RequiredLevel
+
+	url, err := c.getURL(path)
+	if err != nil {
+		return err
+	}
+
+	// Labels in other languages are kept.
+	_, err = c.Client.Put(ctx, url.String(), definition, common.Header{Key: "MSCRM.MergeLabels", Value: "true"})
+
+	return err
+}
+
+// publishEntity makes metadata changes of the entity available to apps.
+// https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/reference/publishxml
+func (c *Connector) publishEntity(ctx context.Context, entityName string) error {
+	url, err := c.getURL("PublishXml")
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Post(ctx, url.String(), map[string]string{
+		"ParameterXml": "<importexportxml><entities><entity>" + entityName + "</entity></entities></importexportxml>",
+	})
+
+	return err
+}
+
+// matches reports whether updating the column with the payload would change anything.
+func (a attributeDefinition) matches(payload *attributePayload) bool {
+	description := ""
+	if payload.Description != nil {
+		description = payload.Description.LocalizedLabels[0].Label
+	}
+
+	return a.DisplayName.String() == payload.DisplayName.LocalizedLabels[0].Label &&
+		a.Description.String() == description &&
+		a.RequiredLevel.Value == payload.RequiredLevel.Value
+}
+
+func newAttributePayload(definition common.FieldDefinition) (*attributePayload, []string, error) { // nolint:funlen
+	payload := &attributePayload{
+		SchemaName:  definition.FieldName,
+		DisplayName: newLabel(definition.DisplayName),
+		RequiredLevel: requiredLevel{
+			Value:                      requiredLevelNone,
+			CanBeChanged:               true,
+			ManagedPropertyLogicalName: "canmodifyrequirementlevelsettings",
+		},
+	}
+
+	if definition.Description != "" {
+		description := newLabel(definition.Description)
+		payload.Description = &description
+	}
+
+	if definition.Required {
+		payload.RequiredLevel.Value = requiredLevelApplication
+	}
+
+	var warnings []string
+
+	switch definition.ValueType {
+	case common.FieldTypeString:
+		length := stringDefaultLength
+		if definition.StringOptions != nil && definition.StringOptions.Length != nil {
+			length = *definition.StringOptions.Length
+		}
+
+		payload.ODataType = "Microsoft.Dynamics.CRM.StringAttributeMetadata"
+		payload.typeName = "StringType"
+		payload.FormatName = &formatName{Value: "Text"}
+
+		if length > stringMaxLength || isMultiLine(definition.StringOptions) {
+			if definition.StringOptions == nil || definition.StringOptions.Length == nil {
+				length = memoDefaultLength
+			}
+
+			payload.ODataType = "Microsoft.Dynamics.CRM.MemoAttributeMetadata"
+			payload.typeName = "MemoType"
+			payload.FormatName = nil
+			payload.Format = "TextArea"
+		}
+
+		payload.MaxLength = &length
+	case common.FieldTypeBoolean:
+		payload.ODataType = "Microsoft.Dynamics.CRM.BooleanAttributeMetadata"
+		payload.typeName = "BooleanType"
+		payload.DefaultValue = false
+		payload.OptionSet = map[string]any{
+			"@odata.type":   "Microsoft.Dynamics.CRM.BooleanOptionSetMetadata",
+			"OptionSetType": "Boolean",
+			"TrueOption":    map[string]any{"Value": 1, "Label": newLabel("Yes")},
+			"FalseOption":   map[string]any{"Value": 0, "Label": newLabel("No")},
+		}
+	case common.FieldTypeDate, common.FieldTypeDateTime:
+		payload.ODataType = "Microsoft.Dynamics.CRM.DateTimeAttributeMetadata"
+		payload.typeName = "DateTimeType"
+		payload.Format = "DateAndTime"
+
+		if definition.ValueType == common.FieldTypeDate {
+			payload.Format = "DateOnly"
+		}
+	case common.FieldTypeSingleSelect, common.FieldTypeMultiSelect:
+		payload.ODataType = "Microsoft.Dynamics.CRM.PicklistAttributeMetadata"
+		payload.typeName = "PicklistType"
+
+		if definition.ValueType == common.FieldTypeMultiSelect {
+			payload.ODataType = "Microsoft.Dynamics.CRM.MultiSelectPicklistAttributeMetadata"
+			payload.typeName = "MultiSelectPicklistType"
+		}
+
+		payload.OptionSet = newOptionSet(definition.StringOptions)
+	case common.FieldTypeInt:
+		payload.ODataType = "Microsoft.Dynamics.CRM.IntegerAttributeMetadata"
+		payload.typeName = "IntegerType"
+		payload.Format = "None"
+	case common.FieldTypeFloat:
+		payload.ODataType = "Microsoft.Dynamics.CRM.DecimalAttributeMetadata"
+		payload.typeName = "DecimalType"
+
+		if definition.NumericOptions != nil {
+			payload.Precision = definition.NumericOptions.Scale
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w, fieldName: %v", common.ErrFieldTypeUnknown, definition.FieldName)
+	}
+
+	if options := definition.NumericOptions; options != nil {
+		if payload.typeName == "IntegerType" || payload.typeName == "DecimalType" {
+			payload.MinValue = options.Min
+			payload.MaxValue = options.Max
+		}
+
+		if options.DefaultValue != nil {
+			warnings = append(warnings, "default values are not supported")
+		}
+	}
+
+	if definition.Unique {
+		warnings = append(warnings, "uniqueness is enforced by alternate keys, which are not created")
+	}
+
+	if definition.Indexed {
+		warnings = append(warnings, "indexing is not configurable")
+	}
+
+	if definition.Association != nil {
+		warnings = append(warnings, "lookup columns are not supported")
+	}
+
+	if options := definition.StringOptions; options != nil {
+		if options.Pattern != "" {
+			warnings = append(warnings, "pattern validation is not supported")
+		}
+
+		if options.DefaultValue != nil {
+			warnings = append(warnings, "default values are not supported")
+		}
+	}
+
+	return payload, warnings, nil
+}
+
+func newOptionSet(options *common.StringFieldOptions) map[string]any {
+	values := make([]map[string]any, 0)
+
+	if options != nil {
+		for index, value := range options.Values {
+			values = append(values, map[string]any{
+				"Value": optionValueBase + index,
+				"Label": newLabel(value),
+			})
+		}
+	}
+
+	return map[string]any{
+		"@odata.type":   "Microsoft.Dynamics.CRM.OptionSetMetadata",
+		"IsGlobal":      false,
+		"OptionSetType": "Picklist",
+		"Options":       values,
+	}
+}
+
+func newLabel(text string) label {
+	return label{
+		ODataType: "Microsoft.Dynamics.CRM.Label",
+		LocalizedLabels: []localizedLabel{{
+			ODataType:    "Microsoft.Dynamics.CRM.LocalizedLabel",
+			Label:        text,
+			LanguageCode: languageCodeEnglish,
+		}},
+	}
+}
+
+func isMultiLine(options *common.StringFieldOptions) bool {
+	return options != nil && options.NumDisplayLines != nil && *options.NumDisplayLines > 1
+}
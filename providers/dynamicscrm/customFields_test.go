@@ -0,0 +1,172 @@
+package dynamicscrm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestUpsertMetadata(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseAttributes := testutils.DataFromFile(t, "custom-fields/contact-attributes.json")
+	responseTierAttribute := testutils.DataFromFile(t, "custom-fields/tier-attribute.json")
+
+	tests := []testroutines.UpsertMetadata{
+		{
+			Name:         "At least one field must be provided",
+			Input:        &common.UpsertMetadataParams{},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingFieldsMetadata},
+		},
+		{
+			Name: "Columns are created, updated or left as is",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"contacts": {{
+						FieldName:   "new_Nickname",
+						DisplayName: "Nickname",
+						ValueType:   common.FieldTypeString,
+					}, {
+						FieldName:     "new_Tier",
+						DisplayName:   "Customer Tier",
+						ValueType:     common.FieldTypeSingleSelect,
+						StringOptions: &common.StringFieldOptions{Values: []string{"Gold", "Silver"}},
+					}, {
+						FieldName:   "new_Score",
+						DisplayName: "Score",
+						ValueType:   common.FieldTypeFloat,
+					}, {
+						FieldName:   "new_Renewal",
+						DisplayName: "Renewal",
+						ValueType:   common.FieldTypeDate,
+						Unique:      true,
+					}},
+				},
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/api/data/v9.2/EntityDefinitions(LogicalName='contact')/Attributes"),
+					},
+					Then: mockserver.Response(http.StatusOK, responseAttributes),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/api/data/v9.2/EntityDefinitions(LogicalName='contact')/Attributes(LogicalName='new_tier')/Microsoft.Dynamics.CRM.PicklistAttributeMetadata"), // nolint:lll
+					},
+					Then: mockserver.Response(http.StatusOK, responseTierAttribute),
+				}, {
+					// The whole definition is sent back, with the changed display name.
+					If: mockcond.And{
+						mockcond.MethodPUT(),
+						mockcond.Path("/api/data/v9.2/EntityDefinitions(LogicalName='contact')/Attributes(LogicalName='new_tier')"), // nolint:lll
+						mockcond.Header(http.Header{"MSCRM.MergeLabels": []string{"true"}}),
+						mockcond.Body(`{
+							"@odata.type": "Microsoft.Dynamics.CRM.PicklistAttributeMetadata",
+							"MetadataId": "7a1c9e2d-4b3f-4a5e-8c6d-2e3f4a5b6c7d",
+							"LogicalName": "new_tier",
+							"SchemaName": "new_Tier",
+							"AttributeType": "Picklist",
+							"AttributeTypeName": {"Value": "PicklistType"},
+							"DefaultFormValue": -1,
+							"IsCustomAttribute": true,
+							"IsAuditEnabled": {"Value": true, "CanBeChanged": true,
+								"ManagedPropertyLogicalName": "canmodifyauditsettings"},
+							"IsValidForAdvancedFind": {"Value": true, "CanBeChanged": true,
+								"ManagedPropertyLogicalName": "canmodifysearchsettings"},
+							"DisplayName": {"@odata.type": "Microsoft.Dynamics.CRM.Label", "LocalizedLabels": [{
+								"@odata.type": "Microsoft.Dynamics.CRM.LocalizedLabel",
+								"Label": "Customer Tier", "LanguageCode": 1033}]},
+							"Description": {"@odata.type": "Microsoft.Dynamics.CRM.Label", "LocalizedLabels": [{
+								"@odata.type": "Microsoft.Dynamics.CRM.LocalizedLabel",
+								"Label": "", "LanguageCode": 1033}]},
+							"RequiredLevel": {"Value": "None", "CanBeChanged": true,
+								"ManagedPropertyLogicalName": "canmodifyrequirementlevelsettings"}
+						}`),
+					},
+					Then: mockserver.Response(http.StatusNoContent),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/api/data/v9.2/EntityDefinitions(LogicalName='contact')/Attributes"),
+					},
+					Then: mockserver.ResponseChainedFuncs(
+						mockserver.Header("OData-EntityId", "https://test-workspace.crm.dynamics.com/api/data/v9.2/"+
+							"EntityDefinitions(LogicalName='contact')/Attributes(9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a)"),
+						mockserver.Response(http.StatusNoContent),
+					),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/api/data/v9.2/PublishXml"),
+						mockcond.Body(`{"ParameterXml":
+							"<importexportxml><entities><entity>contact</entity></entities></importexportxml>"}`),
+					},
+					Then: mockserver.Response(http.StatusNoContent),
+				}},
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"contacts": {
+						"new_Nickname": {
+							FieldName: "new_Nickname",
+							Action:    common.UpsertMetadataActionNone,
+							Metadata: map[string]any{
+								"MetadataId":  "e4b8c5a1-2f3d-4e6a-9b7c-1d2e3f4a5b6c",
+								"LogicalName": "new_nickname",
+							},
+						},
+						"new_Tier": {
+							FieldName: "new_Tier",
+							Action:    common.UpsertMetadataActionUpdate,
+							Metadata: map[string]any{
+								"MetadataId":  "7a1c9e2d-4b3f-4a5e-8c6d-2e3f4a5b6c7d",
+								"LogicalName": "new_tier",
+							},
+							Warnings: []string{"options of existing columns are not changed"},
+						},
+						"new_Score": {
+							FieldName: "new_Score",
+							Action:    common.UpsertMetadataActionNone,
+							Metadata: map[string]any{
+								"MetadataId":  "3c5d7e9f-1a2b-4c3d-9e4f-5a6b7c8d9e0f",
+								"LogicalName": "new_score",
+							},
+							Warnings: []string{
+								"column type cannot be changed from IntegerType to DecimalType, column is left as is",
+							},
+						},
+						"new_Renewal": {
+							FieldName: "new_Renewal",
+							Action:    common.UpsertMetadataActionCreate,
+							Metadata:  map[string]any{"MetadataId": "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a"},
+							Warnings:  []string{"uniqueness is enforced by alternate keys, which are not created"},
+						},
+					},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.UpsertMetadataConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 
+	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/logging"
+	"github.com/amp-labs/connectors/common/urlbuilder"
 )
 
 // Type definitions for HubSpot associations API.
@@ -162,3 +165,259 @@ func (c *Connector) getObjectAssociations( //nolint:cyclop
 
 	return out, nil
 }
+
+const (
+	associationsListLimit = "500"
+
+	// associationCategoryDefault is assumed when the association type has an ID but no category.
+	associationCategoryDefault = "HUBSPOT_DEFINED"
+)
+
+var _ connectors.AssociationConnector = &Connector{}
+
+type assocListOutput struct {
+	Results []assocObject `json:"results"`
+	Paging  *struct {
+		Next *struct {
+			After string `json:"after"`
+		} `json:"next"`
+	} `json:"paging"`
+}
+
+type assocSpec struct {
+	AssociationCategory string `json:"associationCategory"`
+	AssociationTypeId   int    `json:"associationTypeId"`
+}
+
+type assocArchiveInputs struct {
+	Inputs []assocArchiveInput `json:"inputs"`
+}
+
+type assocArchiveInput struct {
+	Types []assocSpec `json:"types"`
+	From  assocId     `json:"from"`
+	To    assocId     `json:"to"`
+}
+
+// ListAssociations returns records of the target object associated with a record.
+// When the association type is set, only associations of that type are returned.
+// https://developers.hubspot.com/docs/api-reference/crm-associations-v4/basic/get-crm-v4-objects-objectType-objectId-associations-toObjectType
+func (c *Connector) ListAssociations(
+	ctx context.Context, params common.ListAssociationsParams,
+) (*common.ListAssociationsResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	url, err := c.getAssociationsURL(params.FromObject, params.FromId, params.ToObject)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("limit", associationsListLimit)
+
+	if len(params.NextPage) != 0 {
+		url.WithQueryParam("after", params.NextPage.String())
+	}
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := common.UnmarshalJSON[assocListOutput](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	associations := make([]common.Association, 0, len(output.Results))
+
+	for _, assoc := range output.Results {
+		for _, typ := range assoc.AssociationTypes {
+			if !params.Type.IsZero() && !typ.matches(params.Type) {
+				continue
+			}
+
+			raw := map[string]any{
+				"category": typ.Category,
+				"typeId":   typ.TypeId,
+			}
+
+			if typ.Label != nil {
+				raw["label"] = *typ.Label
+			}
+
+			associations = append(associations, common.Association{
+				ObjectId:        strconv.FormatInt(assoc.ToObjectId, 10),
+				AssociationType: typ.String(),
+				Raw:             raw,
+			})
+		}
+	}
+
+	nextPage := ""
+	if output.Paging != nil && output.Paging.Next != nil {
+		nextPage = output.Paging.Next.After
+	}
+
+	return &common.ListAssociationsResult{
+		Associations: associations,
+		NextPage:     common.NextPageToken(nextPage),
+		Done:         nextPage == "",
+	}, nil
+}
+
+// CreateAssociation associates two records using either the default or the given association type.
+// https://developers.hubspot.com/docs/api-reference/crm-associations-v4/basic/put-crm-v4-objects-objectType-objectId-associations-default-toObjectType-toObjectId
+// https://developers.hubspot.com/docs/api-reference/crm-associations-v4/basic/put-crm-v4-objects-objectType-objectId-associations-toObjectType-toObjectId
+func (c *Connector) CreateAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.WriteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	var (
+		rsp *common.JSONHTTPResponse
+		err error
+	)
+
+	if descriptor.Type.IsZero() {
+		rsp, err = c.createDefaultAssociation(ctx, descriptor)
+	} else {
+		rsp, err = c.createLabeledAssociation(ctx, descriptor)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := common.UnmarshalJSON[map[string]any](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &common.WriteResult{Success: true}
+	if data != nil {
+		result.Data = *data
+	}
+
+	return result, nil
+}
+
+func (c *Connector) createDefaultAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.JSONHTTPResponse, error) {
+	url, err := urlbuilder.New(c.getRootProviderURL(), "crm/v4/objects",
+		descriptor.FromObject, descriptor.FromId, "associations/default", descriptor.ToObject, descriptor.ToId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The endpoint takes no body.
+	res, body, err := c.Client.HTTPClient.Send(ctx, http.MethodPut, url.String(), nil,
+		common.Header{Key: "Accept", Value: "application/json"})
+	if err != nil {
+		return nil, err
+	}
+
+	return common.ParseJSONResponse(res, body)
+}
+
+func (c *Connector) createLabeledAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.JSONHTTPResponse, error) {
+	spec, err := newAssocSpec(descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := c.getAssociationsURL(descriptor.FromObject, descriptor.FromId, descriptor.ToObject)
+	if err != nil {
+		return nil, err
+	}
+
+	url.AddPath(descriptor.ToId)
+
+	return c.Client.Put(ctx, url.String(), []assocSpec{spec})
+}
+
+// DeleteAssociation removes all associations between two records,
+// or only the association of the given type, keeping the others.
+// https://developers.hubspot.com/docs/api-reference/crm-associations-v4/basic/delete-crm-v4-objects-objectType-objectId-associations-toObjectType-toObjectId
+// https://developers.hubspot.com/docs/api-reference/crm-associations-v4/batch/post-crm-v4-associations-fromObjectType-toObjectType-batch-labels-archive
+func (c *Connector) DeleteAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.DeleteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	if descriptor.Type.IsZero() {
+		url, err := c.getAssociationsURL(descriptor.FromObject, descriptor.FromId, descriptor.ToObject)
+		if err != nil {
+			return nil, err
+		}
+
+		url.AddPath(descriptor.ToId)
+
+		if _, err = c.Client.Delete(ctx, url.String()); err != nil {
+			return nil, err
+		}
+
+		return &common.DeleteResult{Success: true}, nil
+	}
+
+	spec, err := newAssocSpec(descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := urlbuilder.New(c.getRootProviderURL(), "crm/v4/associations",
+		descriptor.FromObject, descriptor.ToObject, "batch/labels/archive")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.Client.Post(ctx, url.String(), assocArchiveInputs{
+		Inputs: []assocArchiveInput{{
+			Types: []assocSpec{spec},
+			From:  assocId{Id: descriptor.FromId},
+			To:    assocId{Id: descriptor.ToId},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DeleteResult{Success: true}, nil
+}
+
+func (c *Connector) getAssociationsURL(fromObject, fromID, toObject string) (*urlbuilder.URL, error) {
+	return urlbuilder.New(c.getRootProviderURL(), "crm/v4/objects", fromObject, fromID, "associations", toObject)
+}
+
+func newAssocSpec(typ common.AssociationType) (assocSpec, error) {
+	typeID, err := strconv.Atoi(typ.Id)
+	if err != nil {
+		return assocSpec{}, fmt.Errorf("%w: association type ID must be numeric, got %q", common.ErrCaller, typ.Id)
+	}
+
+	category := typ.Category
+	if category == "" {
+		category = associationCategoryDefault
+	}
+
+	return assocSpec{
+		AssociationCategory: category,
+		AssociationTypeId:   typeID,
+	}, nil
+}
+
+func (t *assocType) matches(typ common.AssociationType) bool {
+	if typ.Category != "" && typ.Category != t.Category {
+		return false
+	}
+
+	return typ.Id == "" || typ.Id == strconv.Itoa(t.TypeId)
+}
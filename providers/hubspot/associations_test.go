@@ -0,0 +1,226 @@
+package hubspot
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestListAssociations(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseList := testutils.DataFromFile(t, "associations/list.json")
+
+	tests := []testroutines.ListAssociations{
+		{
+			Name:         "Record must be identified",
+			Input:        common.ListAssociationsParams{FromObject: "contacts", ToObject: "companies"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingAssociationEnd},
+		},
+		{
+			Name: "Every association type is listed",
+			Input: common.ListAssociationsParams{
+				FromObject: "contacts",
+				FromId:     "51",
+				ToObject:   "companies",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodGET(),
+					mockcond.Path("/crm/v4/objects/contacts/51/associations/companies"),
+					mockcond.QueryParam("limit", "500"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseList),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{
+					ObjectId:        "9123456001",
+					AssociationType: "category=HUBSPOT_DEFINED id=1 label=Primary",
+					Raw:             map[string]any{"category": "HUBSPOT_DEFINED", "typeId": 1, "label": "Primary"},
+				}, {
+					ObjectId:        "9123456001",
+					AssociationType: "category=HUBSPOT_DEFINED id=279",
+					Raw:             map[string]any{"category": "HUBSPOT_DEFINED", "typeId": 279},
+				}, {
+					ObjectId:        "9123456002",
+					AssociationType: "category=USER_DEFINED id=14 label=Billing contact",
+					Raw:             map[string]any{"category": "USER_DEFINED", "typeId": 14, "label": "Billing contact"},
+				}},
+				NextPage: "MTAw",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Only associations of the requested type are listed",
+			Input: common.ListAssociationsParams{
+				FromObject: "contacts",
+				FromId:     "51",
+				ToObject:   "companies",
+				Type:       common.AssociationType{Id: "14", Category: "USER_DEFINED"},
+				NextPage:   "MTAw",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.QueryParam("after", "MTAw"),
+				Then:  mockserver.Response(http.StatusOK, responseList),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{
+					ObjectId:        "9123456002",
+					AssociationType: "category=USER_DEFINED id=14 label=Billing contact",
+					Raw:             map[string]any{"category": "USER_DEFINED", "typeId": 14, "label": "Billing contact"},
+				}},
+				NextPage: "MTAw",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestCreateAssociation(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []testroutines.CreateAssociation{
+		{
+			Name:         "Both records must be identified",
+			Input:        common.AssociationDescriptor{FromObject: "contacts", FromId: "51", ToObject: "companies"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingAssociationEnd},
+		},
+		{
+			Name: "Association type ID must be numeric",
+			Input: common.AssociationDescriptor{
+				FromObject: "contacts", FromId: "51", ToObject: "companies", ToId: "9123456001",
+				Type: common.AssociationType{Id: "primary"},
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrCaller},
+		},
+		{
+			Name: "Default association is created without body",
+			Input: common.AssociationDescriptor{
+				FromObject: "contacts", FromId: "51", ToObject: "companies", ToId: "9123456001",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPUT(),
+					mockcond.Path("/crm/v4/objects/contacts/51/associations/default/companies/9123456001"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"status":"COMPLETE","results":[]}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: true,
+				Data:    map[string]any{"status": "COMPLETE", "results": []any{}},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Labeled association defaults to HubSpot defined category",
+			Input: common.AssociationDescriptor{
+				FromObject: "contacts", FromId: "51", ToObject: "companies", ToId: "9123456001",
+				Type: common.AssociationType{Id: "1"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPUT(),
+					mockcond.Path("/crm/v4/objects/contacts/51/associations/companies/9123456001"),
+					mockcond.Body(`[{"associationCategory":"HUBSPOT_DEFINED","associationTypeId":1}]`),
+				},
+				Then: mockserver.ResponseString(http.StatusCreated, `{"fromObjectId":51,"toObjectId":9123456001}`),
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success: true,
+				Data:    map[string]any{"fromObjectId": float64(51), "toObjectId": float64(9123456001)},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDeleteAssociation(t *testing.T) {
+	t.Parallel()
+
+	tests := []testroutines.DeleteAssociation{
+		{
+			Name: "All associations between records are removed",
+			Input: common.AssociationDescriptor{
+				FromObject: "contacts", FromId: "51", ToObject: "companies", ToId: "9123456001",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodDELETE(),
+					mockcond.Path("/crm/v4/objects/contacts/51/associations/companies/9123456001"),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Association of the given type is archived",
+			Input: common.AssociationDescriptor{
+				FromObject: "contacts", FromId: "51", ToObject: "companies", ToId: "9123456001",
+				Type: common.AssociationType{Id: "14", Category: "USER_DEFINED"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.Path("/crm/v4/associations/contacts/companies/batch/labels/archive"),
+					mockcond.Body(`{"inputs":[{
+						"types":[{"associationCategory":"USER_DEFINED","associationTypeId":14}],
+						"from":{"id":"51"},"to":{"id":"9123456001"}}]}`),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
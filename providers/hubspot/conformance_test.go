@@ -0,0 +1,42 @@
+package hubspot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/conformance"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	recording, err := mockserver.LoadRecording(testutils.DataFromFile(t, "conformance/recording.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readContacts := common.ReadParams{
+		ObjectName: "contacts",
+		Fields:     connectors.Fields("email", "lastmodifieddate"),
+		Since:      time.Date(2024, 9, 19, 0, 0, 0, 0, time.UTC),
+	}
+
+	conformance.Suite[*Connector]{
+		Server: recording.Server(),
+		Reads: []conformance.Read{{
+			Input:          readContacts,
+			TimestampField: "properties.lastmodifieddate",
+		}},
+		Writes: []conformance.Write{{
+			Input: common.WriteParams{
+				ObjectName: "contacts",
+				RecordData: map[string]any{"email": "d@example.com"},
+			},
+		}},
+		Errors: conformance.ReadErrors[*Connector](readContacts),
+	}.Run(t, constructTestConnector)
+}
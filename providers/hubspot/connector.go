@@ -1,6 +1,8 @@
 package hubspot
 
 import (
+	"net/http"
+
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/paramsbuilder"
@@ -19,6 +21,8 @@ type Connector struct {
 	providerInfo *providers.ProviderInfo
 	moduleInfo   *providers.ModuleInfo
 	moduleID     common.ModuleID
+	// signedURLClient downloads files from signed URLs.
+	signedURLClient *http.Client
 
 	// CRM module sub-adapters
 	// These delegate specialized subsets of Hubspot CRM functionality to keep Connector modular and prevent code bloat.
@@ -47,7 +51,12 @@ func NewConnector(opts ...Option) (conn *Connector, outErr error) {
 		Client: &common.JSONHTTPClient{
 			HTTPClient: params.Client.Caller,
 		},
-		moduleID: params.Module.Selection.ID,
+		moduleID:        params.Module.Selection.ID,
+		signedURLClient: params.signedURLClient,
+	}
+
+	if conn.signedURLClient == nil {
+		conn.signedURLClient = newSignedURLClient()
 	}
 
 	conn.providerInfo, err = providers.ReadInfo(providers.Hubspot)
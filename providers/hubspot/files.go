@@ -0,0 +1,223 @@
+package hubspot
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+)
+
+// Files API stores files in the File Manager, it is not part of the CRM module.
+// https://developers.hubspot.com/docs/api-reference/files-files-v3/guide
+const (
+	filesAPIPath = "files/v3/files"
+
+	// defaultFileAccess keeps uploaded files out of public search and URLs.
+	defaultFileAccess = "PRIVATE"
+	// signedURLResponseTimeout limits waiting for the storage host to respond to a signed URL.
+	signedURLResponseTimeout = 30 * time.Second
+)
+
+var _ connectors.FileConnector = &Connector{}
+
+// UploadFile streams the file to the File Manager in one multipart request.
+// The Files API has no resumable or chunked upload, the only alternative is importing the file from a URL.
+// FileUploadParams.Folder is treated as a folder path when it starts with a slash, otherwise as a folder ID.
+// Files land in the root folder by default.
+// FileUploadParams.Attributes are passed as upload options, ex: "access", "overwrite".
+func (c *Connector) UploadFile(
+	ctx context.Context, params common.FileUploadParams,
+) (*common.FileUploadResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	fields, err := newFileUploadFields(params)
+	if err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := common.MultipartCodec{}.Reader(common.MultipartForm{
+		Fields: fields,
+		Files: []common.MultipartFile{{
+			FieldName:   "file",
+			FileName:    params.FileName,
+			ContentType: params.ContentType,
+			Content:     params.Content,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.HTTPClient.Stream(ctx, http.MethodPost, c.getFilesURL(), body,
+		common.Header{Key: "Content-Type", Value: contentType},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonResponse, err := common.ParseJSONResponse(rsp, common.GetResponseBodyOnce(rsp))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := common.UnmarshalJSON[fileResponse](jsonResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := common.UnmarshalJSON[map[string]any](jsonResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.FileUploadResult{
+		FileId:      file.ID,
+		FileName:    file.fileName(),
+		Size:        file.size(),
+		ContentType: file.contentType(params.ContentType),
+		Data:        *data,
+	}, nil
+}
+
+// DownloadFile resolves a short-lived signed URL for the file and streams its content.
+// The signed URL is fetched without HubSpot credentials, since it is already authorized.
+func (c *Connector) DownloadFile(
+	ctx context.Context, params common.FileDownloadParams,
+) (*common.FileDownloadResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Get(ctx, c.getFilesURL(params.FileId, "signed-url"))
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := common.UnmarshalJSON[fileResponse](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	download, err := c.signedURLHTTPClient().Stream(ctx, http.MethodGet, file.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	size := file.size()
+	if size == common.UnknownFileSize {
+		if length, err := strconv.ParseInt(download.Header.Get("Content-Length"), 10, 64); err == nil {
+			size = length
+		}
+	}
+
+	return &common.FileDownloadResult{
+		Content:     download.Body,
+		FileName:    file.fileName(),
+		Size:        size,
+		ContentType: file.contentType(download.Header.Get("Content-Type")),
+	}, nil
+}
+
+func (c *Connector) getFilesURL(paths ...string) string {
+	return strings.Join(append([]string{c.providerInfo.BaseURL, filesAPIPath}, paths...), "/")
+}
+
+func (c *Connector) signedURLHTTPClient() *common.HTTPClient {
+	return &common.HTTPClient{
+		Client:       c.signedURLClient,
+		ErrorHandler: common.InterpretError,
+	}
+}
+
+// newSignedURLClient creates the default client for signed URLs.
+// Only waiting for the response is limited, the download itself may take as long as the file needs.
+func newSignedURLClient() *http.Client {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return &http.Client{Timeout: signedURLResponseTimeout}
+	}
+
+	transport = transport.Clone()
+	transport.ResponseHeaderTimeout = signedURLResponseTimeout
+
+	return &http.Client{Transport: transport}
+}
+
+func newFileUploadFields(params common.FileUploadParams) (map[string]string, error) {
+	options := map[string]any{
+		"access": defaultFileAccess,
+	}
+
+	for key, value := range params.Attributes {
+		options[key] = value
+	}
+
+	encodedOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		"fileName": params.FileName,
+		"options":  string(encodedOptions),
+	}
+
+	switch {
+	case params.Folder == "":
+		fields["folderPath"] = "/"
+	case strings.HasPrefix(params.Folder, "/"):
+		fields["folderPath"] = params.Folder
+	default:
+		fields["folderId"] = params.Folder
+	}
+
+	return fields, nil
+}
+
+// fileResponse is shared by the upload and signed URL responses.
+type fileResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Extension string   `json:"extension"`
+	Size      *float64 `json:"size"`
+	URL       string   `json:"url"`
+}
+
+// fileName restores the extension which HubSpot keeps separately from the name.
+func (f fileResponse) fileName() string {
+	if f.Extension == "" || strings.HasSuffix(f.Name, "."+f.Extension) {
+		return f.Name
+	}
+
+	return f.Name + "." + f.Extension
+}
+
+func (f fileResponse) size() int64 {
+	if f.Size == nil {
+		return common.UnknownFileSize
+	}
+
+	return int64(*f.Size)
+}
+
+// contentType returns the reported type, falling back to the one implied by the extension.
+// HubSpot reports only a broad category, ex: "IMG", rather than the MIME type.
+func (f fileResponse) contentType(reported string) string {
+	if reported != "" {
+		return reported
+	}
+
+	if f.Extension != "" {
+		return mime.TypeByExtension("." + f.Extension)
+	}
+
+	return ""
+}
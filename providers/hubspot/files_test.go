@@ -0,0 +1,100 @@
+package hubspot
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+)
+
+func TestUploadFile(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Conditional{
+		Setup: mockserver.ContentJSON(),
+		If: mockcond.And{
+			mockcond.MethodPOST(),
+			mockcond.Path("/files/v3/files"),
+			mockcond.MultipartPart("fileName", "notes.txt"),
+			mockcond.MultipartPart("folderPath", "/reports"),
+			mockcond.MultipartPart("options", `{"access":"PUBLIC_NOT_INDEXABLE"}`),
+			mockcond.MultipartPart("file", "hello"),
+		},
+		Then: mockserver.ResponseString(http.StatusCreated,
+			`{"id":"182798235", "name":"notes", "extension":"txt", "size":5, "type":"DOCUMENT"}`),
+	}.Server()
+	defer server.Close()
+
+	conn, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct test connector: %v", err)
+	}
+
+	output, err := conn.UploadFile(t.Context(), common.FileUploadParams{
+		FileName:    "notes.txt",
+		ContentType: "text/plain",
+		Content:     strings.NewReader("hello"),
+		Folder:      "/reports",
+		Attributes:  map[string]any{"access": "PUBLIC_NOT_INDEXABLE"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.FileId != "182798235" || output.FileName != "notes.txt" ||
+		output.Size != 5 || output.ContentType != "text/plain" {
+		t.Fatalf("unexpected upload result: %+v", output)
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	t.Parallel()
+
+	var serverURL string
+
+	server := mockserver.Switch{
+		Cases: []mockserver.Case{{
+			If: mockcond.Path("/files/v3/files/182798235/signed-url"),
+			Then: func(w http.ResponseWriter, r *http.Request) {
+				mockserver.ContentJSON()(w, r)
+				mockserver.ResponseString(http.StatusOK, `{"url":"`+serverURL+`/signed/notes.txt",
+					"name":"notes", "extension":"txt", "size":5, "type":"DOCUMENT"}`)(w, r)
+			},
+		}, {
+			If: mockcond.Path("/signed/notes.txt"),
+			Then: mockserver.ResponseChainedFuncs(
+				mockserver.ContentText(),
+				mockserver.ResponseString(http.StatusOK, "hello"),
+			),
+		}},
+	}.Server()
+	defer server.Close()
+
+	serverURL = server.URL
+
+	conn, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct test connector: %v", err)
+	}
+
+	output, err := conn.DownloadFile(t.Context(), common.FileDownloadParams{FileId: "182798235"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer output.Content.Close()
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		t.Fatalf("failed to read content: %v", err)
+	}
+
+	if string(content) != "hello" || output.FileName != "notes.txt" || output.Size != 5 ||
+		!strings.HasPrefix(output.ContentType, "text/plain") {
+		t.Fatalf("unexpected download: %q %+v", string(content), output)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/logging"
+	"github.com/amp-labs/connectors/common/metadatadiff"
 	"github.com/amp-labs/connectors/internal/datautils"
 	"github.com/amp-labs/connectors/internal/goutils"
 	"github.com/amp-labs/connectors/internal/simultaneously"
@@ -30,8 +31,23 @@ type objectMetadataError struct {
 func (c *Connector) UpsertMetadata(
 	ctx context.Context, params *common.UpsertMetadataParams,
 ) (*common.UpsertMetadataResult, error) {
-	// Delegated.
-	return c.customAdapter.UpsertMetadata(ctx, params)
+	if params == nil || !params.DryRun {
+		// Delegated.
+		return c.customAdapter.UpsertMetadata(ctx, params)
+	}
+
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	// The plan is computed against current object metadata.
+	// Property groups that a real run would create are not part of the plan.
+	current, err := c.ListObjectMetadata(ctx, datautils.FromMap(params.Fields).Keys())
+	if err != nil {
+		return nil, err
+	}
+
+	return metadatadiff.PlanUpsert(params, current)
 }
 
 // ListObjectMetadata returns object metadata for each object name provided.
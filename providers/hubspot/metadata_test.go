@@ -6,6 +6,7 @@ import (
 
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/metadatadiff"
 	"github.com/amp-labs/connectors/internal/goutils"
 	"github.com/amp-labs/connectors/providers"
 	"github.com/amp-labs/connectors/test/utils/mockutils"
@@ -385,6 +386,94 @@ func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop,mai
 	}
 }
 
+func TestUpsertMetadataDryRun(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	metadataContactsProperties := testutils.DataFromFile(t, "metadata-contacts-properties-sampled.json")
+
+	tests := []testroutines.UpsertMetadata{
+		{
+			Name: "Plan is computed from current properties without writing",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"contacts": {{
+						FieldName:   "address",
+						DisplayName: "Street Address",
+						ValueType:   common.FieldTypeString,
+					}, {
+						FieldName:   "mobilephone",
+						DisplayName: "Mobile Phone",
+						ValueType:   common.FieldTypeString,
+						Required:    true,
+					}, {
+						FieldName:   "favorite_color",
+						DisplayName: "Favorite Color",
+						ValueType:   common.FieldTypeString,
+					}},
+				},
+				DryRun: true,
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/crm/v3/properties/contacts"),
+					},
+					Then: mockserver.Response(http.StatusOK, metadataContactsProperties),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/crm/v3/pipelines/contacts"),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, "{}"),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/crm-object-schemas/v3/schemas/contacts"),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"requiredProperties": ["mobilephone"]}`),
+				}},
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				DryRun:  true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"contacts": {
+						"address": {
+							FieldName: "address",
+							Action:    common.UpsertMetadataActionNone,
+						},
+						"mobilephone": {
+							FieldName: "mobilephone",
+							Action:    common.UpsertMetadataActionUpdate,
+							Metadata: map[string]any{
+								"changes": []metadatadiff.ChangeKind{metadatadiff.ChangeDisplayName},
+							},
+						},
+						"favorite_color": {
+							FieldName: "favorite_color",
+							Action:    common.UpsertMetadataActionCreate,
+						},
+					},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.UpsertMetadataConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
 func constructTestConnector(serverURL string) (*Connector, error) {
 	connector, err := NewConnector(
 		WithAuthenticatedClient(mockutils.NewClient()),
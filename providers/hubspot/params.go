@@ -23,6 +23,9 @@ type Option = func(params *parameters)
 type parameters struct {
 	paramsbuilder.Client
 	paramsbuilder.Module
+
+	// signedURLClient downloads files from signed URLs, which must not receive the OAuth token.
+	signedURLClient *http.Client
 }
 
 func newParams(opts []Option) (*common.ConnectorParams, error) { // nolint:unused
@@ -62,6 +65,14 @@ func WithAuthenticatedClient(client common.AuthenticatedHTTPClient) Option {
 	}
 }
 
+// WithSignedURLClient sets the http client downloading files from the signed URLs returned by HubSpot.
+// It is unauthenticated, as signed URLs carry their own credentials. Its usage is optional.
+func WithSignedURLClient(client *http.Client) Option {
+	return func(params *parameters) {
+		params.signedURLClient = client
+	}
+}
+
 // WithModule sets the hubspot API module to use for the connector. It's required.
 func WithModule(module common.ModuleID) Option {
 	return func(params *parameters) {
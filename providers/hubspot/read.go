@@ -9,9 +9,9 @@ import (
 )
 
 // Read reads data from Hubspot. If Since is set, it will use the
-// Search endpoint instead to filter records, but it will be
-// limited to a maximum of 10,000 records. This is a limit of the
-// search endpoint. If Since is not set, it will use the read endpoint.
+// Search endpoint instead to filter records. The search endpoint is
+// limited to 10,000 records per query, larger results are read in windows
+// ordered by record id. If Since is not set, it will use the read endpoint.
 // In case Deleted objects won’t appear in any search results.
 // Deleted objects can only be read by using this endpoint.
 func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common.ReadResult, error) { //nolint:funlen
@@ -34,9 +34,8 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 	}
 
 	// If filtering is required, then we have to use the search endpoint.
-	// The Search endpoint has a 10K record limit. In case this limit is reached,
-	// the sorting allows the caller to continue in another call by offsetting
-	// until the ID of the last record that was successfully fetched.
+	// The Search endpoint has a 10K record limit. Sorting by ID allows Search
+	// to continue past this limit, offsetting from the last fetched record.
 	filters := make(Filters, 0)
 	if !config.Since.IsZero() {
 		filters = append(filters, BuildLastModifiedFilterGroup(&config))
@@ -10,8 +10,9 @@ import (
 )
 
 // Search uses the POST /search endpoint to filter object records and return the result.
-// This endpoint has a limit of 10,000 records. If the result has more than 10,000 records,
-// the caller should employ sorting to paginate through the result on the client side.
+// This endpoint has a limit of 10,000 records. When the search is sorted in ascending order
+// by hs_object_id, hs_lastmodifieddate or lastmodifieddate, results past the limit are read
+// in consecutive windows, otherwise the result is truncated at 10,000 records.
 // This endpoint paginates using paging.next.after which is to be used as an offset.
 // Archived results do not appear in search results.
 // Read more @ https://developers.hubspot.com/docs/api/crm/search
@@ -30,6 +31,25 @@ func (c *Connector) Search(ctx context.Context, config SearchParams) (*common.Re
 		})
 	}
 
+	key, windowed := searchWindowKeyOf(config.SortBy)
+	if !windowed {
+		return c.searchObjects(ctx, config)
+	}
+
+	cursor, err := decodeSearchCursor(config.NextPage)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.searchObjects(ctx, config.withWindow(key, cursor))
+	if err != nil {
+		return nil, err
+	}
+
+	return advanceSearchWindow(result, key, cursor)
+}
+
+func (c *Connector) searchObjects(ctx context.Context, config SearchParams) (*common.ReadResult, error) {
 	url, err := c.getCRMObjectsSearchURL(config)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,235 @@
+package hubspot
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// The search endpoint returns at most 10,000 records for any query, paging beyond that fails.
+// Searches sorted in ascending order by the object id or by the last modified date are split into windows.
+// Once a window is exhausted, the search restarts with a filter on the sort property
+// which excludes records of previous windows. The caller sees one continuous stream of pages.
+//
+// Last modified date is not unique, so the next window is bounded inclusively by the last date.
+// Records of that date which were already returned are remembered in the page token and skipped.
+// Read more @ https://developers.hubspot.com/docs/api/crm/search#limitations
+
+const (
+	searchResultsCeiling = 10000
+	searchWindowPrefix   = "window:"
+)
+
+var ErrSearchWindowStalled = errors.New("search window cannot advance, too many records share the same value")
+
+// searchWindowKey is the sort property used to bound windows.
+type searchWindowKey struct {
+	property string
+	// inclusive is set for properties whose values are not unique.
+	inclusive bool
+}
+
+// searchWindowKeyOf returns the key for windowing, if the sort order allows it.
+// HubSpot applies a single sorting rule per search.
+func searchWindowKeyOf(sorts []SortBy) (*searchWindowKey, bool) {
+	if len(sorts) != 1 || sorts[0].Direction != SortDirectionAsc {
+		return nil, false
+	}
+
+	switch ObjectField(sorts[0].PropertyName) {
+	case ObjectFieldHsObjectId:
+		return &searchWindowKey{property: sorts[0].PropertyName}, true
+	case ObjectFieldHsLastModifiedDate, ObjectFieldLastModifiedDate:
+		return &searchWindowKey{property: sorts[0].PropertyName, inclusive: true}, true
+	default:
+		return nil, false
+	}
+}
+
+// value returns the key of the record, the object id is stored outside of properties.
+func (k searchWindowKey) value(row common.ReadResultRow) string {
+	if ObjectField(k.property) == ObjectFieldHsObjectId {
+		return row.Id
+	}
+
+	properties, _ := row.Raw["properties"].(map[string]any)
+	value, _ := properties[k.property].(string)
+
+	return value
+}
+
+func (k searchWindowKey) filter(cursor searchCursor) Filter {
+	operator := FilterOperatorTypeGT
+	if k.inclusive {
+		operator = FilterOperatorTypeGTE
+	}
+
+	return Filter{
+		FieldName: k.property,
+		Operator:  operator,
+		Value:     cursor.Bound,
+	}
+}
+
+// searchCursor is the position within windowed search.
+// The first window is described by the "after" offset alone, which keeps tokens of unbounded searches unchanged.
+type searchCursor struct {
+	// After is the offset within the current window.
+	After string `json:"after,omitempty"`
+	// Bound is the lower bound of the current window.
+	Bound string `json:"bound,omitempty"`
+	// Seen lists records at the inclusive bound that were returned by the previous window.
+	Seen []string `json:"seen,omitempty"`
+	// Tail is the greatest key returned so far, TailIDs are records having it.
+	// Only tracked for inclusive keys, for unique keys the last record is enough.
+	Tail    string   `json:"tail,omitempty"`
+	TailIDs []string `json:"tailIds,omitempty"`
+}
+
+func decodeSearchCursor(token common.NextPageToken) (searchCursor, error) {
+	text := token.String()
+	if !strings.HasPrefix(text, searchWindowPrefix) {
+		return searchCursor{After: text}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(text, searchWindowPrefix))
+	if err != nil {
+		return searchCursor{}, fmt.Errorf("%w: %w", common.ErrNextPageInvalid, err)
+	}
+
+	var cursor searchCursor
+	if err = json.Unmarshal(data, &cursor); err != nil {
+		return searchCursor{}, fmt.Errorf("%w: %w", common.ErrNextPageInvalid, err)
+	}
+
+	return cursor, nil
+}
+
+func (c searchCursor) encode() (common.NextPageToken, error) {
+	if c.Bound == "" && len(c.Seen) == 0 && c.Tail == "" {
+		return common.NextPageToken(c.After), nil
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return common.NextPageToken(searchWindowPrefix + base64.RawURLEncoding.EncodeToString(data)), nil
+}
+
+// withWindow restricts the search to the window of the cursor.
+// The bound is added to every filter group, since groups are joined with OR.
+func (p SearchParams) withWindow(key *searchWindowKey, cursor searchCursor) SearchParams {
+	p.NextPage = common.NextPageToken(cursor.After)
+
+	if key.inclusive && p.Fields != nil && !p.Fields.Has(key.property) {
+		// Dates of records are needed to move the window.
+		p.Fields = datautils.NewSetFromList(append(p.Fields.List(), key.property))
+	}
+
+	if cursor.Bound == "" {
+		return p
+	}
+
+	bound := key.filter(cursor)
+
+	if len(p.FilterGroups) == 0 {
+		p.FilterGroups = []FilterGroup{{Filters: Filters{bound}}}
+
+		return p
+	}
+
+	groups := make([]FilterGroup, len(p.FilterGroups))
+	for index, group := range p.FilterGroups {
+		groups[index] = FilterGroup{Filters: append(slices.Clone(group.Filters), bound)}
+	}
+
+	p.FilterGroups = groups
+
+	return p
+}
+
+// advanceSearchWindow removes records returned by the previous window and replaces the next page token.
+// When the next page would cross the ceiling, the token starts a new window after the last record.
+func advanceSearchWindow(
+	result *common.ReadResult, key *searchWindowKey, cursor searchCursor,
+) (*common.ReadResult, error) {
+	next := searchCursor{
+		Bound: cursor.Bound,
+		Seen:  cursor.Seen,
+	}
+
+	if key.inclusive {
+		next.Tail, next.TailIDs = cursor.Tail, cursor.TailIDs
+	}
+
+	rows := make([]common.ReadResultRow, 0, len(result.Data))
+
+	for _, row := range result.Data {
+		value := key.value(row)
+
+		// Records seen at the bound are already part of the tail, when the tail is still at the bound.
+		if value == cursor.Bound && slices.Contains(cursor.Seen, row.Id) {
+			continue
+		}
+
+		if key.inclusive {
+			if value != next.Tail {
+				next.Tail, next.TailIDs = value, nil
+			}
+
+			next.TailIDs = append(next.TailIDs, row.Id)
+		}
+
+		rows = append(rows, row)
+	}
+
+	last := ""
+	if len(result.Data) != 0 {
+		last = key.value(result.Data[len(result.Data)-1])
+	}
+
+	result.Data = rows
+	result.Rows = int64(len(rows))
+
+	if result.Done {
+		return result, nil
+	}
+
+	after, err := strconv.Atoi(result.NextPage.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrNextPageInvalid, err)
+	}
+
+	if after+DefaultPageSizeInt <= searchResultsCeiling {
+		next.After = result.NextPage.String()
+	} else {
+		// Start the next window.
+		if key.inclusive && last == cursor.Bound {
+			return nil, fmt.Errorf("%w: %v", ErrSearchWindowStalled, key.property)
+		}
+
+		tail, tailIDs := next.Tail, next.TailIDs
+		next = searchCursor{Bound: last}
+
+		if key.inclusive {
+			next.Seen = slices.Clone(tailIDs)
+			next.Tail, next.TailIDs = tail, tailIDs
+		}
+	}
+
+	result.NextPage, err = next.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
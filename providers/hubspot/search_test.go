@@ -0,0 +1,232 @@
+package hubspot
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+)
+
+func TestSearchWindowsByObjectId(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Switch{
+		Setup: mockserver.ContentJSON(),
+		Cases: mockserver.Cases{{
+			// Second window starts after the last record of the first one, offset is reset.
+			If: mockcond.And{
+				mockcond.Path("/crm/v3/objects/contacts/search"),
+				mockcond.Body(`{
+					"filterGroups": [{"filters": [
+						{"propertyName": "lastmodifieddate", "operator": "GTE", "value": "2024-09-19T04:30:45Z"},
+						{"propertyName": "hs_object_id", "operator": "GT", "value": "2"}
+					]}],
+					"limit": "100",
+					"properties": ["email"],
+					"sorts": [{"propertyName": "hs_object_id", "direction": "ASCENDING"}]
+				}`),
+			},
+			Then: mockserver.ResponseString(http.StatusOK, `{"results": [
+				{"id": "3", "properties": {"email": "c@example.com"}}
+			]}`),
+		}, {
+			If: mockcond.And{
+				mockcond.Path("/crm/v3/objects/contacts/search"),
+				mockcond.Body(`{
+					"filterGroups": [{"filters": [
+						{"propertyName": "lastmodifieddate", "operator": "GTE", "value": "2024-09-19T04:30:45Z"}
+					]}],
+					"limit": "100",
+					"properties": ["email"],
+					"sorts": [{"propertyName": "hs_object_id", "direction": "ASCENDING"}],
+					"after": "9900"
+				}`),
+			},
+			Then: mockserver.ResponseString(http.StatusOK, `{
+				"results": [
+					{"id": "1", "properties": {"email": "a@example.com"}},
+					{"id": "2", "properties": {"email": "b@example.com"}}
+				],
+				"paging": {"next": {"after": "9950"}}
+			}`),
+		}},
+	}.Server()
+	defer server.Close()
+
+	connector, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	// Page that is close to the ceiling.
+	params := common.ReadParams{
+		ObjectName: "contacts",
+		Fields:     connectors.Fields("email"),
+		Since:      time.Date(2024, 9, 19, 4, 30, 45, 0, time.UTC),
+		NextPage:   "9900",
+	}
+
+	identifiers := readAllIdentifiers(t, func(token common.NextPageToken) (*common.ReadResult, error) {
+		params.NextPage = token
+
+		return connector.Read(t.Context(), params)
+	}, params.NextPage)
+
+	expectIdentifiers(t, identifiers, []string{"1", "2", "3"})
+}
+
+func TestSearchWindowsByLastModifiedDate(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Switch{
+		Setup: mockserver.ContentJSON(),
+		Cases: mockserver.Cases{{
+			// Records sharing the bound date were already returned, they must be skipped.
+			If: mockcond.And{
+				mockcond.Path("/crm/v3/objects/deals/search"),
+				mockcond.BodyContains(`{"propertyName":"hs_lastmodifieddate","operator":"GTE",` +
+					`"value":"2024-01-02T00:00:00Z"}`),
+			},
+			Then: mockserver.ResponseString(http.StatusOK, `{"results": [
+				{"id": "20", "properties": {"hs_lastmodifieddate": "2024-01-02T00:00:00Z"}},
+				{"id": "30", "properties": {"hs_lastmodifieddate": "2024-01-02T00:00:00Z"}},
+				{"id": "5", "properties": {"hs_lastmodifieddate": "2024-01-03T00:00:00Z"}}
+			]}`),
+		}, {
+			If: mockcond.And{
+				mockcond.Path("/crm/v3/objects/deals/search"),
+				mockcond.BodyContains(`"properties":[`),
+				mockcond.BodyContains(`"hs_lastmodifieddate"`),
+				mockcond.BodyContains(`"after":"9950"`),
+			},
+			Then: mockserver.ResponseString(http.StatusOK, `{
+				"results": [
+					{"id": "40", "properties": {"hs_lastmodifieddate": "2024-01-01T00:00:00Z"}},
+					{"id": "20", "properties": {"hs_lastmodifieddate": "2024-01-02T00:00:00Z"}},
+					{"id": "30", "properties": {"hs_lastmodifieddate": "2024-01-02T00:00:00Z"}}
+				],
+				"paging": {"next": {"after": "10000"}}
+			}`),
+		}},
+	}.Server()
+	defer server.Close()
+
+	connector, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	params := SearchParams{
+		ObjectName: "deals",
+		Fields:     connectors.Fields("dealname"),
+		SortBy:     []SortBy{BuildSort(ObjectFieldHsLastModifiedDate, SortDirectionAsc)},
+	}
+
+	identifiers := readAllIdentifiers(t, func(token common.NextPageToken) (*common.ReadResult, error) {
+		params.NextPage = token
+
+		return connector.Search(t.Context(), params)
+	}, "9950")
+
+	expectIdentifiers(t, identifiers, []string{"40", "20", "30", "5"})
+}
+
+func TestSearchWindowStalls(t *testing.T) {
+	t.Parallel()
+
+	key := &searchWindowKey{property: string(ObjectFieldHsLastModifiedDate), inclusive: true}
+	result := &common.ReadResult{
+		Rows: 1,
+		Data: []common.ReadResultRow{{
+			Id:  "1",
+			Raw: map[string]any{"properties": map[string]any{"hs_lastmodifieddate": "2024-01-02T00:00:00Z"}},
+		}},
+		NextPage: "9950",
+	}
+
+	_, err := advanceSearchWindow(result, key, searchCursor{Bound: "2024-01-02T00:00:00Z"})
+	if err == nil {
+		t.Fatal("expected error when the whole window shares the same date")
+	}
+}
+
+func TestSearchWindowTailHasNoDuplicates(t *testing.T) {
+	t.Parallel()
+
+	const date = "2024-01-02T00:00:00Z"
+
+	key := &searchWindowKey{property: string(ObjectFieldHsLastModifiedDate), inclusive: true}
+	row := func(id string) common.ReadResultRow {
+		return common.ReadResultRow{
+			Id:  id,
+			Raw: map[string]any{"properties": map[string]any{"hs_lastmodifieddate": date}},
+		}
+	}
+	// The window restarted at the date, records 20 and 30 are returned again.
+	cursor := searchCursor{Bound: date, Seen: []string{"20", "30"}, Tail: date, TailIDs: []string{"20", "30"}}
+	result := &common.ReadResult{
+		Rows:     3,
+		Data:     []common.ReadResultRow{row("20"), row("30"), row("31")},
+		NextPage: "100",
+	}
+
+	result, err := advanceSearchWindow(result, key, cursor)
+	if err != nil {
+		t.Fatalf("failed to advance window: %v", err)
+	}
+
+	next, err := decodeSearchCursor(result.NextPage)
+	if err != nil {
+		t.Fatalf("failed to decode cursor: %v", err)
+	}
+
+	expectIdentifiers(t, next.TailIDs, []string{"20", "30", "31"})
+	expectIdentifiers(t, next.Seen, []string{"20", "30"})
+}
+
+func readAllIdentifiers(
+	t *testing.T, read func(token common.NextPageToken) (*common.ReadResult, error), token common.NextPageToken,
+) []string {
+	t.Helper()
+
+	var identifiers []string
+
+	for range 5 {
+		result, err := read(token)
+		if err != nil {
+			t.Fatalf("failed to read: %v", err)
+		}
+
+		for _, row := range result.Data {
+			identifiers = append(identifiers, row.Id)
+		}
+
+		if result.Done {
+			return identifiers
+		}
+
+		token = result.NextPage
+	}
+
+	t.Fatal("too many pages")
+
+	return nil
+}
+
+func expectIdentifiers(t *testing.T, actual, expected []string) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected records %v, got %v", expected, actual)
+	}
+
+	for index := range expected {
+		if actual[index] != expected[index] {
+			t.Fatalf("expected records %v, got %v", expected, actual)
+		}
+	}
+}
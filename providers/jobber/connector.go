@@ -5,12 +5,14 @@ import (
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/interpreter"
+	"github.com/amp-labs/connectors/common/naming"
 	"github.com/amp-labs/connectors/internal/components"
 	"github.com/amp-labs/connectors/internal/components/deleter"
 	"github.com/amp-labs/connectors/internal/components/operations"
 	"github.com/amp-labs/connectors/internal/components/reader"
 	"github.com/amp-labs/connectors/internal/components/schema"
 	"github.com/amp-labs/connectors/internal/components/writer"
+	"github.com/amp-labs/connectors/internal/graphql"
 	"github.com/amp-labs/connectors/providers"
 )
 
@@ -26,6 +28,9 @@ type Connector struct {
 	components.Reader
 	components.Writer
 	components.Deleter
+
+	// introspector caches the GraphQL schema used to build reads and metadata.
+	introspector *graphql.Introspector
 }
 
 func NewConnector(params common.ConnectorParams) (*Connector, error) {
@@ -34,16 +39,18 @@ func NewConnector(params common.ConnectorParams) (*Connector, error) {
 }
 
 func constructor(base *components.Connector) (*Connector, error) {
-	connector := &Connector{Connector: base}
+	connector := &Connector{
+		Connector: base,
+		introspector: graphql.NewIntrospector(base.JSONHTTPClient(), common.Header{
+			Key:   "X-Jobber-Graphql-Version",
+			Value: apiVersion,
+		}),
+	}
 
 	// Set the metadata provider for the connector
-	connector.SchemaProvider = schema.NewObjectSchemaProvider(
-		connector.HTTPClient().Client,
-		schema.FetchModeParallel,
-		operations.SingleObjectMetadataHandlers{
-			BuildRequest:  connector.buildSingleObjectMetadataRequest,
-			ParseResponse: connector.parseSingleObjectMetadataResponse,
-		},
+	connector.SchemaProvider = schema.NewGraphQLSchemaProvider(
+		connector.graphQLSchema,
+		naming.CapitalizeFirstLetter,
 	)
 
 	registry, err := components.NewEndpointRegistry(supportedOperations())
@@ -7,10 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/amp-labs/connectors/common"
-	"github.com/amp-labs/connectors/common/naming"
 	"github.com/amp-labs/connectors/common/urlbuilder"
 	"github.com/amp-labs/connectors/internal/graphql"
 	"github.com/amp-labs/connectors/internal/jsonquery"
@@ -19,142 +17,41 @@ import (
 //go:embed graphql/*.graphql
 var queryFiles embed.FS
 
-func (c *Connector) buildSingleObjectMetadataRequest(ctx context.Context, objectName string) (*http.Request, error) {
-	url, err := urlbuilder.New(c.ProviderInfo().BaseURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build URL: %w", err)
-	}
-
-	// Use introspection query to get field information
-	query := fmt.Sprintf(`{
-		__type(name: "%s") {
-			name
-			fields {
-				name
-				type {
-					name
-					kind
-					ofType {
-					  name
-					  kind
-					}
-				}
-			}
-		}
-	}`, objectNameMapping.Get(objectName))
-
-	// Create the request body as a map
-	requestBody := map[string]string{
-		"query": query,
-	}
+// graphQLSchema returns the introspected schema of the pinned API version, fetched once per connector.
+func (c *Connector) graphQLSchema(ctx context.Context) (*graphql.Schema, error) {
+	return c.introspector.Schema(ctx, c.ProviderInfo().BaseURL)
+}
 
-	// Marshal the request body to JSON
-	jsonBody, err := json.Marshal(requestBody)
+func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
+	url, err := urlbuilder.New(c.ProviderInfo().BaseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+	schema, err := c.graphQLSchema(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("X-Jobber-Graphql-Version", apiVersion)
-
-	return req, nil
-}
-
-func (c *Connector) parseSingleObjectMetadataResponse(
-	ctx context.Context,
-	objectName string,
-	request *http.Request,
-	response *common.JSONHTTPResponse,
-) (*common.ObjectMetadata, error) {
-	objectMetadata := common.ObjectMetadata{
-		Fields:      make(map[string]common.FieldMetadata),
-		DisplayName: naming.CapitalizeFirstLetter(objectName),
-	}
-
-	metadataResp, err := common.UnmarshalJSON[MetadataResponse](response)
-	if err != nil {
-		return nil, common.ErrFailedToUnmarshalBody
-	}
-
-	if len(metadataResp.Data.Type.Fields) == 0 {
-		return nil, fmt.Errorf(
-			"missing or empty fields for object: %s, error: %w",
-			objectName,
-			common.ErrMissingExpectedValues,
-		)
-	}
-
-	// Process each field from the introspection result
-	for _, field := range metadataResp.Data.Type.Fields {
-		valueType := field.Type.Name
-
-		if valueType == "" {
-			valueType = field.Type.OfType.Name
-		}
-
-		objectMetadata.Fields[field.Name] = common.FieldMetadata{
-			DisplayName:  field.Name,
-			ValueType:    getFieldValueType(valueType),
-			ProviderType: valueType,
-			Values:       nil,
-		}
-	}
-
-	return &objectMetadata, nil
-}
-
-func getFieldValueType(field string) common.ValueType {
-	if field == "" {
-		return ""
+	variables := map[string]any{
+		"first": defaultPageSize,
 	}
 
-	switch strings.ToLower(field) {
-	case "float":
-		return common.ValueTypeFloat
-	case "string", "id":
-		return common.ValueTypeString
-	case "boolean":
-		return common.ValueTypeBoolean
-	default:
-		return common.ValueTypeOther
+	if params.NextPage != "" {
+		variables["after"] = params.NextPage.String()
 	}
-}
 
-func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
-	url, err := urlbuilder.New(c.ProviderInfo().BaseURL)
+	// Records keep the fields they were always read with, requested fields extend the selection.
+	fields, err := graphql.ReadFields(queryFiles, "graphql/query_"+params.ObjectName+".graphql")
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		after string
-		first int
-	)
-
-	if params.NextPage != "" {
-		after = params.NextPage.String()
-	}
-
-	first = defaultPageSize
-
-	pagination := graphql.PaginationParameter{
-		First: first,
-		After: after,
-	}
-
-	query, err := graphql.Operation(queryFiles, "query", params.ObjectName, pagination)
+	requestBody, err := schema.Read(params.ObjectName, append(fields, params.Fields.List()...), variables)
 	if err != nil {
 		return nil, err
 	}
 
-	requestBody := map[string]string{
-		"query": query,
-	}
-
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
 		return nil, err
@@ -178,8 +75,8 @@ func (c *Connector) parseReadResponse(
 ) (*common.ReadResult, error) {
 	return common.ParseResult(
 		resp,
-		common.ExtractOptionalRecordsFromPath("nodes", "data", params.ObjectName),
-		makeNextRecordsURL(params.ObjectName),
+		graphql.Records(params.ObjectName),
+		graphql.NextPage(params.ObjectName),
 		common.GetMarshaledData,
 		params.Fields,
 	)
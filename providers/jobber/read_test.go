@@ -0,0 +1,133 @@
+package jobber
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop
+	t.Parallel()
+
+	responseSchema := testutils.DataFromFile(t, "schema.json")
+	responseClients := testutils.DataFromFile(t, "clients/read.json")
+
+	// Every read introspects the schema first, the schema is cached per connector.
+	introspection := mockserver.Case{
+		If:   mockcond.BodyContains("__schema"),
+		Then: mockserver.Response(http.StatusOK, responseSchema),
+	}
+
+	tests := []testroutines.Read{
+		{
+			Name:         "Read object must be included",
+			Input:        common.ReadParams{},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Unknown object is not supported",
+			Input:        common.ReadParams{ObjectName: "account", Fields: connectors.Fields("name")},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name:  "Requested fields extend the fields clients are always read with",
+			Input: common.ReadParams{ObjectName: "clients", Fields: connectors.Fields("name", "tags")},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.Path("/api/graphql"),
+						mockcond.Header(http.Header{"X-Jobber-Graphql-Version": []string{apiVersion}}),
+						mockcond.BodyContains(`query Read($first: Int) { clients(first: $first) { nodes { balance ` +
+							`billingAddress { city country latitude longitude name postalCode province street ` +
+							`street1 street2 } billingAddressPresent companyName`),
+						mockcond.BodyContains(`secondaryName tags title updatedAt } ` +
+							`pageInfo { hasNextPage endCursor } } }`),
+						mockcond.BodyContains(`"variables":{"first":50}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseClients),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"name": "Ada Lovelace"},
+					Raw: map[string]any{
+						"id": "Z2lkOi8vSm9iYmVyL0NsaWVudC8xMDE=",
+						"billingAddress": map[string]any{
+							"city":    "London",
+							"country": "United Kingdom",
+							"street":  "12 St James's Square",
+						},
+					},
+				}, {
+					Fields: map[string]any{"name": "Northwind Traders"},
+					Raw: map[string]any{
+						"id":          "Z2lkOi8vSm9iYmVyL0NsaWVudC8xMDI=",
+						"companyName": "Northwind Traders",
+					},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Next page continues after the cursor",
+			Input: common.ReadParams{
+				ObjectName: "clients",
+				Fields:     connectors.Fields("name"),
+				NextPage:   "MQ",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.BodyContains(`query Read($after: String, $first: Int) ` +
+							`{ clients(after: $after, first: $first) { nodes {`),
+						mockcond.BodyContains(`"variables":{"after":"MQ","first":50}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseClients),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected:   &common.ReadResult{Rows: 2, NextPage: "", Done: true},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func constructTestConnector(serverURL string) (*Connector, error) {
+	connector, err := NewConnector(
+		common.ConnectorParams{
+			AuthenticatedClient: mockutils.NewClient(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	connector.SetBaseURL(mockutils.ReplaceURLOrigin(connector.HTTPClient().Base, serverURL))
+
+	return connector, nil
+}
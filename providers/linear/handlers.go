@@ -8,12 +8,11 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/amp-labs/connectors/common"
-	"github.com/amp-labs/connectors/common/naming"
 	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/graphql"
 	"github.com/amp-labs/connectors/internal/jsonquery"
 )
 
@@ -22,125 +21,39 @@ var perPage = 100 //nolint:gochecknoglobals
 //go:embed graphql/*.graphql
 var queryFS embed.FS
 
-func (c *Connector) buildSingleObjectMetadataRequest(ctx context.Context, objectName string) (*http.Request, error) {
+// graphQLSchema returns the introspected schema, fetched once per connector.
+func (c *Connector) graphQLSchema(ctx context.Context) (*graphql.Schema, error) {
+	return c.introspector.Schema(ctx, c.ProviderInfo().BaseURL)
+}
+
+func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
 	url, err := urlbuilder.New(c.ProviderInfo().BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
-	// Use introspection query to get field information
-	query := fmt.Sprintf(`{
-		__type(name: "%s") {
-			name
-			fields {
-				name
-				type {
-					name
-					kind
-					ofType {
-					  name
-					  kind
-					}
-				}
-			}
-		}
-	}`, naming.NewSingularString(naming.CapitalizeFirstLetter(objectName)).String())
 
-	// Create the request body as a map
-	requestBody := map[string]string{
-		"query": query,
-	}
-
-	// Marshal the request body to JSON
-	jsonBody, err := json.Marshal(requestBody)
+	schema, err := c.graphQLSchema(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+	// Pages shrink as the complexity budget runs low.
+	reservation, err := c.budget.Reserve(ctx, params.ObjectName, perPage)
 	if err != nil {
 		return nil, err
 	}
 
-	return req, nil
-}
-
-func (c *Connector) parseSingleObjectMetadataResponse(
-	ctx context.Context,
-	objectName string,
-	request *http.Request,
-	response *common.JSONHTTPResponse,
-) (*common.ObjectMetadata, error) {
-	objectMetadata := common.ObjectMetadata{
-		Fields:      make(map[string]common.FieldMetadata),
-		DisplayName: naming.CapitalizeFirstLetterEveryWord(objectName),
-	}
-
-	metadataResp, err := common.UnmarshalJSON[MetadataResponse](response)
+	// Records keep the fields they were always read with, requested fields extend the selection,
+	// so custom and newly added fields can be read.
+	fields, err := graphql.ReadFields(queryFS, "graphql/"+params.ObjectName+".graphql")
 	if err != nil {
-		return nil, common.ErrFailedToUnmarshalBody
-	}
-
-	if len(metadataResp.Data.Type.Fields) == 0 {
-		return nil, fmt.Errorf(
-			"missing or empty fields for object: %s, error: %w",
-			objectName,
-			common.ErrMissingExpectedValues,
-		)
-	}
-
-	// Process each field from the introspection result
-	for _, field := range metadataResp.Data.Type.Fields {
-		valueType := field.Type.Name
-
-		if valueType == "" {
-			valueType = field.Type.OfType.Name
-		}
-
-		objectMetadata.Fields[field.Name] = common.FieldMetadata{
-			DisplayName:  field.Name,
-			ValueType:    getFieldValueType(valueType),
-			ProviderType: valueType,
-			Values:       nil,
-		}
-	}
-
-	return &objectMetadata, nil
-}
-
-func getFieldValueType(field string) common.ValueType {
-	if field == "" {
-		return ""
-	}
-
-	switch strings.ToLower(field) {
-	case "float":
-		return common.ValueTypeFloat
-	case "string", "id":
-		return common.ValueTypeString
-	case "boolean":
-		return common.ValueTypeBoolean
-	default:
-		return common.ValueTypeOther
+		return nil, err
 	}
-}
 
-func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
-	url, err := urlbuilder.New(c.ProviderInfo().BaseURL)
+	requestBody, err := schema.Read(params.ObjectName, append(fields, params.Fields.List()...),
+		buildGraphQLVariables(params, reservation.PageSize()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to build URL: %w", err)
-	}
-
-	query := getQuery("graphql/"+params.ObjectName+".graphql", params.ObjectName)
-
-	// Create request body with query and variables
-	requestBody := map[string]any{
-		"query": query,
-	}
-
-	variables := buildGraphQLVariables(params)
-
-	if len(variables) > 0 {
-		requestBody["variables"] = variables
+		return nil, err
 	}
 
 	jsonBody, err := json.Marshal(requestBody)
@@ -148,7 +61,8 @@ func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadPara
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+	req, err := http.NewRequestWithContext(graphql.WithReservation(ctx, reservation),
+		http.MethodPost, url.String(), bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -183,20 +97,27 @@ func (c *Connector) parseReadResponse(
 	request *http.Request,
 	response *common.JSONHTTPResponse,
 ) (*common.ReadResult, error) {
+	body, _ := response.Body()
+
+	if err := c.budget.Observe(graphql.ReservationFrom(request.Context()),
+		graphql.RecordCount(params.ObjectName, body), response.Headers, body); err != nil {
+		return nil, err
+	}
+
 	return common.ParseResult(
 		response,
-		records(params.ObjectName),
-		nextRecordsURL(params.ObjectName),
+		graphql.Records(params.ObjectName),
+		graphql.NextPage(params.ObjectName),
 		common.GetMarshaledData,
 		params.Fields,
 	)
 }
 
 // buildGraphQLVariables creates GraphQL variables for filtering.
-func buildGraphQLVariables(params common.ReadParams) map[string]any {
+func buildGraphQLVariables(params common.ReadParams, pageSize int) map[string]any {
 	variables := make(map[string]any)
 
-	variables["first"] = perPage
+	variables["first"] = pageSize
 
 	if !params.Since.IsZero() {
 		filter := map[string]any{
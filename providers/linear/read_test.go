@@ -0,0 +1,154 @@
+package linear
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop
+	t.Parallel()
+
+	responseSchema := testutils.DataFromFile(t, "schema.json")
+	errorUnknownField := testutils.DataFromFile(t, "issues/err-unknown-field.json")
+	responseIssues := testutils.DataFromFile(t, "issues/read.json")
+
+	// Every read introspects the schema first, the schema is cached per connector.
+	introspection := mockserver.Case{
+		If:   mockcond.BodyContains("__schema"),
+		Then: mockserver.Response(http.StatusOK, responseSchema),
+	}
+
+	tests := []testroutines.Read{
+		{
+			Name:         "Read object must be included",
+			Input:        common.ReadParams{},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Unknown object is not supported",
+			Input:        common.ReadParams{ObjectName: "organization", Fields: connectors.Fields("name")},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name:  "Error requesting unknown field for the object",
+			Input: common.ReadParams{ObjectName: "issues", Fields: connectors.Fields("random_field")},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusBadRequest, errorUnknownField),
+			}.Server(),
+			ExpectedErrs: []error{
+				common.ErrBadRequest,
+				errors.New(`Cannot query field "random_field" on type "Issue".`),
+			},
+		},
+		{
+			Name:  "Requested fields extend the fields issues are always read with",
+			Input: common.ReadParams{ObjectName: "issues", Fields: connectors.Fields("title", "state")},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.Path("/graphql"),
+						mockcond.BodyContains(`query Read($first: Int) { issues(first: $first) { nodes { ` +
+							`activitySummary addedToCycleAt`),
+						mockcond.BodyContains(`state { id } subIssueSortOrder suggestionsGeneratedAt ` +
+							`title trashed triagedAt updatedAt url } pageInfo { hasNextPage endCursor } } }`),
+						mockcond.BodyContains(`"variables":{"first":100}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseIssues),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"title": "Fix login redirect"},
+					Raw: map[string]any{
+						"id":         "5e1f0c52-4a43-4b8e-9d3e-6f0d1a2b3c01",
+						"identifier": "ENG-101",
+						"priority":   float64(2),
+					},
+				}, {
+					Fields: map[string]any{"title": "Update onboarding copy"},
+					Raw: map[string]any{
+						"id":         "5e1f0c52-4a43-4b8e-9d3e-6f0d1a2b3c02",
+						"identifier": "ENG-102",
+						"priority":   float64(3),
+					},
+				}},
+				NextPage: "5e1f0c52-4a43-4b8e-9d3e-6f0d1a2b3c02",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Incremental read filters by update time and continues after the cursor",
+			Input: common.ReadParams{
+				ObjectName: "issues",
+				Fields:     connectors.Fields("title"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+				NextPage:   "5e1f0c52-4a43-4b8e-9d3e-6f0d1a2b3c00",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.BodyContains(`query Read($after: String, $filter: IssueFilter, $first: Int) ` +
+							`{ issues(after: $after, filter: $filter, first: $first) { nodes {`),
+						mockcond.BodyContains(`"after":"5e1f0c52-4a43-4b8e-9d3e-6f0d1a2b3c00"`),
+						mockcond.BodyContains(`"filter":{"updatedAt":` +
+							`{"gte":"2025-01-01T00:00:00Z","lte":"2025-01-31T00:00:00Z"}}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseIssues),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     2,
+				NextPage: "5e1f0c52-4a43-4b8e-9d3e-6f0d1a2b3c02",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func constructTestConnector(serverURL string) (*Connector, error) {
+	connector, err := NewConnector(
+		common.ConnectorParams{
+			AuthenticatedClient: mockutils.NewClient(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	connector.SetBaseURL(mockutils.ReplaceURLOrigin(connector.HTTPClient().Base, serverURL))
+
+	return connector, nil
+}
@@ -1,6 +1,7 @@
 package loxo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -155,13 +156,13 @@ func (c *Connector) buildWriteRequest(ctx context.Context, params common.WritePa
 		method = http.MethodPut
 	}
 
-	formData := make(neturl.Values)
-
 	fields, ok := params.RecordData.(map[string]any)
 	if !ok {
 		return nil, fmt.Errorf("expected record data to be map[string]any but got %T", params.RecordData) //nolint:err113
 	}
 
+	formData := make(neturl.Values)
+
 	for key, value := range fields {
 		if str, ok := value.(string); ok {
 			formData.Set(key, str)
@@ -170,13 +171,17 @@ func (c *Connector) buildWriteRequest(ctx context.Context, params common.WritePa
 		}
 	}
 
-	body := strings.NewReader(formData.Encode())
+	body, _, err := common.FormCodec{}.Encode(formData)
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
+	// Loxo reads URL encoded fields sent under this content type.
 	req.Header.Set("Content-Type", "multipart/form-data")
 
 	req.Header.Set("Accept", "application/json")
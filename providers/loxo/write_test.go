@@ -32,6 +32,8 @@ func TestWrite(t *testing.T) { // nolint:funlen,gocognit,cyclop,maintidx
 				If: mockcond.And{
 					mockcond.Path("/integration-user-loxo-withampersand-com/companies"),
 					mockcond.MethodPOST(),
+					mockcond.Header(http.Header{"Content-Type": []string{"multipart/form-data"}}),
+					mockcond.Body("company%5Bname%5D=sample+value"),
 				},
 				Then: mockserver.Response(http.StatusOK, companiesFieldResponse),
 			}.Server(),
@@ -5,12 +5,14 @@ import (
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/interpreter"
+	"github.com/amp-labs/connectors/common/naming"
 	"github.com/amp-labs/connectors/internal/components"
 	"github.com/amp-labs/connectors/internal/components/deleter"
 	"github.com/amp-labs/connectors/internal/components/operations"
 	"github.com/amp-labs/connectors/internal/components/reader"
 	"github.com/amp-labs/connectors/internal/components/schema"
 	"github.com/amp-labs/connectors/internal/components/writer"
+	"github.com/amp-labs/connectors/internal/graphql"
 	"github.com/amp-labs/connectors/providers"
 )
 
@@ -28,6 +30,11 @@ type Connector struct {
 	components.Reader
 	components.Writer
 	components.Deleter
+
+	// introspector caches the GraphQL schema used to build reads and metadata.
+	introspector *graphql.Introspector
+	// budget paces reads by the complexity points reported with every read.
+	budget *graphql.Budget
 }
 
 func NewConnector(params common.ConnectorParams) (*Connector, error) {
@@ -36,7 +43,11 @@ func NewConnector(params common.ConnectorParams) (*Connector, error) {
 }
 
 func constructor(base *components.Connector) (*Connector, error) {
-	connector := &Connector{Connector: base}
+	connector := &Connector{
+		Connector:    base,
+		introspector: graphql.NewIntrospector(base.JSONHTTPClient()),
+		budget:       graphql.NewBudget(graphql.MondayCost),
+	}
 
 	registry, err := components.NewEndpointRegistry(supportedOperations())
 	if err != nil {
@@ -44,13 +55,9 @@ func constructor(base *components.Connector) (*Connector, error) {
 	}
 
 	// Set the metadata provider for the connector
-	connector.SchemaProvider = schema.NewObjectSchemaProvider(
-		connector.HTTPClient().Client,
-		schema.FetchModeParallel,
-		operations.SingleObjectMetadataHandlers{
-			BuildRequest:  connector.buildSingleObjectMetadataRequest,
-			ParseResponse: connector.parseSingleObjectMetadataResponse,
-		},
+	connector.SchemaProvider = schema.NewGraphQLSchemaProvider(
+		connector.graphQLSchema,
+		naming.CapitalizeFirstLetterEveryWord,
 	)
 	// Set the reader
 	connector.Reader = reader.NewHTTPReader(
@@ -60,9 +67,9 @@ func constructor(base *components.Connector) (*Connector, error) {
 		operations.ReadHandlers{
 			BuildRequest:  connector.buildReadRequest,
 			ParseResponse: connector.parseReadResponse,
-			ErrorHandler: interpreter.ErrorHandler{
+			ErrorHandler: connector.budget.ErrorHandler(interpreter.ErrorHandler{
 				JSON: interpreter.NewFaultyResponder(errorFormats, nil),
-			}.Handle,
+			}.Handle),
 		},
 	)
 	// Set the writer
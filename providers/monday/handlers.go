@@ -3,6 +3,7 @@ package monday
 import (
 	"bytes"
 	"context"
+	"embed"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,8 +11,8 @@ import (
 	"strconv"
 
 	"github.com/amp-labs/connectors/common"
-	"github.com/amp-labs/connectors/common/naming"
 	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/graphql"
 	"github.com/amp-labs/connectors/internal/jsonquery"
 	"github.com/spyzhov/ajson"
 )
@@ -39,231 +40,64 @@ const (
 	mondayUsersIDPath  = "data.create_user.id"
 )
 
-func (c *Connector) buildSingleObjectMetadataRequest(ctx context.Context, objectName string) (*http.Request, error) {
+//go:embed graphql/*.graphql
+var queryFS embed.FS
+
+// graphQLSchema returns the introspected schema, fetched once per connector.
+func (c *Connector) graphQLSchema(ctx context.Context) (*graphql.Schema, error) {
 	url, err := urlbuilder.New(c.ProviderInfo().BaseURL, apiVersion)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
 
-	// Use introspection query to get field information
-	query := fmt.Sprintf(`{
-		__type(name: "%s") {
-			name
-			fields {
-				name
-				type {
-					name
-					kind
-					ofType {
-						name
-					}
-				}
-			}
-		}
-	}`, naming.NewSingularString(naming.CapitalizeFirstLetterEveryWord(objectName)).String())
-
-	// Create the request body as a map
-	requestBody := map[string]string{
-		"query": query,
-	}
+	return c.introspector.Schema(ctx, url.String())
+}
 
-	// Marshal the request body to JSON
-	jsonBody, err := json.Marshal(requestBody)
+func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
+	url, err := urlbuilder.New(c.ProviderInfo().BaseURL, apiVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+	page, err := currentPage(params)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	return req, nil
-}
-
-func (c *Connector) parseSingleObjectMetadataResponse(
-	ctx context.Context,
-	objectName string,
-	request *http.Request,
-	response *common.JSONHTTPResponse,
-) (*common.ObjectMetadata, error) {
-	objectMetadata := common.ObjectMetadata{
-		FieldsMap:   make(map[string]string),
-		DisplayName: naming.CapitalizeFirstLetterEveryWord(objectName),
-	}
-
-	metadataResp, err := common.UnmarshalJSON[MetadataResponse](response)
+	schema, err := c.graphQLSchema(ctx)
 	if err != nil {
-		return nil, common.ErrFailedToUnmarshalBody
-	}
-
-	if len(metadataResp.Data.Type.Fields) == 0 {
-		return nil, fmt.Errorf(
-			"missing or empty fields for object: %s, error: %w",
-			objectName,
-			common.ErrMissingExpectedValues,
-		)
-	}
-
-	// Process each field from the introspection result
-	for _, field := range metadataResp.Data.Type.Fields {
-		// TODO fix deprecated
-		objectMetadata.FieldsMap[field.Name] = field.Name // nolint:staticcheck
-	}
-
-	return &objectMetadata, nil
-}
-
-func getBoardsBaseFields() string {
-	return `
-		id
-		name
-		state
-		permissions
-		items_count
-		type
-		updated_at
-		url
-		workspace_id`
-}
-
-func getBoardsNestedFields() string {
-	return `
-		columns {
-			id
-			title
-			type
-		}
-		groups {
-			id
-			title
-			position
-		}
-		owner {
-			id
-			name
-		}
-		owners {
-			id
-			name
-		}
-		subscribers {
-			id
-			name
-		}
-		tags {
-			id
-			name
-		}
-		team_owners {
-			id
-			name
-		}
-		team_subscribers {
-			id
-			name
-		}
-		top_group {
-			id
-			title
-		}
-		updates {
-			id
-			body
-			created_at
-		}
-		views {
-			id
-			name
-			type
-		}
-		workspace {
-			id
-			name
-		}`
-}
-
-func getBoardsQuery(page *int, limit *int) string {
-	paginationParams := ""
-	if page != nil && limit != nil {
-		paginationParams = fmt.Sprintf("(limit: %d, page: %d)", *limit, *page)
-	}
-
-	return fmt.Sprintf(`query {
-		boards%s {
-			%s
-			%s
-		}
-	}`, paginationParams, getBoardsBaseFields(), getBoardsNestedFields())
-}
-
-func getUsersQuery(page *int, limit *int) string {
-	paginationParams := ""
-	if page != nil && limit != nil {
-		paginationParams = fmt.Sprintf("(limit: %d, page: %d)", *limit, *page)
-	}
-
-	return fmt.Sprintf(`query {
-		users%s {
-			id
-			email
-			name
-			enabled
-		}
-	}`, paginationParams)
-}
-
-func getQueryForObject(objectName string, page *int, limit *int) (string, error) {
-	switch objectName {
-	case mondayObjectBoard:
-		return getBoardsQuery(page, limit), nil
-	case mondayObjectUser:
-		return getUsersQuery(page, limit), nil
-	default:
-		return "", fmt.Errorf("%w: %s", ErrUnsupportedObjectName, objectName)
+		return nil, err
 	}
-}
 
-func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
-	url, err := urlbuilder.New(c.ProviderInfo().BaseURL, apiVersion)
+	// Monday lists are paginated by page numbers, pages keep their size and reads wait for the budget instead.
+	reservation, err := c.budget.Wait(ctx, params.ObjectName, defaultPageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	var page *int
-
-	var limit int
-
-	if params.NextPage != "" {
-		// Parse the page number from NextPage
-		var pageNum int
-
-		_, err := fmt.Sscanf(string(params.NextPage), "%d", &pageNum)
-		if err != nil {
-			return nil, fmt.Errorf("invalid next page format: %w", err)
-		}
-
-		page = &pageNum
-		limit = defaultPageSize
+	// Records keep the fields they were always read with, requested fields extend the selection.
+	fields, err := graphql.ReadFields(queryFS, "graphql/query_"+params.ObjectName+".graphql")
+	if err != nil {
+		return nil, err
 	}
 
-	query, err := getQueryForObject(params.ObjectName, page, &limit)
+	requestBody, err := schema.Read(params.ObjectName, append(fields, params.Fields.List()...), map[string]any{
+		"limit": defaultPageSize,
+		"page":  page,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	requestBody := map[string]string{
-		"query": query,
-	}
+	requestBody.Select(graphql.MondayComplexitySelection)
 
 	jsonBody, err := json.Marshal(requestBody)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+	req, err := http.NewRequestWithContext(graphql.WithReservation(ctx, reservation),
+		http.MethodPost, url.String(), bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -279,67 +113,22 @@ func (c *Connector) parseReadResponse(
 	request *http.Request,
 	resp *common.JSONHTTPResponse,
 ) (*common.ReadResult, error) {
-	data, err := common.UnmarshalJSON[Response](resp)
-	if err != nil {
-		return nil, common.ErrFailedToUnmarshalBody
-	}
-
-	var records []any
-
-	switch params.ObjectName {
-	case mondayObjectUser:
-		if len(data.Data.Users) == 0 {
-			errMsg := "missing expected values for object: " + params.ObjectName
-
-			return nil, fmt.Errorf("%s, error: %w", errMsg, common.ErrMissingExpectedValues)
-		}
-
-		records = make([]any, len(data.Data.Users))
-		for i, user := range data.Data.Users {
-			records[i] = user
-		}
-	case mondayObjectBoard:
-		if len(data.Data.Boards) == 0 {
-			errMsg := "missing expected values for object: " + params.ObjectName
-
-			return nil, fmt.Errorf("%s, error: %w", errMsg, common.ErrMissingExpectedValues)
-		}
+	body, _ := resp.Body()
 
-		records = make([]any, len(data.Data.Boards))
-		for i, board := range data.Data.Boards {
-			records[i] = board
-		}
-	default:
-		return nil, fmt.Errorf("%w: %s", ErrUnsupportedObjectName, params.ObjectName)
+	if err := c.budget.Observe(graphql.ReservationFrom(request.Context()),
+		graphql.RecordCount(params.ObjectName, body), resp.Headers, body); err != nil {
+		return nil, err
 	}
 
 	return common.ParseResult(
 		resp,
-		getRecords(params.ObjectName),
-		makeNextRecordsURL(params, len(records)),
+		graphql.Records(params.ObjectName),
+		makeNextRecordsURL(params),
 		common.GetMarshaledData,
 		params.Fields,
 	)
 }
 
-func getRecords(objectName string) func(*ajson.Node) ([]map[string]any, error) {
-	return func(node *ajson.Node) ([]map[string]any, error) {
-		// First get the data object
-		dataNode, err := node.GetKey("data")
-		if err != nil {
-			return nil, err
-		}
-
-		// Then get the array under the object name (e.g., "boards" or "users")
-		records, err := jsonquery.New(dataNode).ArrayOptional(objectName)
-		if err != nil {
-			return nil, err
-		}
-
-		return jsonquery.Convertor.ArrayToMap(records)
-	}
-}
-
 func (c *Connector) buildWriteRequest(ctx context.Context, params common.WriteParams) (*http.Request, error) {
 	url, err := urlbuilder.New(c.ProviderInfo().BaseURL, apiVersion)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/test/utils/mockutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
 	"github.com/amp-labs/connectors/test/utils/testroutines"
 	"github.com/amp-labs/connectors/test/utils/testutils"
@@ -16,21 +17,106 @@ import (
 func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 	t.Parallel()
 
+	responseSchema := testutils.DataFromFile(t, "schema.json")
 	errorBadRequest := testutils.DataFromFile(t, "boards/err-unknown-property.json")
+	errorComplexityBudget := testutils.DataFromFile(t, "boards/err-complexity-budget.json")
+	responseBoards := testutils.DataFromFile(t, "boards/read.json")
+
+	// Every read introspects the schema first, the schema is cached per connector.
+	introspection := mockserver.Case{
+		If:   mockcond.BodyContains("__schema"),
+		Then: mockserver.Response(http.StatusOK, responseSchema),
+	}
 
 	tests := []testroutines.Read{
 		{
 			Name:  "Error requesting unknown field for the object",
 			Input: common.ReadParams{ObjectName: "boards", Fields: connectors.Fields("id", "random_field")},
-			Server: mockserver.Fixed{
-				Setup:  mockserver.ContentJSON(),
-				Always: mockserver.Response(http.StatusBadRequest, errorBadRequest),
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusBadRequest, errorBadRequest),
 			}.Server(),
 			ExpectedErrs: []error{
 				common.ErrBadRequest,
 				errors.New(`Cannot query field "random_field" on type "Board".`),
 			},
 		},
+		{
+			Name:  "Exhausted complexity budget is a rate limit error",
+			Input: common.ReadParams{ObjectName: "boards", Fields: connectors.Fields("name")},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, errorComplexityBudget),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrLimitExceeded},
+		},
+		{
+			Name:  "Requested fields extend the fields boards are always read with",
+			Input: common.ReadParams{ObjectName: "boards", Fields: connectors.Fields("name", "description")},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.Path("/v2"),
+						mockcond.BodyContains(`boards(limit: $limit, page: $page) { columns { id title type } ` +
+							`description groups { id position title } id items_count name owner { id name } `),
+						mockcond.BodyContains(`workspace { id name } workspace_id } complexity {`),
+						mockcond.BodyContains(`"variables":{"limit":200,"page":1}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseBoards),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"name": "Roadmap"},
+					Raw:    map[string]any{"id": "101", "name": "Roadmap"},
+				}, {
+					Fields: map[string]any{"name": "Backlog"},
+					Raw:    map[string]any{"id": "102", "name": "Backlog"},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Next page token holds the page number",
+			Input: common.ReadParams{
+				ObjectName: "boards",
+				Fields:     connectors.Fields("name"),
+				NextPage:   "page:3",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If:   mockcond.BodyContains(`"variables":{"limit":200,"page":3}`),
+					Then: mockserver.Response(http.StatusOK, responseBoards),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected:   &common.ReadResult{Rows: 2, NextPage: "", Done: true},
+		},
+		{
+			Name: "Next page token of earlier versions holds the records read so far",
+			Input: common.ReadParams{
+				ObjectName: "boards",
+				Fields:     connectors.Fields("name"),
+				NextPage:   "400",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If:   mockcond.BodyContains(`"variables":{"limit":200,"page":3}`),
+					Then: mockserver.Response(http.StatusOK, responseBoards),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected:   &common.ReadResult{Rows: 2, NextPage: "", Done: true},
+		},
 	}
 
 	for _, tt := range tests {
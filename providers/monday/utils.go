@@ -7,6 +7,7 @@ import (
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/internal/components"
+	"github.com/amp-labs/connectors/internal/graphql"
 	"github.com/spyzhov/ajson"
 )
 
@@ -34,22 +35,48 @@ func supportedOperations() components.EndpointRegistryInput {
 	}
 }
 
-func makeNextRecordsURL(params common.ReadParams, count int) func(*ajson.Node) (string, error) {
+// pageTokenPrefix marks next page tokens holding a page number.
+// Tokens without it are the number of records read so far, as issued by earlier versions.
+const pageTokenPrefix = "page:"
+
+// currentPage returns the page number being read, pages are numbered from 1.
+func currentPage(params common.ReadParams) (int, error) {
+	if params.NextPage == "" {
+		return 1, nil
+	}
+
+	token, versioned := strings.CutPrefix(params.NextPage.String(), pageTokenPrefix)
+
+	number, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid next page format: %w", err)
+	}
+
+	if versioned {
+		return number, nil
+	}
+
+	// Records were read in full pages, the offset is where the next page starts.
+	return number/defaultPageSize + 1, nil
+}
+
+// makeNextRecordsURL returns the next page number while pages are full.
+func makeNextRecordsURL(params common.ReadParams) common.NextPageFunc {
 	return func(node *ajson.Node) (string, error) {
-		if count < defaultPageSize {
-			return "", nil
+		records, err := graphql.Records(params.ObjectName)(node)
+		if err != nil {
+			return "", err
 		}
 
-		var currentPage int
-		if params.NextPage != "" {
-			_, err := fmt.Sscanf(string(params.NextPage), "%d", &currentPage)
-			if err != nil {
-				return "", fmt.Errorf("invalid next page format: %w", err)
-			}
+		if len(records) < defaultPageSize {
+			return "", nil
 		}
 
-		nextPage := currentPage + count
+		page, err := currentPage(params)
+		if err != nil {
+			return "", err
+		}
 
-		return strconv.Itoa(nextPage), nil
+		return pageTokenPrefix + strconv.Itoa(page+1), nil
 	}
 }
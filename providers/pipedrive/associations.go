@@ -0,0 +1,352 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+const (
+	// participantsAssociation links persons to deals beyond the main contact person.
+	// Other associations are lookup fields of the source record, ex: org_id of a person.
+	participantsAssociation = "participants"
+
+	participantsPageSize = "100"
+)
+
+var _ connectors.AssociationConnector = &Connector{}
+
+// defaultAssociations maps source and target objects to the field or the endpoint linking them.
+var defaultAssociations = map[[2]string]string{ // nolint:gochecknoglobals
+	{"persons", "organizations"}:    "org_id",
+	{"deals", "organizations"}:      "org_id",
+	{"deals", "persons"}:            participantsAssociation,
+	{"leads", "persons"}:            "person_id",
+	{"leads", "organizations"}:      "organization_id",
+	{"activities", "deals"}:         "deal_id",
+	{"activities", "persons"}:       "person_id",
+	{"activities", "organizations"}: "org_id",
+}
+
+// ListAssociations returns records of the target object linked to a record.
+// Deal participants are listed page by page, lookup fields hold at most one record.
+// https://developers.pipedrive.com/docs/api/v1/Deals#getDealParticipants
+func (c *Connector) ListAssociations(
+	ctx context.Context, params common.ListAssociationsParams,
+) (*common.ListAssociationsResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	association, err := resolveAssociation(params.FromObject, params.ToObject, params.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if association == participantsAssociation {
+		return c.listParticipants(ctx, params)
+	}
+
+	target, err := c.getLookupValue(ctx, params.FromObject, params.FromId, association)
+	if err != nil {
+		return nil, err
+	}
+
+	associations := make([]common.Association, 0, 1)
+	if target != "" {
+		associations = append(associations, common.Association{
+			ObjectId:        target,
+			AssociationType: association,
+		})
+	}
+
+	return &common.ListAssociationsResult{
+		Associations: associations,
+		Done:         true,
+	}, nil
+}
+
+// CreateAssociation adds a deal participant or sets the lookup field of the source record.
+// https://developers.pipedrive.com/docs/api/v1/Deals#addDealParticipant
+func (c *Connector) CreateAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.WriteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	association, err := resolveAssociation(descriptor.FromObject, descriptor.ToObject, descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if association != participantsAssociation {
+		return c.setLookupValue(ctx, descriptor.FromObject, descriptor.FromId, association, toIdentifier(descriptor.ToId))
+	}
+
+	participantID, err := c.findParticipant(ctx, descriptor.FromId, descriptor.ToId)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantID != "" {
+		return &common.WriteResult{Success: true, RecordId: participantID}, nil
+	}
+
+	url, err := c.getParticipantsURL(descriptor.FromId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Client.Post(ctx, url.String(), map[string]any{"person_id": toIdentifier(descriptor.ToId)})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAssociationWrite(resp)
+}
+
+// DeleteAssociation removes a deal participant or clears the lookup field,
+// provided it points to the target record.
+// https://developers.pipedrive.com/docs/api/v1/Deals#deleteDealParticipant
+func (c *Connector) DeleteAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.DeleteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	association, err := resolveAssociation(descriptor.FromObject, descriptor.ToObject, descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if association != participantsAssociation {
+		target, err := c.getLookupValue(ctx, descriptor.FromObject, descriptor.FromId, association)
+		if err != nil {
+			return nil, err
+		}
+
+		if target == descriptor.ToId {
+			if _, err = c.setLookupValue(ctx, descriptor.FromObject, descriptor.FromId, association, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		return &common.DeleteResult{Success: true}, nil
+	}
+
+	participantID, err := c.findParticipant(ctx, descriptor.FromId, descriptor.ToId)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantID != "" {
+		url, err := c.getParticipantsURL(descriptor.FromId)
+		if err != nil {
+			return nil, err
+		}
+
+		url.AddPath(participantID)
+
+		if _, err = c.Client.Delete(ctx, url.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &common.DeleteResult{Success: true}, nil
+}
+
+// resolveAssociation returns the lookup field or participantsAssociation.
+// Type.Id overrides the default, ex: "person_id" links the main contact person of a deal.
+func resolveAssociation(fromObject, toObject string, typ common.AssociationType) (string, error) {
+	if typ.Id != "" {
+		return typ.Id, nil
+	}
+
+	association, ok := defaultAssociations[[2]string{fromObject, toObject}]
+	if !ok {
+		return "", fmt.Errorf("%w: %s to %s", common.ErrUnsupportedAssociation, fromObject, toObject)
+	}
+
+	return association, nil
+}
+
+func (c *Connector) listParticipants(
+	ctx context.Context, params common.ListAssociationsParams,
+) (*common.ListAssociationsResult, error) {
+	url, err := c.getParticipantsURL(params.FromId)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("limit", participantsPageSize)
+
+	if len(params.NextPage) != 0 {
+		url.WithQueryParam("start", params.NextPage.String())
+	}
+
+	resp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := resp.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	participants, err := jsonquery.New(body).ArrayOptional("data")
+	if err != nil {
+		return nil, err
+	}
+
+	associations := make([]common.Association, 0, len(participants))
+
+	for _, participant := range participants {
+		personID, err := lookupIdentifier(participant, "person_id")
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := jsonquery.Convertor.ObjectToMap(participant)
+		if err != nil {
+			return nil, err
+		}
+
+		associations = append(associations, common.Association{
+			ObjectId:        personID,
+			AssociationType: participantsAssociation,
+			Raw:             raw,
+		})
+	}
+
+	nextStart, err := nextStartValue(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ListAssociationsResult{
+		Associations: associations,
+		NextPage:     common.NextPageToken(nextStart),
+		Done:         nextStart == "",
+	}, nil
+}
+
+// findParticipant returns the identifier of the participant record linking the person to the deal.
+func (c *Connector) findParticipant(ctx context.Context, dealID, personID string) (string, error) {
+	params := common.ListAssociationsParams{FromId: dealID}
+
+	for {
+		result, err := c.listParticipants(ctx, params)
+		if err != nil {
+			return "", err
+		}
+
+		for _, association := range result.Associations {
+			if association.ObjectId == personID {
+				return fmt.Sprint(association.Raw["id"]), nil
+			}
+		}
+
+		if result.Done {
+			return "", nil
+		}
+
+		params.NextPage = result.NextPage
+	}
+}
+
+func (c *Connector) getLookupValue(ctx context.Context, objectName, recordID, field string) (string, error) {
+	url, err := c.getAPIURL(objectName)
+	if err != nil {
+		return "", err
+	}
+
+	url.AddPath(recordID)
+
+	resp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return "", err
+	}
+
+	body, ok := resp.Body()
+	if !ok {
+		return "", common.ErrEmptyJSONHTTPResponse
+	}
+
+	record, err := jsonquery.New(body).ObjectRequired("data")
+	if err != nil {
+		return "", err
+	}
+
+	return lookupIdentifier(record, field)
+}
+
+// setLookupValue updates a single field, a nil value clears it.
+func (c *Connector) setLookupValue(
+	ctx context.Context, objectName, recordID, field string, value any,
+) (*common.WriteResult, error) {
+	return c.Write(ctx, common.WriteParams{
+		ObjectName: objectName,
+		RecordId:   recordID,
+		RecordData: map[string]any{field: value},
+	})
+}
+
+func (c *Connector) getParticipantsURL(dealID string) (*urlbuilder.URL, error) {
+	return urlbuilder.New(c.BaseURL, apiVersion, "deals", dealID, participantsAssociation)
+}
+
+// lookupIdentifier reads a reference to another record.
+// Depending on the endpoint it is either the plain ID or an object with the ID under "value".
+func lookupIdentifier(node *ajson.Node, field string) (string, error) {
+	value, err := jsonquery.New(node).ObjectOptional(field)
+	if err == nil && value != nil {
+		return jsonquery.New(value).TextWithDefault("value", "")
+	}
+
+	return jsonquery.New(node).TextWithDefault(field, "")
+}
+
+func nextStartValue(node *ajson.Node) (string, error) {
+	more, err := jsonquery.New(node, "additional_data", "pagination").BoolWithDefault("more_items_in_collection", false)
+	if err != nil || !more {
+		return "", err
+	}
+
+	start, err := jsonquery.New(node, "additional_data", "pagination").IntegerOptional("next_start")
+	if err != nil || start == nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(*start, 10), nil
+}
+
+func parseAssociationWrite(resp *common.JSONHTTPResponse) (*common.WriteResult, error) {
+	response, err := common.UnmarshalJSON[writeResponse](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.WriteResult{
+		Success:  response.Success,
+		RecordId: fmt.Sprint(response.Data["id"]),
+		Data:     response.Data,
+	}, nil
+}
+
+// toIdentifier sends numeric IDs as numbers, which Pipedrive expects for lookup fields.
+func toIdentifier(recordID string) any {
+	if number, err := strconv.Atoi(recordID); err == nil {
+		return number
+	}
+
+	return recordID
+}
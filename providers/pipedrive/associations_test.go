@@ -0,0 +1,178 @@
+package pipedrive
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestListAssociations(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseParticipants := testutils.DataFromFile(t, "associations/participants.json")
+
+	tests := []testroutines.ListAssociations{
+		{
+			Name:         "Unknown object pair is not supported",
+			Input:        common.ListAssociationsParams{FromObject: "notes", FromId: "3", ToObject: "files"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrUnsupportedAssociation},
+		},
+		{
+			Name:  "Deal participants are listed",
+			Input: common.ListAssociationsParams{FromObject: "deals", FromId: "12", ToObject: "persons"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodGET(),
+					mockcond.Path("/v1/deals/12/participants"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseParticipants),
+			}.Server(),
+			Comparator: func(_ string, actual, expected *common.ListAssociationsResult) bool {
+				return len(actual.Associations) == 2 &&
+					actual.Associations[0].ObjectId == "7" &&
+					actual.Associations[1].ObjectId == "9" &&
+					actual.Associations[1].AssociationType == expected.Associations[0].AssociationType &&
+					actual.Done == expected.Done
+			},
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{AssociationType: "participants"}},
+				Done:         true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Lookup field is read",
+			Input: common.ListAssociationsParams{FromObject: "persons", FromId: "7", ToObject: "organizations"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.Path("/v1/persons/7"),
+				Then: mockserver.ResponseString(http.StatusOK,
+					`{"success":true,"data":{"id":7,"org_id":{"name":"Analytical Engines","value":3}}}`),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{ObjectId: "3", AssociationType: "org_id"}},
+				Done:         true,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestCreateAssociation(t *testing.T) {
+	t.Parallel()
+
+	responseParticipants := testutils.DataFromFile(t, "associations/participants.json")
+
+	tests := []testroutines.CreateAssociation{
+		{
+			Name: "Person is added as participant",
+			Input: common.AssociationDescriptor{
+				FromObject: "deals", FromId: "12", ToObject: "persons", ToId: "15",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.MethodGET(),
+					Then: mockserver.Response(http.StatusOK, responseParticipants),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/v1/deals/12/participants"),
+						mockcond.Body(`{"person_id":15}`),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"success":true,"data":{"id":43}}`),
+				}},
+			}.Server(),
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "43",
+				Data:     map[string]any{"id": float64(43)},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Existing participant is not duplicated",
+			Input: common.AssociationDescriptor{
+				FromObject: "deals", FromId: "12", ToObject: "persons", ToId: "9",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.MethodGET(),
+				Then:  mockserver.Response(http.StatusOK, responseParticipants),
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true, RecordId: "42"},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDeleteAssociation(t *testing.T) {
+	t.Parallel()
+
+	responseParticipants := testutils.DataFromFile(t, "associations/participants.json")
+
+	tests := []testroutines.DeleteAssociation{
+		{
+			Name: "Participant is removed",
+			Input: common.AssociationDescriptor{
+				FromObject: "deals", FromId: "12", ToObject: "persons", ToId: "7",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.MethodGET(),
+					Then: mockserver.Response(http.StatusOK, responseParticipants),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodDELETE(),
+						mockcond.Path("/v1/deals/12/participants/41"),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"success":true,"data":{"id":41}}`),
+				}},
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
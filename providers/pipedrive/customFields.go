@@ -0,0 +1,337 @@
+package pipedrive
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+const (
+	// Longest value of varchar fields. Longer text goes to large text fields.
+	varcharMaxLength = 255
+
+	fieldsPageSize = "500"
+)
+
+// Objects whose fields can be created. Note fields can only be read.
+var customFieldObjects = datautils.NewSet( // nolint:gochecknoglobals
+	"activities", "deals", "products", "persons", "organizations",
+)
+
+// fieldPayload is the subset of field metadata we manage.
+// https://developers.pipedrive.com/docs/api/v1/PersonFields#addPersonField
+type fieldPayload struct {
+	Name      string          `json:"name"`
+	FieldType string          `json:"field_type,omitempty"` // nolint:tagliatelle
+	Options   []optionPayload `json:"options,omitempty"`
+}
+
+type optionPayload struct {
+	ID    any    `json:"id,omitempty"`
+	Label string `json:"label"`
+}
+
+// UpsertMetadata creates custom fields or updates them if they already exist.
+// Pipedrive generates field keys, so fields are matched by key, falling back to the field name.
+// The generated key is returned in the metadata of each field.
+// A dry run compares definitions with existing fields the same way, but doesn't write them.
+func (c *Connector) UpsertMetadata(
+	ctx context.Context, params *common.UpsertMetadataParams,
+) (*common.UpsertMetadataResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	result := &common.UpsertMetadataResult{
+		Success: true,
+		Fields:  make(map[string]map[string]common.FieldUpsertResult, len(params.Fields)),
+		DryRun:  params.DryRun,
+	}
+
+	for objectName, definitions := range params.Fields {
+		if !customFieldObjects.Has(objectName) {
+			return nil, fmt.Errorf("%w: %s", common.ErrOperationNotSupportedForObject, objectName)
+		}
+
+		fields, err := c.upsertFields(ctx, metadataDiscoveryEndpoints.Get(objectName), definitions, params.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("object %s: %w", objectName, err)
+		}
+
+		result.Fields[objectName] = fields
+	}
+
+	return result, nil
+}
+
+func (c *Connector) upsertFields(
+	ctx context.Context, endpoint string, definitions []common.FieldDefinition, dryRun bool,
+) (map[string]common.FieldUpsertResult, error) {
+	existing, err := c.fetchFields(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]common.FieldUpsertResult, len(definitions))
+
+	for _, definition := range definitions {
+		payload, warnings, err := newFieldPayload(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		current := findField(existing, definition)
+		action := common.UpsertMetadataActionNone
+		data := map[string]any{}
+
+		switch {
+		case current == nil:
+			action = common.UpsertMetadataActionCreate
+
+			if !dryRun {
+				data, err = c.writeField(ctx, endpoint, "", payload)
+			}
+		case !current.matches(payload):
+			action = common.UpsertMetadataActionUpdate
+
+			if current.FieldType != payload.FieldType {
+				warnings = append(warnings, fmt.Sprintf(
+					"field type cannot be changed from %s to %s", current.FieldType, payload.FieldType))
+			}
+
+			// Keeping option IDs preserves values of existing records.
+			payload.keepOptionIDs(current.Options)
+			payload.FieldType = ""
+
+			data = map[string]any{"id": current.ID, "key": current.Key}
+
+			if !dryRun {
+				data, err = c.writeField(ctx, endpoint, strconv.Itoa(current.ID), payload)
+			}
+		default:
+			data = map[string]any{"id": current.ID, "key": current.Key}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", definition.FieldName, err)
+		}
+
+		fields[definition.FieldName] = common.FieldUpsertResult{
+			FieldName: definition.FieldName,
+			Action:    action,
+			Metadata:  data,
+			Warnings:  warnings,
+		}
+	}
+
+	return fields, nil
+}
+
+func (c *Connector) fetchFields(ctx context.Context, endpoint string) ([]fieldResults, error) {
+	url, err := c.getAPIURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam(limitQuery, fieldsPageSize)
+
+	var fields []fieldResults
+
+	for {
+		resp, err := c.Client.Get(ctx, url.String())
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := common.UnmarshalJSON[metadataFields](resp)
+		if err != nil {
+			return nil, err
+		}
+
+		if response != nil {
+			fields = append(fields, response.Data...)
+		}
+
+		body, ok := resp.Body()
+		if !ok {
+			return fields, nil
+		}
+
+		start, err := nextStartValue(body)
+		if err != nil {
+			return nil, err
+		}
+
+		if start == "" {
+			return fields, nil
+		}
+
+		url.WithQueryParam("start", start)
+	}
+}
+
+// writeField creates a field when the identifier is empty, otherwise updates it.
+// https://developers.pipedrive.com/docs/api/v1/PersonFields#updatePersonField
+func (c *Connector) writeField(
+	ctx context.Context, endpoint, identifier string, payload *fieldPayload,
+) (map[string]any, error) {
+	var (
+		url   *urlbuilder.URL
+		err   error
+		write common.WriteMethod
+	)
+
+	if identifier == "" {
+		url, err = c.getAPIURL(endpoint)
+		write = c.Client.Post
+	} else {
+		url, err = urlbuilder.New(c.BaseURL, apiVersion, endpoint, identifier)
+		write = c.Client.Put
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := write(ctx, url.String(), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[writeResponse](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if response == nil || !response.Success {
+		return nil, fmt.Errorf("%w: field was not saved", common.ErrBadRequest)
+	}
+
+	return map[string]any{
+		"id":  response.Data["id"],
+		"key": response.Data["key"],
+	}, nil
+}
+
+func findField(fields []fieldResults, definition common.FieldDefinition) *fieldResults {
+	for index, field := range fields {
+		if field.Key == definition.FieldName {
+			return &fields[index]
+		}
+	}
+
+	// Only custom fields are matched by name, a system field keeps its key.
+	for index, field := range fields {
+		if field.EditFlag && strings.EqualFold(field.Name, definition.DisplayName) {
+			return &fields[index]
+		}
+	}
+
+	return nil
+}
+
+// matches reports whether updating the field with the payload would change anything.
+func (f fieldResults) matches(payload *fieldPayload) bool {
+	if f.Name != payload.Name || f.FieldType != payload.FieldType {
+		return false
+	}
+
+	if len(payload.Options) == 0 {
+		return true
+	}
+
+	current := datautils.NewStringSet()
+	for _, option := range f.Options {
+		current.AddOne(option.Label)
+	}
+
+	desired := datautils.NewStringSet()
+	for _, option := range payload.Options {
+		desired.AddOne(option.Label)
+	}
+
+	return len(current) == len(desired) && len(current.Intersection(desired)) == len(desired)
+}
+
+func (p *fieldPayload) keepOptionIDs(options []options) {
+	identifiers := make(map[string]any, len(options))
+	for _, option := range options {
+		identifiers[option.Label] = option.ID
+	}
+
+	for index, option := range p.Options {
+		p.Options[index].ID = identifiers[option.Label]
+	}
+}
+
+func newFieldPayload(definition common.FieldDefinition) (*fieldPayload, []string, error) {
+	payload := &fieldPayload{Name: definition.DisplayName}
+
+	var warnings []string
+
+	switch definition.ValueType {
+	case common.FieldTypeString:
+		payload.FieldType = "varchar"
+
+		if options := definition.StringOptions; options != nil {
+			if (options.Length != nil && *options.Length > varcharMaxLength) ||
+				(options.NumDisplayLines != nil && *options.NumDisplayLines > 1) {
+				payload.FieldType = "text"
+			}
+		}
+	case common.FieldTypeBoolean:
+		// There is no boolean type, a single option field with both values stands in for it.
+		payload.FieldType = enum
+		payload.Options = []optionPayload{{Label: "True"}, {Label: "False"}}
+	case common.FieldTypeDate:
+		payload.FieldType = "date"
+	case common.FieldTypeDateTime:
+		payload.FieldType = "date"
+
+		warnings = append(warnings, "time of day is not stored, the field holds a date")
+	case common.FieldTypeSingleSelect, common.FieldTypeMultiSelect:
+		payload.FieldType = enum
+		if definition.ValueType == common.FieldTypeMultiSelect {
+			payload.FieldType = set
+		}
+
+		if definition.StringOptions != nil {
+			for _, value := range definition.StringOptions.Values {
+				payload.Options = append(payload.Options, optionPayload{Label: value})
+			}
+		}
+	case common.FieldTypeInt, common.FieldTypeFloat:
+		payload.FieldType = "double"
+	default:
+		return nil, nil, fmt.Errorf("%w, fieldName: %v", common.ErrFieldTypeUnknown, definition.FieldName)
+	}
+
+	if definition.Description != "" {
+		warnings = append(warnings, "field description is not supported")
+	}
+
+	if definition.Required || definition.Unique || definition.Indexed {
+		warnings = append(warnings, "required, unique and indexed constraints are not supported")
+	}
+
+	if definition.Association != nil {
+		warnings = append(warnings, "relationship fields are not supported")
+	}
+
+	if options := definition.StringOptions; options != nil {
+		if options.Pattern != "" || options.DefaultValue != nil {
+			warnings = append(warnings, "pattern and default value are not supported")
+		}
+	}
+
+	if options := definition.NumericOptions; options != nil {
+		warnings = append(warnings, "numeric options are not supported")
+	}
+
+	return payload, warnings, nil
+}
@@ -0,0 +1,199 @@
+package pipedrive
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestUpsertMetadata(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseFields := testutils.DataFromFile(t, "custom-fields/person-fields.json")
+
+	tests := []testroutines.UpsertMetadata{
+		{
+			Name: "Notes have no custom fields",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"notes": {{FieldName: "topic", DisplayName: "Topic", ValueType: common.FieldTypeString}},
+				},
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Fields are created, updated or left as is",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"persons": {{
+						FieldName:   "name",
+						DisplayName: "Name",
+						ValueType:   common.FieldTypeString,
+					}, {
+						FieldName:     "tier",
+						DisplayName:   "Tier",
+						ValueType:     common.FieldTypeSingleSelect,
+						StringOptions: &common.StringFieldOptions{Values: []string{"Gold", "Silver", "Bronze"}},
+					}, {
+						FieldName:   "renewal",
+						DisplayName: "Renewal",
+						ValueType:   common.FieldTypeDateTime,
+					}},
+				},
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/v1/personFields"),
+					},
+					Then: mockserver.Response(http.StatusOK, responseFields),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPUT(),
+						mockcond.Path("/v1/personFields/9057"),
+						mockcond.Body(`{"name":"Tier","options":[
+							{"id":61,"label":"Gold"},{"id":62,"label":"Silver"},{"label":"Bronze"}]}`),
+					},
+					Then: mockserver.ResponseString(http.StatusOK,
+						`{"success":true,"data":{"id":9057,"key":"3f1a0e2b7c94d5e6f8a1b2c3d4e5f6a7b8c9d0e1"}}`),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/v1/personFields"),
+						mockcond.Body(`{"name":"Renewal","field_type":"date"}`),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated,
+						`{"success":true,"data":{"id":9061,"key":"a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0"}}`),
+				}},
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"persons": {
+						"name": {
+							FieldName: "name",
+							Action:    common.UpsertMetadataActionNone,
+							Metadata:  map[string]any{"id": 9001, "key": "name"},
+						},
+						"tier": {
+							FieldName: "tier",
+							Action:    common.UpsertMetadataActionUpdate,
+							Metadata: map[string]any{
+								"id": float64(9057), "key": "3f1a0e2b7c94d5e6f8a1b2c3d4e5f6a7b8c9d0e1",
+							},
+						},
+						"renewal": {
+							FieldName: "renewal",
+							Action:    common.UpsertMetadataActionCreate,
+							Metadata: map[string]any{
+								"id": float64(9061), "key": "a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0",
+							},
+							Warnings: []string{"time of day is not stored, the field holds a date"},
+						},
+					},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "System fields are not matched by name",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"persons": {{
+						FieldName:   "legalName",
+						DisplayName: "Name",
+						ValueType:   common.FieldTypeString,
+					}},
+				},
+				DryRun: true,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodGET(),
+					mockcond.Path("/v1/personFields"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseFields),
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				DryRun:  true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"persons": {
+						"legalName": {
+							FieldName: "legalName",
+							Action:    common.UpsertMetadataActionCreate,
+							Metadata:  map[string]any{},
+						},
+					},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Dry run reports the plan without writing",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"persons": {{
+						FieldName:     "tier",
+						DisplayName:   "Tier",
+						ValueType:     common.FieldTypeSingleSelect,
+						StringOptions: &common.StringFieldOptions{Values: []string{"Gold", "Silver", "Bronze"}},
+					}, {
+						FieldName:   "renewal",
+						DisplayName: "Renewal",
+						ValueType:   common.FieldTypeDate,
+					}},
+				},
+				DryRun: true,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodGET(),
+					mockcond.Path("/v1/personFields"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseFields),
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				DryRun:  true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"persons": {
+						"tier": {
+							FieldName: "tier",
+							Action:    common.UpsertMetadataActionUpdate,
+							Metadata:  map[string]any{"id": 9057, "key": "3f1a0e2b7c94d5e6f8a1b2c3d4e5f6a7b8c9d0e1"},
+						},
+						"renewal": {
+							FieldName: "renewal",
+							Action:    common.UpsertMetadataActionCreate,
+							Metadata:  map[string]any{},
+						},
+					},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.UpsertMetadataConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
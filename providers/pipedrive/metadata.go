@@ -27,6 +27,8 @@ type fieldResults struct {
 	FieldType       string    `json:"field_type"`        //nolint:tagliatelle
 	BulkEditAllowed bool      `json:"bulk_edit_allowed"` //nolint:tagliatelle
 	Options         []options `json:"options"`
+	// EditFlag is set for custom fields, system fields cannot be edited.
+	EditFlag bool `json:"edit_flag"` //nolint:tagliatelle
 }
 
 // options represents the set of values one can use for enum, sets data Types.
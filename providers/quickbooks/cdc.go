@@ -0,0 +1,242 @@
+package quickbooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+// Change Data Capture returns entities changed since a point in time, including deleted ones,
+// which the query endpoint never returns.
+// https://developer.intuit.com/app/developer/qbo/docs/learn/explore-the-quickbooks-online-api/change-data-capture
+const (
+	cdcPath = "cdc"
+
+	// cdcWindow is how far back CDC can look. Older checkpoints are served by the query endpoint.
+	cdcWindow = 30 * 24 * time.Hour
+
+	// cdcMaxResults is the most entities CDC returns per call. There is no pagination.
+	cdcMaxResults = 1000
+
+	// queryFirstPage is the next page token handing a capped CDC read over to the query endpoint.
+	queryFirstPage = "1"
+
+	statusDeleted = "Deleted"
+)
+
+// ErrCDCResultsCapped is returned when Change Data Capture reaches its result cap on a deleted read.
+// CDC neither paginates nor tells which changes were left out, so the read must use a more recent checkpoint.
+var ErrCDCResultsCapped = errors.New("change data capture returned the maximum number of entities")
+
+// cdcObjects lists objects supported by Change Data Capture.
+var cdcObjects = datautils.NewSet( //nolint:gochecknoglobals
+	"account",
+	"attachable",
+	"bill",
+	"billPayment",
+	"budget",
+	"class",
+	"creditCardPayment",
+	"creditMemo",
+	"customer",
+	"department",
+	"deposit",
+	"employee",
+	"estimate",
+	"invoice",
+	"item",
+	"journalCode",
+	"journalEntry",
+	"payment",
+	"paymentMethod",
+	"purchase",
+	"purchaseOrder",
+	"refundReceipt",
+	"salesReceipt",
+	"taxAgency",
+	"term",
+	"timeActivity",
+	"transfer",
+	"vendor",
+	"vendorCredit",
+)
+
+// isCDCRead decides whether Change Data Capture should serve this read.
+// Deleted entities are only discoverable via CDC, in which case the whole window is scanned.
+// Otherwise, CDC is used when the checkpoint falls within the CDC window.
+// Next pages always belong to the query endpoint, as CDC reads fit a single response.
+func isCDCRead(params common.ReadParams, now time.Time) bool {
+	if !cdcObjects.Has(params.ObjectName) {
+		return false
+	}
+
+	if params.Deleted {
+		return true
+	}
+
+	if params.NextPage != "" {
+		return false
+	}
+
+	return !params.Since.IsZero() && now.Sub(params.Since) < cdcWindow
+}
+
+// cdcEntityName returns the entity name used by CDC requests and responses, ex: CreditCardPaymentTxn.
+func cdcEntityName(objectName string) string {
+	return naming.CapitalizeFirstLetter(objectNameToResponseField.Get(objectName))
+}
+
+func (c *Connector) buildCDCRequest(
+	ctx context.Context, params common.ReadParams, now time.Time,
+) (*http.Request, error) {
+	url, err := urlbuilder.New(c.ProviderInfo().BaseURL, restAPIPrefix, c.realmID, cdcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	changedSince := params.Since
+
+	if windowStart := now.Add(-cdcWindow); changedSince.Before(windowStart) {
+		changedSince = windowStart
+	}
+
+	url.WithQueryParam("entities", cdcEntityName(params.ObjectName))
+	url.WithQueryParam("changedSince", changedSince.Format(time.RFC3339))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	return req, nil
+}
+
+// parseCDCResponse returns either changed or deleted entities, depending on ReadParams.Deleted.
+// Deleted entities carry only the Id, status and MetaData.
+//
+// CDC doesn't paginate, and a capped response doesn't tell which changes were left out.
+// Such reads of live entities are handed over to the query endpoint, which paginates the same time range.
+// Deleted entities cannot be found otherwise, so capped deleted reads fail with ErrCDCResultsCapped.
+func parseCDCResponse(params common.ReadParams, response *common.JSONHTTPResponse) (*common.ReadResult, error) {
+	body, ok := response.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	entities, err := extractCDCEntities(body, params.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) >= cdcMaxResults {
+		if params.Deleted {
+			return nil, fmt.Errorf("%w: %d %s entities changed since %s, use a more recent checkpoint",
+				ErrCDCResultsCapped, len(entities), params.ObjectName, params.Since.Format(time.RFC3339))
+		}
+
+		return &common.ReadResult{
+			Rows:     0,
+			Data:     []common.ReadResultRow{},
+			NextPage: queryFirstPage,
+			Done:     false,
+		}, nil
+	}
+
+	records := make([]map[string]any, 0, len(entities))
+
+	for _, entity := range entities {
+		if !params.Until.IsZero() && entityLastUpdated(entity).After(params.Until) {
+			continue
+		}
+
+		isDeleted := entity["status"] == statusDeleted
+		if isDeleted == params.Deleted {
+			records = append(records, entity)
+		}
+	}
+
+	rows, err := common.GetMarshaledData(records, params.Fields.List())
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.ReadResult{
+		Rows:     int64(len(rows)),
+		Data:     rows,
+		NextPage: "",
+		Done:     true,
+	}, nil
+}
+
+// extractCDCEntities returns entities ordered by the time of the last update.
+// Response has the following shape:
+//
+//	{"CDCResponse": [{"QueryResponse": [{"Customer": [...], "startPosition": 1, "maxResults": 2}]}]}
+func extractCDCEntities(body *ajson.Node, objectName string) ([]map[string]any, error) {
+	responseKey := cdcEntityName(objectName)
+
+	cdcResponses, err := jsonquery.New(body).ArrayOptional("CDCResponse")
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]map[string]any, 0)
+
+	for _, cdcResponse := range cdcResponses {
+		queryResponses, err := jsonquery.New(cdcResponse).ArrayOptional("QueryResponse")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, queryResponse := range queryResponses {
+			items, err := jsonquery.New(queryResponse).ArrayOptional(responseKey)
+			if err != nil {
+				return nil, err
+			}
+
+			records, err := jsonquery.Convertor.ArrayToMap(items)
+			if err != nil {
+				return nil, err
+			}
+
+			entities = append(entities, records...)
+		}
+	}
+
+	slices.SortStableFunc(entities, func(left, right map[string]any) int {
+		return entityLastUpdated(left).Compare(entityLastUpdated(right))
+	})
+
+	return entities, nil
+}
+
+func entityLastUpdated(entity map[string]any) time.Time {
+	metadata, ok := entity["MetaData"].(map[string]any)
+	if !ok {
+		return time.Time{}
+	}
+
+	text, ok := metadata["LastUpdatedTime"].(string)
+	if !ok {
+		return time.Time{}
+	}
+
+	updated, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return updated
+}
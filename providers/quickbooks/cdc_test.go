@@ -0,0 +1,267 @@
+package quickbooks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestReadCDC(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	responseCDC := testutils.DataFromFile(t, "customer-cdc.json")
+
+	since := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+
+	server := func() *mockserver.Conditional {
+		return &mockserver.Conditional{
+			Setup: mockserver.ContentJSON(),
+			If: mockcond.And{
+				mockcond.Path("/v3/company/123456789/cdc"),
+				mockcond.QueryParam("entities", "Customer"),
+				mockcond.QueryParam("changedSince", since.Format(time.RFC3339)),
+			},
+			Then: mockserver.Response(http.StatusOK, responseCDC),
+		}
+	}
+
+	tests := []testroutines.Read{
+		{
+			Name: "Recent checkpoint returns changed entities oldest first",
+			Input: common.ReadParams{
+				ObjectName: "customer",
+				Fields:     connectors.Fields("DisplayName"),
+				Since:      since,
+			},
+			Server:     server().Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"displayname": "Amy's Bird Sanctuary"},
+					Raw:    map[string]any{"Id": "12"},
+				}, {
+					Fields: map[string]any{"displayname": "Bill's Windsurf Shop"},
+					Raw:    map[string]any{"Id": "58"},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Deleted read returns only deleted entities",
+			Input: common.ReadParams{
+				ObjectName: "customer",
+				Fields:     connectors.Fields("Id"),
+				Since:      since,
+				Deleted:    true,
+			},
+			Server:     server().Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"id": "61"},
+					Raw:    map[string]any{"status": "Deleted"},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Old checkpoint falls back to query",
+			Input: common.ReadParams{
+				ObjectName: "customer",
+				Fields:     connectors.Fields("DisplayName"),
+				Since:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.QueryParam("query", "SELECT * FROM Customer WHERE "+
+					" MetaData.LastUpdatedTime >= '2024-01-01T00:00:00Z' STARTPOSITION 1 MAXRESULTS 1000"),
+				Then: mockserver.Response(http.StatusOK, testutils.DataFromFile(t, "customer-read.json")),
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     1,
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Entities use QuickBooks names",
+			Input: common.ReadParams{
+				ObjectName: "creditCardPayment",
+				Fields:     connectors.Fields("Id"),
+				Since:      since,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.Path("/v3/company/123456789/cdc"),
+					mockcond.QueryParam("entities", "CreditCardPaymentTxn"),
+				},
+				Then: mockserver.Response(http.StatusOK, cdcResponse(t, "CreditCardPaymentTxn", 1)),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{"id": "0"},
+					Raw:    map[string]any{"Id": "0"},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Capped response hands over to query",
+			Input: common.ReadParams{
+				ObjectName: "customer",
+				Fields:     connectors.Fields("DisplayName"),
+				Since:      since,
+			},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.Response(http.StatusOK, cdcResponse(t, "Customer", cdcMaxResults)),
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     0,
+				NextPage: "1",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Capped deleted response is an error",
+			Input: common.ReadParams{
+				ObjectName: "customer",
+				Fields:     connectors.Fields("Id"),
+				Since:      since,
+				Deleted:    true,
+			},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.Response(http.StatusOK, cdcResponse(t, "Customer", cdcMaxResults)),
+			}.Server(),
+			ExpectedErrs: []error{ErrCDCResultsCapped},
+		},
+		{
+			Name: "Deleted read of object untracked by CDC is not supported",
+			Input: common.ReadParams{
+				ObjectName: "exchangeRate",
+				Fields:     connectors.Fields("Id"),
+				Deleted:    true,
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+// cdcResponse builds a CDC response holding the given number of entities.
+func cdcResponse(t *testing.T, entityName string, count int) []byte {
+	t.Helper()
+
+	entities := make([]map[string]any, count)
+	for index := range entities {
+		entities[index] = map[string]any{
+			"Id":       strconv.Itoa(index),
+			"MetaData": map[string]any{"LastUpdatedTime": "2024-05-03T09:30:00-07:00"},
+		}
+	}
+
+	data, err := json.Marshal(map[string]any{
+		"CDCResponse": []any{map[string]any{
+			"QueryResponse": []any{map[string]any{entityName: entities}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestIsCDCRead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		input    common.ReadParams
+		expected bool
+	}{
+		{
+			name:     "Full read uses query",
+			input:    common.ReadParams{ObjectName: "customer"},
+			expected: false,
+		},
+		{
+			name:     "Checkpoint within window uses CDC",
+			input:    common.ReadParams{ObjectName: "customer", Since: now.Add(-29 * 24 * time.Hour)},
+			expected: true,
+		},
+		{
+			name:     "Checkpoint beyond window uses query",
+			input:    common.ReadParams{ObjectName: "customer", Since: now.Add(-31 * 24 * time.Hour)},
+			expected: false,
+		},
+		{
+			name:     "Deleted read uses CDC",
+			input:    common.ReadParams{ObjectName: "invoice", Deleted: true},
+			expected: true,
+		},
+		{
+			name:     "Objects unsupported by CDC use query",
+			input:    common.ReadParams{ObjectName: "exchangeRate", Since: now.Add(-time.Hour)},
+			expected: false,
+		},
+		{
+			name:     "Query next page continues query",
+			input:    common.ReadParams{ObjectName: "customer", Since: now.Add(-time.Hour), NextPage: "1001"},
+			expected: false,
+		},
+		{
+			name:     "Deleted next page continues CDC",
+			input:    common.ReadParams{ObjectName: "customer", NextPage: "1001", Deleted: true},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if output := isCDCRead(tt.input, now); output != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, output)
+			}
+		})
+	}
+}
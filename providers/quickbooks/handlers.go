@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/naming"
@@ -89,6 +90,16 @@ func (c *Connector) parseSingleObjectMetadataResponse(
 }
 
 func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
+	// The query endpoint never returns deleted entities, only CDC tracks them.
+	if params.Deleted && !cdcObjects.Has(params.ObjectName) {
+		return nil, fmt.Errorf("%w: deleted %s entities are not tracked by change data capture",
+			common.ErrOperationNotSupportedForObject, params.ObjectName)
+	}
+
+	if now := time.Now(); isCDCRead(params, now) {
+		return c.buildCDCRequest(ctx, params, now)
+	}
+
 	url, err := urlbuilder.New(c.ProviderInfo().BaseURL, restAPIPrefix, c.realmID, "query")
 	if err != nil {
 		return nil, err
@@ -114,6 +125,10 @@ func (c *Connector) parseReadResponse(
 	request *http.Request,
 	response *common.JSONHTTPResponse,
 ) (*common.ReadResult, error) {
+	if strings.HasSuffix(request.URL.Path, "/"+cdcPath) {
+		return parseCDCResponse(params, response)
+	}
+
 	return common.ParseResult(
 		response,
 		getRecords(params.ObjectName),
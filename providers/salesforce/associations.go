@@ -0,0 +1,364 @@
+package salesforce
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/amp-labs/connectors/providers/salesforce/internal/crm/core"
+)
+
+// Salesforce links records in two ways:
+//   - lookup: a field on the record points to the other record, ex: Contact.AccountId.
+//   - junction: a record of a third object points to both records, ex: OpportunityContactRole.
+const (
+	associationCategoryLookup   = "lookup"
+	associationCategoryJunction = "junction"
+
+	customObjectSuffix = "__c"
+)
+
+var _ connectors.AssociationConnector = &Connector{}
+
+// LookupAssociation links records through a lookup field of the source object, ex: "AccountId" on Contact.
+func LookupAssociation(field string) common.AssociationType {
+	return common.AssociationType{
+		Id:       field,
+		Category: associationCategoryLookup,
+		Label:    field,
+	}
+}
+
+// JunctionAssociation links records through a junction object,
+// ex: JunctionAssociation("OpportunityContactRole", "OpportunityId", "ContactId").
+func JunctionAssociation(junctionObject, fromField, toField string) common.AssociationType {
+	return common.AssociationType{
+		Id:       strings.Join([]string{junctionObject, fromField, toField}, "."),
+		Category: associationCategoryJunction,
+		Label:    junctionObject,
+	}
+}
+
+type junctionSpec struct {
+	object    string
+	fromField string
+	toField   string
+}
+
+// ListAssociations returns records of the target object linked to a record.
+func (c *Connector) ListAssociations(
+	ctx context.Context, params common.ListAssociationsParams,
+) (*common.ListAssociationsResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	lookupField, junction, err := resolveAssociationType(params.ToObject, params.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if junction != nil {
+		return c.listJunctionAssociations(ctx, params, junction)
+	}
+
+	target, err := c.getLookupValue(ctx, params.FromObject, params.FromId, lookupField)
+	if err != nil {
+		return nil, err
+	}
+
+	associations := make([]common.Association, 0, 1)
+	if target != "" {
+		associations = append(associations, common.Association{
+			ObjectId:        target,
+			AssociationType: lookupField,
+		})
+	}
+
+	return &common.ListAssociationsResult{
+		Associations: associations,
+		Done:         true,
+	}, nil
+}
+
+// CreateAssociation sets the lookup field of the source record or creates a junction record.
+// A junction record is not duplicated if one already links both records.
+func (c *Connector) CreateAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.WriteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	lookupField, junction, err := resolveAssociationType(descriptor.ToObject, descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if junction == nil {
+		if err = c.setLookupValue(ctx, descriptor.FromObject, descriptor.FromId, lookupField, descriptor.ToId); err != nil {
+			return nil, err
+		}
+
+		return &common.WriteResult{Success: true, RecordId: descriptor.FromId}, nil
+	}
+
+	identifiers, err := c.findJunctionRecords(ctx, descriptor, junction)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(identifiers) != 0 {
+		return &common.WriteResult{Success: true, RecordId: identifiers[0]}, nil
+	}
+
+	url, err := c.getRestApiURL("sobjects", junction.object)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Post(ctx, url.String(), map[string]any{
+		junction.fromField: descriptor.FromId,
+		junction.toField:   descriptor.ToId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWriteResult(rsp)
+}
+
+// DeleteAssociation clears the lookup field of the source record, provided it points to the target record,
+// or deletes junction records linking both records.
+func (c *Connector) DeleteAssociation(
+	ctx context.Context, descriptor common.AssociationDescriptor,
+) (*common.DeleteResult, error) {
+	if err := descriptor.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	lookupField, junction, err := resolveAssociationType(descriptor.ToObject, descriptor.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if junction == nil {
+		target, err := c.getLookupValue(ctx, descriptor.FromObject, descriptor.FromId, lookupField)
+		if err != nil {
+			return nil, err
+		}
+
+		if target == descriptor.ToId {
+			if err = c.setLookupValue(ctx, descriptor.FromObject, descriptor.FromId, lookupField, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		return &common.DeleteResult{Success: true}, nil
+	}
+
+	identifiers, err := c.findJunctionRecords(ctx, descriptor, junction)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identifier := range identifiers {
+		url, err := c.getRestApiURL("sobjects", junction.object, identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = c.Client.Delete(ctx, url.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &common.DeleteResult{Success: true}, nil
+}
+
+// resolveAssociationType returns either the lookup field or the junction linking records.
+// Standard objects are linked by default through the lookup field named after the target, ex: AccountId.
+func resolveAssociationType(toObject string, typ common.AssociationType) (string, *junctionSpec, error) {
+	switch typ.Category {
+	case "", associationCategoryLookup:
+		if typ.Id != "" {
+			return typ.Id, nil, nil
+		}
+
+		if typ.Category == "" && !strings.HasSuffix(toObject, customObjectSuffix) {
+			return toObject + "Id", nil, nil
+		}
+
+		return "", nil, common.ErrMissingAssociationType
+	case associationCategoryJunction:
+		parts := strings.Split(typ.Id, ".")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" { // nolint:mnd
+			return "", nil, fmt.Errorf("%w: junction must be formatted as Object.FromField.ToField, got %q",
+				common.ErrCaller, typ.Id)
+		}
+
+		return "", &junctionSpec{object: parts[0], fromField: parts[1], toField: parts[2]}, nil
+	default:
+		return "", nil, fmt.Errorf("%w: unknown category %q", common.ErrUnsupportedAssociation, typ.Category)
+	}
+}
+
+func (c *Connector) getLookupValue(ctx context.Context, objectName, recordID, field string) (string, error) {
+	url, err := c.getRestApiURL("sobjects", objectName, recordID)
+	if err != nil {
+		return "", err
+	}
+
+	url.WithQueryParam("fields", field)
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return "", err
+	}
+
+	body, ok := rsp.Body()
+	if !ok {
+		return "", common.ErrEmptyJSONHTTPResponse
+	}
+
+	return jsonquery.New(body).StrWithDefault(field, "")
+}
+
+// setLookupValue updates a single field, a nil value clears it.
+func (c *Connector) setLookupValue(ctx context.Context, objectName, recordID, field string, value any) error {
+	url, err := c.getRestApiURL("sobjects", objectName, recordID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.Patch(ctx, url.String(), map[string]any{field: value})
+
+	return err
+}
+
+func (c *Connector) listJunctionAssociations(
+	ctx context.Context, params common.ListAssociationsParams, junction *junctionSpec,
+) (*common.ListAssociationsResult, error) {
+	soql := (&core.SOQLBuilder{}).
+		SelectFields([]string{"Id", junction.toField}).
+		From(junction.object).
+		WhereField(junction.fromField, "=", params.FromId)
+
+	var (
+		url *urlbuilder.URL
+		err error
+	)
+
+	if len(params.NextPage) != 0 {
+		url, err = c.getDomainURL(params.NextPage.String())
+	} else {
+		url, err = c.getRestApiURL("query")
+		if err == nil {
+			url.WithQueryParam("q", soql.String())
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := rsp.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	records, err := getRecords(body)
+	if err != nil {
+		return nil, err
+	}
+
+	nextPage, err := getNextRecordsURL(body)
+	if err != nil {
+		return nil, err
+	}
+
+	associations := make([]common.Association, 0, len(records))
+
+	for _, record := range records {
+		target, _ := record[junction.toField].(string)
+		if target == "" {
+			continue
+		}
+
+		associations = append(associations, common.Association{
+			ObjectId:        target,
+			AssociationType: junction.object,
+			Raw:             record,
+		})
+	}
+
+	return &common.ListAssociationsResult{
+		Associations: associations,
+		NextPage:     common.NextPageToken(nextPage),
+		Done:         nextPage == "",
+	}, nil
+}
+
+// findJunctionRecords returns identifiers of junction records linking both records.
+func (c *Connector) findJunctionRecords(
+	ctx context.Context, descriptor common.AssociationDescriptor, junction *junctionSpec,
+) ([]string, error) {
+	soql := (&core.SOQLBuilder{}).
+		SelectFields([]string{"Id"}).
+		From(junction.object).
+		WhereField(junction.fromField, "=", descriptor.FromId).
+		WhereField(junction.toField, "=", descriptor.ToId)
+
+	url, err := c.getRestApiURL("query")
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("q", soql.String())
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := rsp.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	records, err := getRecords(body)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]string, 0, len(records))
+
+	for _, record := range records {
+		if identifier, ok := record["Id"].(string); ok {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	return identifiers, nil
+}
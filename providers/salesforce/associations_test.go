@@ -0,0 +1,267 @@
+package salesforce
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+var contactRoles = JunctionAssociation("OpportunityContactRole", "OpportunityId", "ContactId") // nolint:gochecknoglobals
+
+func TestListAssociations(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseContactRoles := testutils.DataFromFile(t, "associations/contact-roles.json")
+
+	tests := []testroutines.ListAssociations{
+		{
+			Name: "Custom objects need association type",
+			Input: common.ListAssociationsParams{
+				FromObject: "Contact", FromId: "003ak00000Ff9QZAA1", ToObject: "Invoice__c",
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingAssociationType},
+		},
+		{
+			Name: "Lookup field is inferred for standard objects",
+			Input: common.ListAssociationsParams{
+				FromObject: "Contact", FromId: "003ak00000Ff9QZAA1", ToObject: "Account",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodGET(),
+					mockcond.Path("/services/data/v60.0/sobjects/Contact/003ak00000Ff9QZAA1"),
+					mockcond.QueryParam("fields", "AccountId"),
+				},
+				Then: mockserver.ResponseString(http.StatusOK, `{"AccountId":"001ak00000Oc1XyAAJ","Id":"003ak00000Ff9QZAA1"}`),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{
+					ObjectId:        "001ak00000Oc1XyAAJ",
+					AssociationType: "AccountId",
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Empty lookup field has no associations",
+			Input: common.ListAssociationsParams{
+				FromObject: "Contact", FromId: "003ak00000Ff9QZAA1", ToObject: "User",
+				Type: LookupAssociation("ReportsToId"),
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.QueryParam("fields", "ReportsToId"),
+				Then:  mockserver.ResponseString(http.StatusOK, `{"ReportsToId":null}`),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{},
+				Done:         true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Junction records are queried",
+			Input: common.ListAssociationsParams{
+				FromObject: "Opportunity", FromId: "006ak000004Lx2XAAS", ToObject: "Contact",
+				Type: contactRoles,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.Path("/services/data/v60.0/query"),
+					mockcond.QueryParam("q", "SELECT Id,ContactId FROM OpportunityContactRole "+
+						"WHERE OpportunityId = '006ak000004Lx2XAAS'"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseContactRoles),
+			}.Server(),
+			Expected: &common.ListAssociationsResult{
+				Associations: []common.Association{{
+					ObjectId:        "003ak00000Ff9QZAA1",
+					AssociationType: "OpportunityContactRole",
+					Raw: map[string]any{
+						"attributes": map[string]any{
+							"type": "OpportunityContactRole",
+							"url":  "/services/data/v60.0/sobjects/OpportunityContactRole/00Kak000001aBcDEAU",
+						},
+						"Id":        "00Kak000001aBcDEAU",
+						"ContactId": "003ak00000Ff9QZAA1",
+					},
+				}},
+				NextPage: "/services/data/v60.0/query/01gak00000AbCdE-2000",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestCreateAssociation(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []testroutines.CreateAssociation{
+		{
+			Name: "Lookup field is set",
+			Input: common.AssociationDescriptor{
+				FromObject: "Contact", FromId: "003ak00000Ff9QZAA1", ToObject: "Account", ToId: "001ak00000Oc1XyAAJ",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPATCH(),
+					mockcond.Path("/services/data/v60.0/sobjects/Contact/003ak00000Ff9QZAA1"),
+					mockcond.Body(`{"AccountId":"001ak00000Oc1XyAAJ"}`),
+				},
+				Then: mockserver.Response(http.StatusNoContent),
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true, RecordId: "003ak00000Ff9QZAA1"},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Existing junction record is reused",
+			Input: common.AssociationDescriptor{
+				FromObject: "Opportunity", FromId: "006ak000004Lx2XAAS", ToObject: "Contact", ToId: "003ak00000Ff9QZAA1",
+				Type: contactRoles,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.QueryParam("q", "SELECT Id FROM OpportunityContactRole "+
+					"WHERE OpportunityId = '006ak000004Lx2XAAS' AND ContactId = '003ak00000Ff9QZAA1'"),
+				Then: mockserver.ResponseString(http.StatusOK,
+					`{"totalSize":1,"done":true,"records":[{"Id":"00Kak000001aBcDEAU"}]}`),
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true, RecordId: "00Kak000001aBcDEAU"},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Junction record is created",
+			Input: common.AssociationDescriptor{
+				FromObject: "Opportunity", FromId: "006ak000004Lx2XAAS", ToObject: "Contact", ToId: "003ak00000Ff9QZAA1",
+				Type: contactRoles,
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.Path("/services/data/v60.0/query"),
+					Then: mockserver.ResponseString(http.StatusOK, `{"totalSize":0,"done":true,"records":[]}`),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/services/data/v60.0/sobjects/OpportunityContactRole"),
+						mockcond.Body(`{"OpportunityId":"006ak000004Lx2XAAS","ContactId":"003ak00000Ff9QZAA1"}`),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated,
+						`{"id":"00Kak000001aBcDEAU","success":true,"errors":[]}`),
+				}},
+			}.Server(),
+			Expected:     &common.WriteResult{Success: true, RecordId: "00Kak000001aBcDEAU", Errors: []any{}},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestDeleteAssociation(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []testroutines.DeleteAssociation{
+		{
+			Name: "Lookup field pointing elsewhere is kept",
+			Input: common.AssociationDescriptor{
+				FromObject: "Contact", FromId: "003ak00000Ff9QZAA1", ToObject: "Account", ToId: "001ak00000Oc1XyAAJ",
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.MethodGET(),
+				Then:  mockserver.ResponseString(http.StatusOK, `{"AccountId":"001ak00000ZZZZZAAJ"}`),
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Lookup field is cleared",
+			Input: common.AssociationDescriptor{
+				FromObject: "Contact", FromId: "003ak00000Ff9QZAA1", ToObject: "Account", ToId: "001ak00000Oc1XyAAJ",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If:   mockcond.MethodGET(),
+					Then: mockserver.ResponseString(http.StatusOK, `{"AccountId":"001ak00000Oc1XyAAJ"}`),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPATCH(),
+						mockcond.Path("/services/data/v60.0/sobjects/Contact/003ak00000Ff9QZAA1"),
+						mockcond.Body(`{"AccountId":null}`),
+					},
+					Then: mockserver.Response(http.StatusNoContent),
+				}},
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Junction records are deleted",
+			Input: common.AssociationDescriptor{
+				FromObject: "Opportunity", FromId: "006ak000004Lx2XAAS", ToObject: "Contact", ToId: "003ak00000Ff9QZAA1",
+				Type: contactRoles,
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.Path("/services/data/v60.0/query"),
+					Then: mockserver.ResponseString(http.StatusOK,
+						`{"totalSize":1,"done":true,"records":[{"Id":"00Kak000001aBcDEAU"}]}`),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodDELETE(),
+						mockcond.Path("/services/data/v60.0/sobjects/OpportunityContactRole/00Kak000001aBcDEAU"),
+					},
+					Then: mockserver.Response(http.StatusNoContent),
+				}},
+			}.Server(),
+			Expected:     &common.DeleteResult{Success: true},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.AssociationConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
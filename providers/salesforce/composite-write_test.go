@@ -0,0 +1,137 @@
+package salesforce
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestCompositeWrite(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	graphPayload := testutils.DataFromFile(t, "batch/composite/payload.json")
+	responseSuccess := testutils.DataFromFile(t, "batch/composite/success.json")
+	responseRolledBack := testutils.DataFromFile(t, "batch/composite/rolled-back.json")
+
+	nodes := []common.CompositeWriteNode{{
+		ReferenceId: "refAccount",
+		Params: common.WriteParams{
+			ObjectName: "Account",
+			RecordData: map[string]any{"Name": "Acme"},
+		},
+	}, {
+		ReferenceId: "refContact",
+		Params: common.WriteParams{
+			ObjectName: "Contact",
+			RecordData: map[string]any{"LastName": "Dyer", "AccountId": "@{refAccount.id}"},
+		},
+	}, {
+		ReferenceId: "refOpportunity",
+		Params: common.WriteParams{
+			ObjectName: "Opportunity",
+			RecordId:   "006ak000004Lx2XAAS",
+			RecordData: map[string]any{"AccountId": "@{refAccount.id}"},
+		},
+	}}
+
+	tests := []testroutines.CompositeWrite{
+		{
+			Name:         "At least one node is required",
+			Input:        &common.CompositeWriteParams{},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingCompositeNodes},
+		},
+		{
+			Name: "Reference IDs must be unique",
+			Input: &common.CompositeWriteParams{
+				Nodes: []common.CompositeWriteNode{nodes[0], nodes[0]},
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrDuplicateReferenceID},
+		},
+		{
+			Name: "Placeholders must refer to preceding nodes",
+			Input: &common.CompositeWriteParams{
+				Nodes: []common.CompositeWriteNode{nodes[1], nodes[0]},
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrUnresolvedReference},
+		},
+		{
+			Name:  "Graph creates and updates records with references",
+			Input: &common.CompositeWriteParams{Nodes: nodes},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.Path("/services/data/v60.0/composite/graph"),
+					mockcond.BodyBytes(graphPayload),
+				},
+				Then: mockserver.Response(http.StatusOK, responseSuccess),
+			}.Server(),
+			Expected: &common.CompositeWriteResult{
+				Success: true,
+				Results: map[string]*common.WriteResult{
+					"refAccount":     {Success: true, RecordId: "001ak00000Oc1XyAAJ"},
+					"refContact":     {Success: true, RecordId: "003ak00000Ff9QZAA1"},
+					"refOpportunity": {Success: true, RecordId: "006ak000004Lx2XAAS"},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Failed node rolls back the graph",
+			Input: &common.CompositeWriteParams{Nodes: nodes},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.Path("/services/data/v60.0/composite/graph"),
+				Then:  mockserver.Response(http.StatusOK, responseRolledBack),
+			}.Server(),
+			Expected: &common.CompositeWriteResult{
+				Success: false,
+				Results: map[string]*common.WriteResult{
+					"refAccount": {
+						Success: false,
+						Errors:  []any{common.ErrBatchUnprocessedRecord},
+					},
+					"refContact": {
+						Success: false,
+						Errors: []any{map[string]any{
+							"errorCode": "REQUIRED_FIELD_MISSING",
+							"message":   "Required fields are missing: [LastName]",
+							"fields":    []any{"LastName"},
+						}},
+					},
+					"refOpportunity": {
+						Success:  false,
+						RecordId: "",
+						Errors:   []any{common.ErrBatchUnprocessedRecord},
+					},
+				},
+				Errors: []any{map[string]any{
+					"errorCode": "REQUIRED_FIELD_MISSING",
+					"message":   "Required fields are missing: [LastName]",
+					"fields":    []any{"LastName"},
+				}},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.CompositeWriteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
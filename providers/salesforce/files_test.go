@@ -0,0 +1,162 @@
+package salesforce
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/go-test/deep"
+)
+
+func TestUploadFile(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        common.FileUploadParams
+		server       *mockserver.Conditional
+		expected     *common.FileUploadResult
+		expectedErrs []error
+	}{
+		{
+			name:         "File name is required",
+			input:        common.FileUploadParams{Content: strings.NewReader("hello")},
+			expectedErrs: []error{common.ErrMissingFileName},
+		},
+		{
+			name:         "Content is required",
+			input:        common.FileUploadParams{FileName: "notes.txt"},
+			expectedErrs: []error{common.ErrMissingFileContent},
+		},
+		{
+			name: "Upload creates content version linked to record",
+			input: common.FileUploadParams{
+				FileName:    "notes.txt",
+				ContentType: "text/plain",
+				Content:     strings.NewReader("hello"),
+				Size:        5,
+				ParentId:    "001ak00000OQTieAAH",
+			},
+			server: &mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.Path("/services/data/v60.0/sobjects/ContentVersion"),
+					mockcond.MultipartPart("entity_content", `{
+						"Title": "notes",
+						"PathOnClient": "notes.txt",
+						"FirstPublishLocationId": "001ak00000OQTieAAH"
+					}`),
+					mockcond.MultipartPart("VersionData", "hello"),
+				},
+				Then: mockserver.ResponseString(http.StatusCreated,
+					`{"id":"068ak00000AbCdEAAZ","success":true,"errors":[]}`),
+			},
+			expected: &common.FileUploadResult{
+				FileId:      "068ak00000AbCdEAAZ",
+				FileName:    "notes.txt",
+				Size:        5,
+				ContentType: "text/plain",
+			},
+		},
+		{
+			name: "Error response is understood",
+			input: common.FileUploadParams{
+				FileName: "notes.txt",
+				Content:  strings.NewReader("hello"),
+			},
+			server: &mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If:    mockcond.MethodPOST(),
+				Then: mockserver.ResponseString(http.StatusForbidden,
+					`[{"message":"Insufficient access","errorCode":"INSUFFICIENT_ACCESS_OR_READONLY"}]`),
+			},
+			expectedErrs: []error{common.ErrForbidden},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := mockserver.Dummy()
+			if tt.server != nil {
+				server = tt.server.Server()
+			}
+
+			defer server.Close()
+
+			conn, err := constructTestConnector(server.URL)
+			if err != nil {
+				t.Fatalf("failed to construct test connector: %v", err)
+			}
+
+			output, err := conn.UploadFile(t.Context(), tt.input)
+			for _, expectedErr := range tt.expectedErrs {
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("expected error %v, got %v", expectedErr, err)
+				}
+			}
+
+			if len(tt.expectedErrs) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := deep.Equal(output, tt.expected); diff != nil {
+				t.Fatalf("output mismatch: %v", diff)
+			}
+		})
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	t.Parallel()
+
+	server := mockserver.Switch{
+		Cases: []mockserver.Case{{
+			If: mockcond.And{
+				mockcond.Path("/services/data/v60.0/sobjects/ContentVersion/068ak00000AbCdEAAZ"),
+				mockcond.QueryParam("fields", "Title,PathOnClient,FileExtension,ContentSize"),
+			},
+			Then: mockserver.ResponseChainedFuncs(
+				mockserver.ContentJSON(),
+				mockserver.ResponseString(http.StatusOK,
+					`{"Title":"notes","PathOnClient":"notes.txt","FileExtension":"txt","ContentSize":5}`),
+			),
+		}, {
+			If: mockcond.Path("/services/data/v60.0/sobjects/ContentVersion/068ak00000AbCdEAAZ/VersionData"),
+			Then: mockserver.ResponseChainedFuncs(
+				mockserver.ContentMIME("application/octetstream"),
+				mockserver.ResponseString(http.StatusOK, "hello"),
+			),
+		}},
+	}.Server()
+	defer server.Close()
+
+	conn, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct test connector: %v", err)
+	}
+
+	output, err := conn.DownloadFile(t.Context(), common.FileDownloadParams{FileId: "068ak00000AbCdEAAZ"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer output.Content.Close()
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		t.Fatalf("failed to read content: %v", err)
+	}
+
+	if string(content) != "hello" || output.FileName != "notes.txt" || output.Size != 5 ||
+		!strings.HasPrefix(output.ContentType, "text/plain") {
+		t.Fatalf("unexpected download: %q %+v", string(content), output)
+	}
+}
@@ -0,0 +1,279 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/httpkit"
+)
+
+const (
+	// graphID identifies the only graph sent per request.
+	// Salesforce rolls back each graph independently, so one graph keeps the whole write atomic.
+	graphID = "graph"
+
+	// maxGraphNodes is the most nodes a single graph can contain.
+	maxGraphNodes = 500
+
+	errorCodeProcessingHalted = "PROCESSING_HALTED"
+)
+
+// referencePattern matches placeholders such as "@{refAccount.id}" or "@{refAccount.records[0].Id}".
+var referencePattern = regexp.MustCompile(`@\{([^.}\[]+)[.\[]`) // nolint:gochecknoglobals
+
+// CompositeWrite executes nodes as a single Composite Graph with all-or-none semantics.
+// Nodes may reference records written by preceding nodes with placeholders like "@{refAccount.id}",
+// both in record data and in the record ID of an update.
+//
+// nolint:lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_graph_introduction.htm
+func (a *Adapter) CompositeWrite(
+	ctx context.Context, params *common.CompositeWriteParams,
+) (*common.CompositeWriteResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	payload, err := buildGraphPayload(params)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := a.getGraphURL()
+	if err != nil {
+		return nil, err
+	}
+
+	headers := common.TransformWriteHeaders(params.Headers, common.HeaderModeOverwrite)
+
+	rsp, err := a.Client.Post(ctx, url.String(), payload, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[GraphResponse](rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failed graphs are reported with 200 OK, other statuses are returned as errors by the client.
+	if response == nil || len(response.Graphs) == 0 {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	return response.Graphs[0].toCompositeWriteResult(params), nil
+}
+
+// getGraphURL builds the endpoint for executing composite graphs.
+//
+// nolint:lll
+// https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_composite_graph.htm
+func (a *Adapter) getGraphURL() (*urlbuilder.URL, error) {
+	return urlbuilder.New(a.getModuleURL(), restAPISuffix, "/composite/graph")
+}
+
+func buildGraphPayload(params *common.CompositeWriteParams) (*GraphPayload, error) {
+	if len(params.Nodes) > maxGraphNodes {
+		return nil, fmt.Errorf("%w: composite graph allows at most %d nodes, got %d",
+			common.ErrCaller, maxGraphNodes, len(params.Nodes))
+	}
+
+	preceding := make(map[string]bool, len(params.Nodes))
+	nodes := make([]GraphNode, len(params.Nodes))
+
+	for index, node := range params.Nodes {
+		record, err := node.Params.GetRecord()
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", node.ReferenceId, err)
+		}
+
+		if err = checkReferences(preceding, node.Params.RecordId, map[string]any(record)); err != nil {
+			return nil, fmt.Errorf("node %s: %w", node.ReferenceId, err)
+		}
+
+		preceding[node.ReferenceId] = true
+
+		method := http.MethodPost
+		path := restAPISuffix + "/sobjects/" + node.Params.ObjectName
+
+		if node.Params.RecordId != "" {
+			method = http.MethodPatch
+			path += "/" + node.Params.RecordId
+		}
+
+		nodes[index] = GraphNode{
+			URL:         path,
+			Method:      method,
+			ReferenceId: node.ReferenceId,
+			Body:        record,
+		}
+	}
+
+	return &GraphPayload{
+		Graphs: []Graph{{
+			GraphId:          graphID,
+			CompositeRequest: nodes,
+		}},
+	}, nil
+}
+
+// checkReferences ensures every placeholder refers to a node written earlier.
+// Salesforce would otherwise reject the whole graph with a less descriptive error.
+func checkReferences(preceding map[string]bool, values ...any) error {
+	for _, value := range values {
+		switch value := value.(type) {
+		case string:
+			for _, match := range referencePattern.FindAllStringSubmatch(value, -1) {
+				if !preceding[match[1]] {
+					return fmt.Errorf("%w: %s", common.ErrUnresolvedReference, match[0])
+				}
+			}
+		case map[string]any:
+			for _, nested := range value {
+				if err := checkReferences(preceding, nested); err != nil {
+					return err
+				}
+			}
+		case []any:
+			if err := checkReferences(preceding, value...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// GraphPayload is the request body of the Composite Graph endpoint.
+type GraphPayload struct {
+	Graphs []Graph `json:"graphs"`
+}
+
+type Graph struct {
+	GraphId          string      `json:"graphId"`
+	CompositeRequest []GraphNode `json:"compositeRequest"`
+}
+
+type GraphNode struct {
+	URL         string         `json:"url"`
+	Method      string         `json:"method"`
+	ReferenceId string         `json:"referenceId"`
+	Body        map[string]any `json:"body"`
+}
+
+// GraphResponse is returned by the Composite Graph endpoint.
+// Failed graphs are reported with 200 OK as well.
+type GraphResponse struct {
+	Graphs []GraphResult `json:"graphs"`
+}
+
+type GraphResult struct {
+	GraphId       string `json:"graphId"`
+	IsSuccessful  bool   `json:"isSuccessful"`
+	GraphResponse struct {
+		CompositeResponse []GraphNodeResponse `json:"compositeResponse"`
+	} `json:"graphResponse"`
+}
+
+// GraphNodeResponse holds the outcome of a node.
+// Body is a save result for created records, empty for updated records, and a list of errors on failure.
+type GraphNodeResponse struct {
+	Body           any    `json:"body"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+	ReferenceId    string `json:"referenceId"`
+}
+
+func (g GraphResult) toCompositeWriteResult(params *common.CompositeWriteParams) *common.CompositeWriteResult {
+	responses := make(map[string]GraphNodeResponse, len(g.GraphResponse.CompositeResponse))
+	for _, response := range g.GraphResponse.CompositeResponse {
+		responses[response.ReferenceId] = response
+	}
+
+	result := &common.CompositeWriteResult{
+		Success: g.IsSuccessful,
+		Results: make(map[string]*common.WriteResult, len(params.Nodes)),
+		Errors:  nil,
+	}
+
+	for _, node := range params.Nodes {
+		response, ok := responses[node.ReferenceId]
+		delete(responses, node.ReferenceId)
+
+		// Nodes executed before the failure were rolled back, whatever their status says.
+		if !ok || (!g.IsSuccessful && httpkit.Status2xx(response.HTTPStatusCode)) {
+			result.Results[node.ReferenceId] = createUnprocessableItem()
+
+			continue
+		}
+
+		item := response.toWriteResult(node.Params.RecordId)
+		result.Results[node.ReferenceId] = item
+
+		// Nodes halted by the rollback report the unprocessed record error, which explains nothing.
+		if !item.Success && !isUnprocessable(item) {
+			result.Errors = append(result.Errors, item.Errors...)
+		}
+	}
+
+	// Responses which match no node still explain why the graph failed.
+	for _, response := range g.GraphResponse.CompositeResponse {
+		_, unmatched := responses[response.ReferenceId]
+		if unmatched && !httpkit.Status2xx(response.HTTPStatusCode) {
+			result.Errors = append(result.Errors, response.toWriteResult("").Errors...)
+		}
+	}
+
+	return result
+}
+
+func isUnprocessable(result *common.WriteResult) bool {
+	if len(result.Errors) != 1 {
+		return false
+	}
+
+	err, ok := result.Errors[0].(error)
+
+	return ok && errors.Is(err, common.ErrBatchUnprocessedRecord)
+}
+
+func (r GraphNodeResponse) toWriteResult(recordID string) *common.WriteResult {
+	if httpkit.Status2xx(r.HTTPStatusCode) {
+		if body, ok := r.Body.(map[string]any); ok {
+			if identifier, ok := body["id"].(string); ok {
+				recordID = identifier
+			}
+		}
+
+		return &common.WriteResult{
+			Success:  true,
+			RecordId: recordID,
+			Errors:   nil,
+			Data:     nil,
+		}
+	}
+
+	errs, _ := r.Body.([]any)
+
+	// The node was fine, but the transaction was rolled back because of another node.
+	if len(errs) == 1 {
+		if item, ok := errs[0].(map[string]any); ok && item["errorCode"] == errorCodeProcessingHalted {
+			return createUnprocessableItem()
+		}
+	}
+
+	if len(errs) == 0 && r.Body != nil {
+		errs = []any{r.Body}
+	}
+
+	return &common.WriteResult{
+		Success:  false,
+		RecordId: recordID,
+		Errors:   errs,
+		Data:     nil,
+	}
+}
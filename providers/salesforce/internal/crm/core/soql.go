@@ -1,10 +1,15 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/internal/datautils"
 )
 
 // nolint:lll
@@ -14,18 +19,45 @@ const (
 	identifiersLimitStr = "200"
 )
 
+var (
+	// ErrEmptyList is returned for an empty list, SOQL has no syntax for it, ex: "Id IN ()" is rejected.
+	ErrEmptyList = errors.New("SOQL list cannot be empty")
+	// ErrUnsupportedLiteral is returned for values which have no SOQL representation, such as raw bytes.
+	ErrUnsupportedLiteral = errors.New("value cannot be converted into a SOQL literal")
+)
+
+// SortDirection is the order of rows for a field in the ORDER BY clause.
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_orderby.htm
+type SortDirection string
+
+const (
+	SortAscending            SortDirection = "ASC"
+	SortDescending           SortDirection = "DESC"
+	SortAscendingNullsLast   SortDirection = "ASC NULLS LAST"
+	SortDescendingNullsFirst SortDirection = "DESC NULLS FIRST"
+)
+
 // SOQLBuilder builder of Salesforce Object Query Language.
 // It constructs query dynamically.
+//
+// Besides fields of the object, a query may select fields of parent records, ex: "Account.Name",
+// and records of child relationships using subqueries, ex: "(SELECT Id FROM Contacts)".
+// nolint:lll
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_relationships_query_using.htm
 type SOQLBuilder struct {
-	fields string
-	from   string
-	where  []string
-	limit  string
+	fields   []string
+	children []*SOQLBuilder
+	from     string
+	where    []string
+	orderBy  []string
+	limit    string
+	// err is the first condition which couldn't be built, see Err.
+	err error
 }
 
 func (s *SOQLBuilder) SelectFields(fields []string) *SOQLBuilder {
 	if slices.Contains(fields, "*") {
-		s.fields = "FIELDS(ALL)"
+		s.fields = []string{"FIELDS(ALL)"}
 		// if all fields are to be returned then we must limit to avoid error.
 		// Error example: `The SOQL FIELDS function must have a LIMIT of at most 200`
 		s.limit = identifiersLimitStr
@@ -33,7 +65,25 @@ func (s *SOQLBuilder) SelectFields(fields []string) *SOQLBuilder {
 		return s
 	}
 
-	s.fields = strings.Join(fields, ",")
+	s.fields = slices.Clone(fields)
+
+	return s
+}
+
+// SelectParentFields adds fields of the parent record referenced by the relationship.
+// Relationship names may be chained to reach grandparents, ex: "Account.Owner".
+func (s *SOQLBuilder) SelectParentFields(relationship string, fields []string) *SOQLBuilder {
+	for _, field := range fields {
+		s.fields = append(s.fields, relationship+"."+field)
+	}
+
+	return s
+}
+
+// SelectChildren adds a subquery returning child records.
+// The subquery must select from the child relationship name, ex: "Contacts" of an Account.
+func (s *SOQLBuilder) SelectChildren(subquery *SOQLBuilder) *SOQLBuilder {
+	s.children = append(s.children, subquery)
 
 	return s
 }
@@ -60,29 +110,166 @@ func (s *SOQLBuilder) Where(condition string) *SOQLBuilder {
 	return s
 }
 
-func (s *SOQLBuilder) WithIDs(identifiers []string) *SOQLBuilder {
-	// Decorate each id with quotes.
-	for index, id := range identifiers {
-		identifiers[index] = fmt.Sprintf("'%v'", id)
+// WhereField adds a condition comparing the field to the value, which is converted into a SOQL literal.
+// Example: WhereField("Name", "=", "O'Brien") produces "Name = 'O\'Brien'".
+// Slices are converted into a list, to be used with IN and NOT IN operators.
+// Values which cannot be converted leave the condition out and are reported by Err.
+func (s *SOQLBuilder) WhereField(field, operator string, value any) *SOQLBuilder {
+	literal, err := Literal(value)
+	if err != nil {
+		s.fail(fmt.Errorf("condition on %s: %w", field, err))
+
+		return s
 	}
 
-	identifiersList := strings.Join(identifiers, ",")
+	return s.Where(field + " " + operator + " " + literal)
+}
 
+func (s *SOQLBuilder) WithIDs(identifiers []string) *SOQLBuilder {
 	// nolint:lll
 	// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_fields.htm
-	return s.Where(fmt.Sprintf("Id IN (%v)", identifiersList))
+	return s.WhereField("Id", "IN", identifiers)
+}
+
+// Err returns the first condition of the query, or of its subqueries, which couldn't be built.
+// The query must not be run if there is an error, since the condition is missing.
+func (s *SOQLBuilder) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, child := range s.children {
+		if err := child.Err(); err != nil {
+			return fmt.Errorf("subquery of %s: %w", child.from, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SOQLBuilder) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// OrderBy sorts rows by the field. Calls are cumulative, first field has the highest priority.
+func (s *SOQLBuilder) OrderBy(field string, direction SortDirection) *SOQLBuilder {
+	s.orderBy = append(s.orderBy, field+" "+string(direction))
+
+	return s
+}
+
+// Fields returns selected fields, including fields of parent records. Subqueries are excluded.
+func (s *SOQLBuilder) Fields() []string {
+	return slices.Clone(s.fields)
+}
+
+// Children returns names of child relationships queried by subqueries.
+func (s *SOQLBuilder) Children() []string {
+	names := make([]string, len(s.children))
+	for index, child := range s.children {
+		names[index] = child.from
+	}
+
+	return names
 }
 
 func (s *SOQLBuilder) String() string {
-	query := fmt.Sprintf("SELECT %s FROM %s", s.fields, s.from)
+	selection := slices.Clone(s.fields)
+	for _, child := range s.children {
+		selection = append(selection, "("+child.String()+")")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selection, ","), s.from)
 
 	if len(s.where) != 0 {
 		query += " WHERE " + strings.Join(s.where, " AND ")
 	}
 
+	if len(s.orderBy) != 0 {
+		query += " ORDER BY " + strings.Join(s.orderBy, ",")
+	}
+
 	if len(s.limit) != 0 {
 		query += " LIMIT " + s.limit
 	}
 
 	return query
 }
+
+// Literal converts a Go value into a SOQL literal.
+// Strings are quoted and escaped, numbers are written as is, time is formatted as a dateTime in UTC,
+// nil becomes null.
+// Slices and arrays become a parenthesised list of literals, they must not be empty.
+// Byte slices are rejected, there is no binary literal. Any other value is treated as a string.
+// nolint:lll
+// https://developer.salesforce.com/docs/atlas.en-us.soql_sosl.meta/soql_sosl/sforce_api_calls_soql_select_quotedstringescapes.htm
+func Literal(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "null", nil
+	case time.Time:
+		return datautils.Time.FormatRFC3339inUTC(value), nil
+	}
+
+	// Kinds rather than types are matched, so that named types and every numeric size are covered.
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() { // nolint:exhaustive
+	case reflect.String:
+		return QuoteString(reflected.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(reflected.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflected.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(reflected.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, reflected.Type().Bits()), nil
+	case reflect.Slice, reflect.Array:
+		return literalList(reflected)
+	default:
+		return QuoteString(fmt.Sprint(value)), nil
+	}
+}
+
+func literalList(values reflect.Value) (string, error) {
+	if values.Type().Elem().Kind() == reflect.Uint8 {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedLiteral, values.Type())
+	}
+
+	if values.Len() == 0 {
+		return "", ErrEmptyList
+	}
+
+	literals := make([]string, values.Len())
+
+	for index := range literals {
+		literal, err := Literal(values.Index(index).Interface())
+		if err != nil {
+			return "", err
+		}
+
+		literals[index] = literal
+	}
+
+	return "(" + strings.Join(literals, ",") + ")", nil
+}
+
+// nolint:gochecknoglobals
+var stringEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"\b", `\b`,
+	"\f", `\f`,
+)
+
+// QuoteString returns the value as a quoted SOQL string literal with reserved characters escaped.
+func QuoteString(value string) string {
+	return "'" + stringEscaper.Replace(value) + "'"
+}
@@ -0,0 +1,246 @@
+package custom
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+)
+
+// Retrieval and deployment run asynchronously on Salesforce side.
+// Both are started with one call, then their status is checked until they are done.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_deploy.htm
+
+const (
+	defaultPollInterval = 2 * time.Second
+	// defaultPollTimeout is long enough for deployments running every test of an org.
+	defaultPollTimeout = time.Hour
+
+	deployStatusSucceededPartial = "SucceededPartial"
+)
+
+var (
+	ErrRetrieveFailed = errors.New("metadata: retrieve failed")
+	ErrDeployFailed   = errors.New("metadata: deploy failed")
+	ErrPollTimeout    = errors.New("metadata: operation did not finish in time")
+)
+
+// Test levels of a deployment. When omitted, Salesforce picks one depending on the org type.
+const (
+	TestLevelNoTestRun         = "NoTestRun"
+	TestLevelRunSpecifiedTests = "RunSpecifiedTests"
+	TestLevelRunLocalTests     = "RunLocalTests"
+	TestLevelRunAllTestsInOrg  = "RunAllTestsInOrg"
+)
+
+// DeployOptions control how a package is deployed.
+// Deployments are all or nothing, any failing component rolls back the whole package,
+// unless partial success is allowed.
+type DeployOptions struct {
+	// CheckOnly validates the package without saving any change.
+	CheckOnly bool
+	// IgnoreWarnings lets the deployment succeed even if some components produced warnings.
+	IgnoreWarnings bool
+	// AllowPartialSuccess keeps the components which were deployed when others fail.
+	// Failures are then listed in DeployResult.Problems. Production orgs reject this option.
+	AllowPartialSuccess bool
+	// TestLevel is one of TestLevel constants.
+	TestLevel string
+	// RunTests lists Apex test classes to run with TestLevelRunSpecifiedTests.
+	RunTests []string
+	// PollInterval is the delay between status checks. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// Timeout limits how long the deployment is awaited, ErrPollTimeout is returned afterwards.
+	// Defaults to 1 hour. The deployment itself keeps running on Salesforce side.
+	Timeout time.Duration
+}
+
+// DeployResult describes a finished deployment.
+type DeployResult struct {
+	ID                 string
+	Status             string
+	CheckOnly          bool
+	ComponentsDeployed int
+	ComponentsTotal    int
+	// Problems lists components which failed to deploy, when partial success is allowed.
+	Problems []string
+}
+
+// RetrieveOptions control how a package is retrieved.
+type RetrieveOptions struct {
+	// PollInterval is the delay between status checks. Defaults to 2 seconds.
+	PollInterval time.Duration
+	// Timeout limits how long the retrieval is awaited, ErrPollTimeout is returned afterwards. Defaults to 1 hour.
+	Timeout time.Duration
+}
+
+// Retrieve downloads the listed components as a package.
+// Components that cannot be retrieved don't fail the call, they are listed in MetadataPackage.Problems.
+func (a *Adapter) Retrieve(
+	ctx context.Context, components []MetadataComponent, options RetrieveOptions,
+) (*MetadataPackage, error) {
+	if len(components) == 0 {
+		return nil, ErrPackageEmpty
+	}
+
+	started, err := performMetadataAPICall[RetrieveResponse](ctx, a, NewRetrievePayload(components))
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := waitUntilDone(ctx, options.PollInterval, options.Timeout,
+		func(ctx context.Context) (*RetrieveStatus, bool, error) {
+			response, err := performMetadataAPICall[CheckRetrieveStatusResponse](ctx, a, &CheckRetrieveStatusPayload{
+				AsyncProcessID: started.Result.ID,
+				IncludeZip:     true,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			return &response.Result, response.Result.Done, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Success {
+		return nil, fmt.Errorf("%w: %v %v", ErrRetrieveFailed, status.ErrorStatusCode, status.ErrorMessage)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(status.ZipFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPackageInvalid, err)
+	}
+
+	pkg, err := UnzipPackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range status.Messages {
+		pkg.Problems = append(pkg.Problems, fmt.Sprintf("%v: %v", message.FileName, message.Problem))
+	}
+
+	return pkg, nil
+}
+
+// Deploy uploads the package and waits for the deployment to finish.
+// Failures of individual components are joined into a single error.
+func (a *Adapter) Deploy(ctx context.Context, pkg MetadataPackage, options DeployOptions) (*DeployResult, error) {
+	zipFile, err := pkg.Zip()
+	if err != nil {
+		return nil, err
+	}
+
+	started, err := performMetadataAPICall[DeployResponse](ctx, a, NewDeployPayload(zipFile, options))
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := waitUntilDone(ctx, options.PollInterval, options.Timeout,
+		func(ctx context.Context) (*DeployStatus, bool, error) {
+			response, err := performMetadataAPICall[CheckDeployStatusResponse](ctx, a, &CheckDeployStatusPayload{
+				AsyncProcessID: started.Result.ID,
+				IncludeDetails: true,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			return &response.Result, response.Result.Done, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Success && status.Status != deployStatusSucceededPartial {
+		return nil, deployError(status)
+	}
+
+	result := &DeployResult{
+		ID:                 status.ID,
+		Status:             status.Status,
+		CheckOnly:          status.CheckOnly,
+		ComponentsDeployed: status.NumberComponentsDeployed,
+		ComponentsTotal:    status.NumberComponentsTotal,
+	}
+
+	if status.Status == deployStatusSucceededPartial {
+		result.Problems = deployProblems(status)
+	}
+
+	return result, nil
+}
+
+func deployError(status *DeployStatus) error {
+	messages := deployProblems(status)
+
+	return fmt.Errorf("%w: %v: %w: %v", ErrDeployFailed, status.Status,
+		common.ErrBadRequest, strings.Join(messages, "; "))
+}
+
+// deployProblems lists the error of the deployment and failures of its components, sorted.
+func deployProblems(status *DeployStatus) []string {
+	messages := make([]string, 0, len(status.Details.ComponentFailures)+1)
+
+	if status.ErrorMessage != "" {
+		messages = append(messages, status.ErrorMessage)
+	}
+
+	for _, failure := range status.Details.ComponentFailures {
+		messages = append(messages, fmt.Sprintf("%v %v: %v", failure.ComponentType, failure.FullName, failure.Problem))
+	}
+
+	slices.Sort(messages)
+
+	return messages
+}
+
+// waitUntilDone repeats the check until it reports completion, pausing between attempts.
+// It gives up with ErrPollTimeout once the timeout elapses, even if the context has no deadline.
+func waitUntilDone[R any](
+	ctx context.Context, interval, timeout time.Duration, check func(ctx context.Context) (*R, bool, error),
+) (*R, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	if timeout <= 0 {
+		timeout = defaultPollTimeout
+	}
+
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, ErrPollTimeout)
+	defer cancel()
+
+	for {
+		result, done, err := check(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				// The check was interrupted, report why.
+				return nil, context.Cause(ctx)
+			}
+
+			return nil, err
+		}
+
+		if done {
+			return result, nil
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, context.Cause(ctx)
+		case <-timer.C:
+		}
+	}
+}
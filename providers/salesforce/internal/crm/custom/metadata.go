@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/metadatadiff"
 	"github.com/amp-labs/connectors/internal/datautils"
 )
 
@@ -55,7 +57,7 @@ func (a *Adapter) UpsertMetadata(
 	}
 
 	// Step 3: Fetch the existing FieldPermissions defined in the Ampersand Permission Set.
-	existingFieldPermissions, err := a.fetchFieldPermissions(ctx)
+	permissionSet, err := a.fetchPermissionSet(ctx)
 	if err != nil {
 		return nil, err // [Current state]: Fields upserted, but optional ones remain invisible.
 	}
@@ -63,7 +65,7 @@ func (a *Adapter) UpsertMetadata(
 	// Step 4: Merge new optional field permissions with the existing set.
 	// This ensures existing permissions are preserved and new fields are appended.
 	combinedPermissions := datautils.MergeMaps(
-		existingFieldPermissions,
+		permissionSet.GetFieldPermissions(),
 		optionalFields,
 	)
 
@@ -90,6 +92,42 @@ func (a *Adapter) UpsertMetadata(
 	}
 
 	// [Current state]: Fields upserted, permission set updated, and current user is assigned to the permission set.
+	result.PermissionSets = describePermissionSetChanges(permissionSet, optionalFields)
+
+	return result, nil
+}
+
+// PlanUpsertMetadata reports what UpsertMetadata would change without changing anything.
+//
+// Field actions are derived from the current object metadata, see metadatadiff.PlanUpsert.
+// The Ampersand Permission Set is read to find which optional fields it would be extended with.
+// Assignment of the Permission Set to the current user is repeated on every run, and is not part of the plan.
+func (a *Adapter) PlanUpsertMetadata(
+	ctx context.Context, params *common.UpsertMetadataParams, current *common.ListObjectMetadataResult,
+) (*common.UpsertMetadataResult, error) {
+	result, err := metadatadiff.PlanUpsert(params, current)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payload is not sent, building it validates field definitions the same way a real run would.
+	payload, err := NewCustomFieldsPayload(params)
+	if err != nil {
+		return nil, err
+	}
+
+	optionalFields := payload.getOptionalFields()
+	if len(optionalFields) == 0 {
+		return result, nil
+	}
+
+	permissionSet, err := a.fetchPermissionSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result.PermissionSets = describePermissionSetChanges(permissionSet, optionalFields)
+
 	return result, nil
 }
 
@@ -114,15 +152,43 @@ func (a *Adapter) upsertCustomFields(
 	return result, payload.getOptionalFields(), nil
 }
 
-func (a *Adapter) fetchFieldPermissions(ctx context.Context) (FieldPermissions, error) {
+func (a *Adapter) fetchPermissionSet(ctx context.Context) (*PermissionSetResponse, error) {
 	payload := NewReadPermissionSetPayload()
 
-	permissionSetBody, err := performMetadataAPICall[PermissionSetResponse](ctx, a, payload)
-	if err != nil {
-		return nil, err
+	return performMetadataAPICall[PermissionSetResponse](ctx, a, payload)
+}
+
+// describePermissionSetChanges lists optional fields which are not yet visible through the Permission Set.
+func describePermissionSetChanges(
+	permissionSet *PermissionSetResponse, optionalFields FieldPermissions,
+) map[string]common.PermissionSetUpsertResult {
+	existing := permissionSet.GetFieldPermissions()
+	granted := make([]string, 0)
+
+	for name := range optionalFields {
+		if permission, ok := existing[name]; !ok || !permission.Readable || !permission.Editable {
+			granted = append(granted, name)
+		}
+	}
+
+	slices.Sort(granted)
+
+	action := common.UpsertMetadataActionNone
+
+	switch {
+	case !permissionSet.Exists():
+		action = common.UpsertMetadataActionCreate
+	case len(granted) != 0:
+		action = common.UpsertMetadataActionUpdate
 	}
 
-	return permissionSetBody.GetFieldPermissions(), nil
+	return map[string]common.PermissionSetUpsertResult{
+		DefaultPermissionSetName: {
+			Name:          DefaultPermissionSetName,
+			Action:        action,
+			GrantedFields: granted,
+		},
+	}
 }
 
 func (a *Adapter) upsertPermissionSet(ctx context.Context, permissions FieldPermissions) error {
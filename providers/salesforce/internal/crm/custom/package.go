@@ -0,0 +1,258 @@
+package custom
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// Metadata other than custom fields, such as validation rules, record types, layouts and value sets,
+// is moved in zip packages by the retrieve and deploy calls of the Metadata API.
+// Every package has a package.xml manifest at its root, which lists the components it holds.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/file_based_zip_file.htm
+
+const (
+	packageManifestName = "package.xml"
+	metadataNamespace   = "http://soap.sforce.com/2006/04/metadata"
+)
+
+// Metadata types commonly managed together with custom fields.
+// Child components, such as validation rules and record types, are stored in the file of their object,
+// ex: "objects/Account.object", while the manifest lists them individually.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_types_list.htm
+const (
+	ComponentTypeCustomObject     = "CustomObject"
+	ComponentTypeCustomField      = "CustomField"
+	ComponentTypeValidationRule   = "ValidationRule"
+	ComponentTypeRecordType       = "RecordType"
+	ComponentTypeLayout           = "Layout"
+	ComponentTypeGlobalValueSet   = "GlobalValueSet"
+	ComponentTypeStandardValueSet = "StandardValueSet"
+)
+
+var (
+	ErrPackageEmpty   = errors.New("metadata: package has no components")
+	ErrPackageInvalid = errors.New("metadata: invalid package")
+)
+
+// MetadataComponent identifies a single component of the Metadata API.
+type MetadataComponent struct {
+	// Type is the metadata type, ex: ValidationRule.
+	Type string
+	// FullName of child components is prefixed with the object name, ex: "Account.Require_Phone".
+	// Layouts are named after the object and the layout, ex: "Account-Account Layout".
+	// Retrieve accepts "*" to select every component of the type, where the type supports it.
+	FullName string
+}
+
+// MetadataPackage is the content of a zip file exchanged with retrieve and deploy.
+type MetadataPackage struct {
+	// Components are listed by the package.xml manifest.
+	Components []MetadataComponent
+	// Files maps the path within the package to the file content, ex: "objects/Account.object".
+	// The manifest is generated from components and is never part of the files.
+	Files map[string][]byte
+	// Problems are reported by retrieve for components that could not be included,
+	// ex: a requested validation rule which doesn't exist.
+	Problems []string
+}
+
+// packageManifest is the package.xml file.
+type packageManifest struct {
+	XMLName   xml.Name `xml:"Package"`
+	Namespace string   `xml:"xmlns,attr"`
+
+	packageContent
+}
+
+// packageContent is shared between the manifest file and the retrieve request.
+type packageContent struct {
+	Types   []packageTypeMembers `xml:"types"`
+	Version string               `xml:"version"`
+}
+
+type packageTypeMembers struct {
+	Members []string `xml:"members"`
+	Name    string   `xml:"name"`
+}
+
+// newPackageContent groups components by type. Types and members are sorted.
+func newPackageContent(components []MetadataComponent) packageContent {
+	registry := make(map[string][]string)
+
+	for _, component := range components {
+		registry[component.Type] = append(registry[component.Type], component.FullName)
+	}
+
+	typeNames := datautils.FromMap(registry).Keys()
+	slices.Sort(typeNames)
+
+	types := make([]packageTypeMembers, 0, len(typeNames))
+
+	for _, name := range typeNames {
+		members := slices.Compact(slices.Sorted(slices.Values(registry[name])))
+		types = append(types, packageTypeMembers{
+			Members: members,
+			Name:    name,
+		})
+	}
+
+	return packageContent{
+		Types:   types,
+		Version: apiVersion,
+	}
+}
+
+func (c packageContent) components() []MetadataComponent {
+	components := make([]MetadataComponent, 0)
+
+	for _, group := range c.Types {
+		for _, member := range group.Members {
+			components = append(components, MetadataComponent{
+				Type:     group.Name,
+				FullName: member,
+			})
+		}
+	}
+
+	return components
+}
+
+// Manifest returns the package.xml listing package components.
+func (p MetadataPackage) Manifest() ([]byte, error) {
+	if len(p.Components) == 0 {
+		return nil, ErrPackageEmpty
+	}
+
+	data, err := xml.MarshalIndent(packageManifest{
+		Namespace:      metadataNamespace,
+		packageContent: newPackageContent(p.Components),
+	}, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMetadataMarshal, err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Zip packs the manifest and the files into an archive accepted by deploy.
+// Files are written in the order of their paths, so the same package always produces the same archive.
+func (p MetadataPackage) Zip() ([]byte, error) {
+	manifest, err := p.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	archive := zip.NewWriter(&buffer)
+
+	if err = writeZipFile(archive, packageManifestName, manifest); err != nil {
+		return nil, err
+	}
+
+	names := datautils.FromMap(p.Files).Keys()
+	slices.Sort(names)
+
+	for _, name := range names {
+		if name == packageManifestName {
+			continue
+		}
+
+		if err = writeZipFile(archive, name, p.Files[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func writeZipFile(archive *zip.Writer, name string, content []byte) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(content)
+
+	return err
+}
+
+// UnzipPackage reads an archive returned by retrieve. Components are taken from its manifest.
+// Archives which place the package in a folder, ex: "unpackaged/package.xml", are read relative to that folder.
+func UnzipPackage(data []byte) (*MetadataPackage, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPackageInvalid, err)
+	}
+
+	files := make(map[string][]byte, len(archive.File))
+	root := ""
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if path.Base(file.Name) == packageManifestName && (root == "" || len(path.Dir(file.Name)) < len(root)) {
+			root = path.Dir(file.Name)
+		}
+
+		files[file.Name] = content
+	}
+
+	if root == "" {
+		return nil, fmt.Errorf("%w: %v is missing", ErrPackageInvalid, packageManifestName)
+	}
+
+	var manifest packageManifest
+	if err = xml.Unmarshal(files[path.Join(root, packageManifestName)], &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPackageInvalid, err)
+	}
+
+	pkg := &MetadataPackage{
+		Components: manifest.components(),
+		Files:      make(map[string][]byte, len(files)),
+	}
+
+	for name, content := range files {
+		relative := name
+		if root != "." {
+			relative = strings.TrimPrefix(name, root+"/")
+		}
+
+		if relative != packageManifestName {
+			pkg.Files[relative] = content
+		}
+	}
+
+	return pkg, nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPackageInvalid, err)
+	}
+
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
@@ -1,6 +1,7 @@
 package custom
 
 import (
+	"encoding/base64"
 	"encoding/xml"
 	"fmt"
 	"strconv"
@@ -396,3 +397,75 @@ type MetadataPermissionSet struct {
 	// Fields
 	Fields []FieldPermission `xml:"fieldPermissions"`
 }
+
+// RetrievePayload starts an asynchronous retrieval of the listed components.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_retrieve_request.htm
+type RetrievePayload struct {
+	XMLName xml.Name        `xml:"retrieve"`
+	Request RetrieveRequest `xml:"retrieveRequest"`
+}
+
+type RetrieveRequest struct {
+	APIVersion    string         `xml:"apiVersion"`
+	SinglePackage bool           `xml:"singlePackage"`
+	Unpackaged    packageContent `xml:"unpackaged"`
+}
+
+func NewRetrievePayload(components []MetadataComponent) *RetrievePayload {
+	return &RetrievePayload{
+		Request: RetrieveRequest{
+			APIVersion:    apiVersion,
+			SinglePackage: true,
+			Unpackaged:    newPackageContent(components),
+		},
+	}
+}
+
+// CheckRetrieveStatusPayload asks for the state of a retrieval and, once it is done, for the zip file.
+type CheckRetrieveStatusPayload struct {
+	XMLName        xml.Name `xml:"checkRetrieveStatus"`
+	AsyncProcessID string   `xml:"asyncProcessId"`
+	IncludeZip     bool     `xml:"includeZip"`
+}
+
+// DeployPayload starts an asynchronous deployment of a zip package.
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_deploy.htm
+type DeployPayload struct {
+	XMLName xml.Name             `xml:"deploy"`
+	ZipFile string               `xml:"ZipFile"`
+	Options DeployRequestOptions `xml:"DeployOptions"`
+}
+
+// DeployRequestOptions fields can be found here:
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_deploy.htm#deploy_options
+type DeployRequestOptions struct {
+	CheckOnly       bool     `xml:"checkOnly"`
+	IgnoreWarnings  bool     `xml:"ignoreWarnings"`
+	RollbackOnError bool     `xml:"rollbackOnError"`
+	RunTests        []string `xml:"runTests,omitempty"`
+	SinglePackage   bool     `xml:"singlePackage"`
+	TestLevel       string   `xml:"testLevel,omitempty"`
+}
+
+func NewDeployPayload(zipFile []byte, options DeployOptions) *DeployPayload {
+	return &DeployPayload{
+		ZipFile: base64.StdEncoding.EncodeToString(zipFile),
+		Options: DeployRequestOptions{
+			CheckOnly:       options.CheckOnly,
+			IgnoreWarnings:  options.IgnoreWarnings,
+			RollbackOnError: !options.AllowPartialSuccess,
+			RunTests:        options.RunTests,
+			// Describes the layout of the archive rather than a choice,
+			// MetadataPackage.Zip places the manifest at the root, as a single package.
+			SinglePackage: true,
+			TestLevel:     options.TestLevel,
+		},
+	}
+}
+
+// CheckDeployStatusPayload asks for the state of a deployment including component failures.
+type CheckDeployStatusPayload struct {
+	XMLName        xml.Name `xml:"checkDeployStatus"`
+	AsyncProcessID string   `xml:"asyncProcessId"`
+	IncludeDetails bool     `xml:"includeDetails"`
+}
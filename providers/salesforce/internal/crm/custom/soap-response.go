@@ -141,6 +141,19 @@ func (r PermissionSetResponse) GetFieldPermissions() FieldPermissions {
 	return fieldPermissions
 }
 
+// Exists returns true if the default Ampersand-managed permission set was found.
+func (r PermissionSetResponse) Exists() bool {
+	for _, result := range r.Response.Results {
+		for _, record := range result.Records {
+			if !record.IsNil && record.XSIType == PermissionSetType && record.FullName == DefaultPermissionSetName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 type PermissionSet struct {
 	XSIType          string            `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
 	IsNil            bool              `xml:"http://www.w3.org/2001/XMLSchema-instance nil,attr"`
@@ -158,3 +171,72 @@ type FieldPermission struct {
 	Readable bool   `xml:"readable"`
 	Editable bool   `xml:"editable"`
 }
+
+type RetrieveResponse struct {
+	Result AsyncResult `xml:"retrieveResponse>result"`
+}
+
+type DeployResponse struct {
+	Result AsyncResult `xml:"deployResponse>result"`
+}
+
+// AsyncResult identifies a started retrieval or deployment.
+type AsyncResult struct {
+	Done  bool   `xml:"done"`
+	ID    string `xml:"id"`
+	State string `xml:"state"`
+}
+
+type CheckRetrieveStatusResponse struct {
+	Result RetrieveStatus `xml:"checkRetrieveStatusResponse>result"`
+}
+
+// RetrieveStatus fields can be found here:
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_retrieveresult.htm
+type RetrieveStatus struct {
+	Done            bool              `xml:"done"`
+	ErrorMessage    string            `xml:"errorMessage"`
+	ErrorStatusCode string            `xml:"errorStatusCode"`
+	ID              string            `xml:"id"`
+	Messages        []RetrieveMessage `xml:"messages"`
+	Status          string            `xml:"status"`
+	Success         bool              `xml:"success"`
+	// ZipFile is base64 encoded.
+	ZipFile string `xml:"zipFile"`
+}
+
+type RetrieveMessage struct {
+	FileName string `xml:"fileName"`
+	Problem  string `xml:"problem"`
+}
+
+type CheckDeployStatusResponse struct {
+	Result DeployStatus `xml:"checkDeployStatusResponse>result"`
+}
+
+// DeployStatus fields can be found here:
+// https://developer.salesforce.com/docs/atlas.en-us.api_meta.meta/api_meta/meta_deployresult.htm
+type DeployStatus struct {
+	CheckOnly                bool   `xml:"checkOnly"`
+	Done                     bool   `xml:"done"`
+	ErrorMessage             string `xml:"errorMessage"`
+	ErrorStatusCode          string `xml:"errorStatusCode"`
+	ID                       string `xml:"id"`
+	NumberComponentErrors    int    `xml:"numberComponentErrors"`
+	NumberComponentsDeployed int    `xml:"numberComponentsDeployed"`
+	NumberComponentsTotal    int    `xml:"numberComponentsTotal"`
+	Status                   string `xml:"status"`
+	Success                  bool   `xml:"success"`
+	Details                  struct {
+		ComponentFailures []ComponentMessage `xml:"componentFailures"`
+	} `xml:"details"`
+}
+
+// ComponentMessage describes the outcome of deploying a single component.
+type ComponentMessage struct {
+	ComponentType string `xml:"componentType"`
+	FileName      string `xml:"fileName"`
+	FullName      string `xml:"fullName"`
+	Problem       string `xml:"problem"`
+	ProblemType   string `xml:"problemType"`
+}
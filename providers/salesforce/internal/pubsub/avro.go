@@ -0,0 +1,385 @@
+package pubsub
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+var (
+	ErrInvalidSchema = errors.New("invalid avro schema")
+	ErrInvalidBitmap = errors.New("invalid field bitmap")
+)
+
+const (
+	keyChangeEventHeader = "ChangeEventHeader"
+	bitmapPrefix         = "0x"
+	bitmapNestedSep      = "-"
+)
+
+// bitmapFields are the ChangeEventHeader properties which list fields as bitmaps.
+// https://developer.salesforce.com/docs/platform/pub-sub-api/guide/event-deserialization-considerations.html
+var bitmapFields = []string{"changedFields", "nulledFields", "diffFields"} // nolint:gochecknoglobals
+
+// Schema decodes event payloads written with a topic's Avro schema.
+type Schema struct {
+	codec *goavro.Codec
+	root  *avroType
+}
+
+// ParseSchema prepares the schema returned by GetSchema for decoding.
+func ParseSchema(schemaJSON string) (*Schema, error) {
+	codec, err := goavro.NewCodec(schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	var definition any
+	if err = json.Unmarshal([]byte(schemaJSON), &definition); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	root, err := newSchemaParser().parse(definition, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if root.kind != kindRecord {
+		return nil, fmt.Errorf("%w: event schema must be a record, got %q", ErrInvalidSchema, root.name)
+	}
+
+	return &Schema{codec: codec, root: root}, nil
+}
+
+// Decode converts a binary Avro payload into plain JSON-like values.
+// Union values are unwrapped, so optional fields hold their value or nil.
+// Field bitmaps of the change event header are replaced by field names,
+// where nested fields of compound fields are named "Parent.Child".
+func (s *Schema) Decode(payload []byte) (map[string]any, error) {
+	native, _, err := s.codec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := s.root.unwrap(native).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: payload is not a record", ErrInvalidSchema)
+	}
+
+	header, ok := record[keyChangeEventHeader].(map[string]any)
+	if !ok {
+		return record, nil
+	}
+
+	for _, key := range bitmapFields {
+		bitmaps, ok := header[key].([]any)
+		if !ok {
+			continue
+		}
+
+		header[key], err = s.root.fieldNames(bitmaps)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return record, nil
+}
+
+type avroKind int
+
+const (
+	kindPrimitive avroKind = iota
+	kindRecord
+	kindUnion
+	kindArray
+	kindMap
+)
+
+// avroType is a resolved schema node. Named types are shared, so recursive schemas form cycles.
+type avroType struct {
+	kind avroKind
+	// name is what goavro uses to label union branches:
+	// full name for named types, "type.logicalType" or "type" for the rest.
+	name     string
+	fields   []avroField // record
+	branches []*avroType // union
+	items    *avroType   // array items and map values
+}
+
+type avroField struct {
+	name string
+	typ  *avroType
+}
+
+type schemaParser struct {
+	named map[string]*avroType
+}
+
+func newSchemaParser() *schemaParser {
+	return &schemaParser{named: make(map[string]*avroType)}
+}
+
+func (p *schemaParser) parse(definition any, namespace string) (*avroType, error) {
+	switch definition := definition.(type) {
+	case string:
+		if named, ok := p.named[fullName(definition, namespace)]; ok {
+			return named, nil
+		}
+
+		if named, ok := p.named[definition]; ok {
+			return named, nil
+		}
+
+		return &avroType{kind: kindPrimitive, name: definition}, nil
+	case []any:
+		union := &avroType{kind: kindUnion, name: "union"}
+
+		for _, branch := range definition {
+			typ, err := p.parse(branch, namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			union.branches = append(union.branches, typ)
+		}
+
+		return union, nil
+	case map[string]any:
+		return p.parseComplex(definition, namespace)
+	default:
+		return nil, fmt.Errorf("%w: unexpected definition %v", ErrInvalidSchema, definition)
+	}
+}
+
+func (p *schemaParser) parseComplex(definition map[string]any, namespace string) (*avroType, error) {
+	typeName, _ := definition["type"].(string)
+	name, _ := definition["name"].(string)
+
+	if ns, ok := definition["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	switch typeName {
+	case "record", "error":
+		record := &avroType{kind: kindRecord, name: fullName(name, namespace)}
+		p.named[record.name] = record
+
+		// Nested named types inherit the namespace of the record's full name.
+		if index := strings.LastIndex(record.name, "."); index >= 0 {
+			namespace = record.name[:index]
+		}
+
+		fields, _ := definition["fields"].([]any)
+		for _, fieldDefinition := range fields {
+			field, ok := fieldDefinition.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: record %q has invalid field", ErrInvalidSchema, record.name)
+			}
+
+			fieldName, _ := field["name"].(string)
+
+			typ, err := p.parse(field["type"], namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			record.fields = append(record.fields, avroField{name: fieldName, typ: typ})
+		}
+
+		return record, nil
+	case "enum", "fixed":
+		named := &avroType{kind: kindPrimitive, name: fullName(name, namespace)}
+		p.named[named.name] = named
+
+		return named, nil
+	case "array", "map":
+		key := "items"
+		kind := kindArray
+
+		if typeName == "map" {
+			key = "values"
+			kind = kindMap
+		}
+
+		items, err := p.parse(definition[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		return &avroType{kind: kind, name: typeName, items: items}, nil
+	default:
+		if logicalType, ok := definition["logicalType"].(string); ok {
+			return &avroType{kind: kindPrimitive, name: typeName + "." + logicalType}, nil
+		}
+
+		return p.parse(definition["type"], namespace)
+	}
+}
+
+func fullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+
+	return namespace + "." + name
+}
+
+// unwrap removes union wrappers produced by goavro, which look like {"string": "value"}.
+func (t *avroType) unwrap(value any) any { // nolint:cyclop
+	switch t.kind {
+	case kindRecord:
+		record, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+
+		result := make(map[string]any, len(record))
+		for _, field := range t.fields {
+			result[field.name] = field.typ.unwrap(record[field.name])
+		}
+
+		return result
+	case kindUnion:
+		wrapper, ok := value.(map[string]any)
+		if !ok || len(wrapper) != 1 {
+			return value
+		}
+
+		for branchName, branchValue := range wrapper {
+			for _, branch := range t.branches {
+				if branch.name == branchName {
+					return branch.unwrap(branchValue)
+				}
+			}
+		}
+
+		return value
+	case kindArray:
+		list, ok := value.([]any)
+		if !ok {
+			return value
+		}
+
+		result := make([]any, len(list))
+		for index, item := range list {
+			result[index] = t.items.unwrap(item)
+		}
+
+		return result
+	case kindMap:
+		dictionary, ok := value.(map[string]any)
+		if !ok {
+			return value
+		}
+
+		result := make(map[string]any, len(dictionary))
+		for key, item := range dictionary {
+			result[key] = t.items.unwrap(item)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// record returns the record type itself or the record branch of an optional record.
+func (t *avroType) record() *avroType {
+	if t.kind == kindRecord {
+		return t
+	}
+
+	for _, branch := range t.branches {
+		if branch.kind == kindRecord {
+			return branch
+		}
+	}
+
+	return nil
+}
+
+// fieldNames translates bitmaps into field names. Bitmaps come in two forms:
+//   - "0x..." where each bit set is the position of a top level field;
+//   - "N-0x..." where bits refer to nested fields of the compound field at position N.
+//
+// Any other value is considered to be a field name already.
+func (t *avroType) fieldNames(bitmaps []any) ([]any, error) {
+	names := make([]any, 0, len(bitmaps))
+
+	for _, entry := range bitmaps {
+		bitmap, ok := entry.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBitmap, entry)
+		}
+
+		switch {
+		case strings.HasPrefix(bitmap, bitmapPrefix):
+			fields, err := t.fieldsFromBitmap(bitmap, "")
+			if err != nil {
+				return nil, err
+			}
+
+			names = append(names, fields...)
+		case strings.Contains(bitmap, bitmapNestedSep+bitmapPrefix):
+			position, nested, _ := strings.Cut(bitmap, bitmapNestedSep)
+
+			index, err := strconv.Atoi(position)
+			if err != nil || index < 0 || index >= len(t.fields) {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidBitmap, bitmap)
+			}
+
+			parent := t.fields[index]
+
+			record := parent.typ.record()
+			if record == nil {
+				return nil, fmt.Errorf("%w: field %q is not compound", ErrInvalidBitmap, parent.name)
+			}
+
+			fields, err := record.fieldsFromBitmap(nested, parent.name+".")
+			if err != nil {
+				return nil, err
+			}
+
+			names = append(names, fields...)
+		default:
+			names = append(names, bitmap)
+		}
+	}
+
+	return names, nil
+}
+
+// fieldsFromBitmap reads the hex bitmap, where the least significant bit stands for the first field.
+func (t *avroType) fieldsFromBitmap(bitmap, prefix string) ([]any, error) {
+	digits := strings.TrimPrefix(bitmap, bitmapPrefix)
+	if len(digits)%2 == 1 {
+		digits = "0" + digits
+	}
+
+	data, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBitmap, bitmap)
+	}
+
+	names := make([]any, 0)
+
+	for position := range len(data) * 8 { // nolint:mnd
+		if data[len(data)-1-position/8]&(1<<(position%8)) == 0 {
+			continue
+		}
+
+		if position >= len(t.fields) {
+			return nil, fmt.Errorf("%w: %q has no field at position %d", ErrInvalidBitmap, bitmap, position)
+		}
+
+		names = append(names, prefix+t.fields[position].name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,748 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pubsub_api.proto
+
+package pubsub
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReplayPreset int32
+
+const (
+	ReplayPreset_LATEST   ReplayPreset = 0
+	ReplayPreset_EARLIEST ReplayPreset = 1
+	ReplayPreset_CUSTOM   ReplayPreset = 2
+)
+
+// Enum value maps for ReplayPreset.
+var (
+	ReplayPreset_name = map[int32]string{
+		0: "LATEST",
+		1: "EARLIEST",
+		2: "CUSTOM",
+	}
+	ReplayPreset_value = map[string]int32{
+		"LATEST":   0,
+		"EARLIEST": 1,
+		"CUSTOM":   2,
+	}
+)
+
+func (x ReplayPreset) Enum() *ReplayPreset {
+	p := new(ReplayPreset)
+	*p = x
+	return p
+}
+
+func (x ReplayPreset) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReplayPreset) Descriptor() protoreflect.EnumDescriptor {
+	return file_pubsub_api_proto_enumTypes[0].Descriptor()
+}
+
+func (ReplayPreset) Type() protoreflect.EnumType {
+	return &file_pubsub_api_proto_enumTypes[0]
+}
+
+func (x ReplayPreset) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReplayPreset.Descriptor instead.
+func (ReplayPreset) EnumDescriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{0}
+}
+
+type TopicInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicName     string                 `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	TenantGuid    string                 `protobuf:"bytes,2,opt,name=tenant_guid,json=tenantGuid,proto3" json:"tenant_guid,omitempty"`
+	CanPublish    bool                   `protobuf:"varint,3,opt,name=can_publish,json=canPublish,proto3" json:"can_publish,omitempty"`
+	CanSubscribe  bool                   `protobuf:"varint,4,opt,name=can_subscribe,json=canSubscribe,proto3" json:"can_subscribe,omitempty"`
+	SchemaId      string                 `protobuf:"bytes,5,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	RpcId         string                 `protobuf:"bytes,6,opt,name=rpc_id,json=rpcId,proto3" json:"rpc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicInfo) Reset() {
+	*x = TopicInfo{}
+	mi := &file_pubsub_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicInfo) ProtoMessage() {}
+
+func (x *TopicInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicInfo.ProtoReflect.Descriptor instead.
+func (*TopicInfo) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TopicInfo) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *TopicInfo) GetTenantGuid() string {
+	if x != nil {
+		return x.TenantGuid
+	}
+	return ""
+}
+
+func (x *TopicInfo) GetCanPublish() bool {
+	if x != nil {
+		return x.CanPublish
+	}
+	return false
+}
+
+func (x *TopicInfo) GetCanSubscribe() bool {
+	if x != nil {
+		return x.CanSubscribe
+	}
+	return false
+}
+
+func (x *TopicInfo) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *TopicInfo) GetRpcId() string {
+	if x != nil {
+		return x.RpcId
+	}
+	return ""
+}
+
+type TopicRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicName     string                 `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicRequest) Reset() {
+	*x = TopicRequest{}
+	mi := &file_pubsub_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicRequest) ProtoMessage() {}
+
+func (x *TopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicRequest.ProtoReflect.Descriptor instead.
+func (*TopicRequest) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TopicRequest) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+type EventHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventHeader) Reset() {
+	*x = EventHeader{}
+	mi := &file_pubsub_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventHeader) ProtoMessage() {}
+
+func (x *EventHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventHeader.ProtoReflect.Descriptor instead.
+func (*EventHeader) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EventHeader) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EventHeader) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ProducerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SchemaId      string                 `protobuf:"bytes,2,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Headers       []*EventHeader         `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProducerEvent) Reset() {
+	*x = ProducerEvent{}
+	mi := &file_pubsub_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProducerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProducerEvent) ProtoMessage() {}
+
+func (x *ProducerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProducerEvent.ProtoReflect.Descriptor instead.
+func (*ProducerEvent) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProducerEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProducerEvent) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *ProducerEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ProducerEvent) GetHeaders() []*EventHeader {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type ConsumerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *ProducerEvent         `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	ReplayId      []byte                 `protobuf:"bytes,2,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumerEvent) Reset() {
+	*x = ConsumerEvent{}
+	mi := &file_pubsub_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumerEvent) ProtoMessage() {}
+
+func (x *ConsumerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumerEvent.ProtoReflect.Descriptor instead.
+func (*ConsumerEvent) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConsumerEvent) GetEvent() *ProducerEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ConsumerEvent) GetReplayId() []byte {
+	if x != nil {
+		return x.ReplayId
+	}
+	return nil
+}
+
+type FetchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicName     string                 `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	ReplayPreset  ReplayPreset           `protobuf:"varint,2,opt,name=replay_preset,json=replayPreset,proto3,enum=eventbus.v1.ReplayPreset" json:"replay_preset,omitempty"`
+	ReplayId      []byte                 `protobuf:"bytes,3,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	NumRequested  int32                  `protobuf:"varint,4,opt,name=num_requested,json=numRequested,proto3" json:"num_requested,omitempty"`
+	AuthRefresh   string                 `protobuf:"bytes,5,opt,name=auth_refresh,json=authRefresh,proto3" json:"auth_refresh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRequest) Reset() {
+	*x = FetchRequest{}
+	mi := &file_pubsub_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRequest) ProtoMessage() {}
+
+func (x *FetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FetchRequest) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *FetchRequest) GetReplayPreset() ReplayPreset {
+	if x != nil {
+		return x.ReplayPreset
+	}
+	return ReplayPreset_LATEST
+}
+
+func (x *FetchRequest) GetReplayId() []byte {
+	if x != nil {
+		return x.ReplayId
+	}
+	return nil
+}
+
+func (x *FetchRequest) GetNumRequested() int32 {
+	if x != nil {
+		return x.NumRequested
+	}
+	return 0
+}
+
+func (x *FetchRequest) GetAuthRefresh() string {
+	if x != nil {
+		return x.AuthRefresh
+	}
+	return ""
+}
+
+type FetchResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Events              []*ConsumerEvent       `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	LatestReplayId      []byte                 `protobuf:"bytes,2,opt,name=latest_replay_id,json=latestReplayId,proto3" json:"latest_replay_id,omitempty"`
+	RpcId               string                 `protobuf:"bytes,3,opt,name=rpc_id,json=rpcId,proto3" json:"rpc_id,omitempty"`
+	PendingNumRequested int32                  `protobuf:"varint,4,opt,name=pending_num_requested,json=pendingNumRequested,proto3" json:"pending_num_requested,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *FetchResponse) Reset() {
+	*x = FetchResponse{}
+	mi := &file_pubsub_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchResponse) ProtoMessage() {}
+
+func (x *FetchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResponse.ProtoReflect.Descriptor instead.
+func (*FetchResponse) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FetchResponse) GetEvents() []*ConsumerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetLatestReplayId() []byte {
+	if x != nil {
+		return x.LatestReplayId
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetRpcId() string {
+	if x != nil {
+		return x.RpcId
+	}
+	return ""
+}
+
+func (x *FetchResponse) GetPendingNumRequested() int32 {
+	if x != nil {
+		return x.PendingNumRequested
+	}
+	return 0
+}
+
+type SchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaId      string                 `protobuf:"bytes,1,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaRequest) Reset() {
+	*x = SchemaRequest{}
+	mi := &file_pubsub_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaRequest) ProtoMessage() {}
+
+func (x *SchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaRequest.ProtoReflect.Descriptor instead.
+func (*SchemaRequest) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SchemaRequest) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+type SchemaInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaJson    string                 `protobuf:"bytes,1,opt,name=schema_json,json=schemaJson,proto3" json:"schema_json,omitempty"`
+	SchemaId      string                 `protobuf:"bytes,2,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	RpcId         string                 `protobuf:"bytes,3,opt,name=rpc_id,json=rpcId,proto3" json:"rpc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaInfo) Reset() {
+	*x = SchemaInfo{}
+	mi := &file_pubsub_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaInfo) ProtoMessage() {}
+
+func (x *SchemaInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pubsub_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaInfo.ProtoReflect.Descriptor instead.
+func (*SchemaInfo) Descriptor() ([]byte, []int) {
+	return file_pubsub_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SchemaInfo) GetSchemaJson() string {
+	if x != nil {
+		return x.SchemaJson
+	}
+	return ""
+}
+
+func (x *SchemaInfo) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *SchemaInfo) GetRpcId() string {
+	if x != nil {
+		return x.RpcId
+	}
+	return ""
+}
+
+var File_pubsub_api_proto protoreflect.FileDescriptor
+
+const file_pubsub_api_proto_rawDesc = "" +
+	"\n" +
+	"\x10pubsub_api.proto\x12\veventbus.v1\"\xc5\x01\n" +
+	"\tTopicInfo\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x01 \x01(\tR\ttopicName\x12\x1f\n" +
+	"\vtenant_guid\x18\x02 \x01(\tR\n" +
+	"tenantGuid\x12\x1f\n" +
+	"\vcan_publish\x18\x03 \x01(\bR\n" +
+	"canPublish\x12#\n" +
+	"\rcan_subscribe\x18\x04 \x01(\bR\fcanSubscribe\x12\x1b\n" +
+	"\tschema_id\x18\x05 \x01(\tR\bschemaId\x12\x15\n" +
+	"\x06rpc_id\x18\x06 \x01(\tR\x05rpcId\"-\n" +
+	"\fTopicRequest\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x01 \x01(\tR\ttopicName\"5\n" +
+	"\vEventHeader\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\"\x8a\x01\n" +
+	"\rProducerEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tschema_id\x18\x02 \x01(\tR\bschemaId\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x122\n" +
+	"\aheaders\x18\x04 \x03(\v2\x18.eventbus.v1.EventHeaderR\aheaders\"^\n" +
+	"\rConsumerEvent\x120\n" +
+	"\x05event\x18\x01 \x01(\v2\x1a.eventbus.v1.ProducerEventR\x05event\x12\x1b\n" +
+	"\treplay_id\x18\x02 \x01(\fR\breplayId\"\xd2\x01\n" +
+	"\fFetchRequest\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x01 \x01(\tR\ttopicName\x12>\n" +
+	"\rreplay_preset\x18\x02 \x01(\x0e2\x19.eventbus.v1.ReplayPresetR\freplayPreset\x12\x1b\n" +
+	"\treplay_id\x18\x03 \x01(\fR\breplayId\x12#\n" +
+	"\rnum_requested\x18\x04 \x01(\x05R\fnumRequested\x12!\n" +
+	"\fauth_refresh\x18\x05 \x01(\tR\vauthRefresh\"\xb8\x01\n" +
+	"\rFetchResponse\x122\n" +
+	"\x06events\x18\x01 \x03(\v2\x1a.eventbus.v1.ConsumerEventR\x06events\x12(\n" +
+	"\x10latest_replay_id\x18\x02 \x01(\fR\x0elatestReplayId\x12\x15\n" +
+	"\x06rpc_id\x18\x03 \x01(\tR\x05rpcId\x122\n" +
+	"\x15pending_num_requested\x18\x04 \x01(\x05R\x13pendingNumRequested\",\n" +
+	"\rSchemaRequest\x12\x1b\n" +
+	"\tschema_id\x18\x01 \x01(\tR\bschemaId\"a\n" +
+	"\n" +
+	"SchemaInfo\x12\x1f\n" +
+	"\vschema_json\x18\x01 \x01(\tR\n" +
+	"schemaJson\x12\x1b\n" +
+	"\tschema_id\x18\x02 \x01(\tR\bschemaId\x12\x15\n" +
+	"\x06rpc_id\x18\x03 \x01(\tR\x05rpcId*4\n" +
+	"\fReplayPreset\x12\n" +
+	"\n" +
+	"\x06LATEST\x10\x00\x12\f\n" +
+	"\bEARLIEST\x10\x01\x12\n" +
+	"\n" +
+	"\x06CUSTOM\x10\x022\xd1\x01\n" +
+	"\x06PubSub\x12F\n" +
+	"\tSubscribe\x12\x19.eventbus.v1.FetchRequest\x1a\x1a.eventbus.v1.FetchResponse(\x010\x01\x12@\n" +
+	"\tGetSchema\x12\x1a.eventbus.v1.SchemaRequest\x1a\x17.eventbus.v1.SchemaInfo\x12=\n" +
+	"\bGetTopic\x12\x19.eventbus.v1.TopicRequest\x1a\x16.eventbus.v1.TopicInfoBEZCgithub.com/amp-labs/connectors/providers/salesforce/internal/pubsubb\x06proto3"
+
+var (
+	file_pubsub_api_proto_rawDescOnce sync.Once
+	file_pubsub_api_proto_rawDescData []byte
+)
+
+func file_pubsub_api_proto_rawDescGZIP() []byte {
+	file_pubsub_api_proto_rawDescOnce.Do(func() {
+		file_pubsub_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pubsub_api_proto_rawDesc), len(file_pubsub_api_proto_rawDesc)))
+	})
+	return file_pubsub_api_proto_rawDescData
+}
+
+var file_pubsub_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pubsub_api_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pubsub_api_proto_goTypes = []any{
+	(ReplayPreset)(0),     // 0: eventbus.v1.ReplayPreset
+	(*TopicInfo)(nil),     // 1: eventbus.v1.TopicInfo
+	(*TopicRequest)(nil),  // 2: eventbus.v1.TopicRequest
+	(*EventHeader)(nil),   // 3: eventbus.v1.EventHeader
+	(*ProducerEvent)(nil), // 4: eventbus.v1.ProducerEvent
+	(*ConsumerEvent)(nil), // 5: eventbus.v1.ConsumerEvent
+	(*FetchRequest)(nil),  // 6: eventbus.v1.FetchRequest
+	(*FetchResponse)(nil), // 7: eventbus.v1.FetchResponse
+	(*SchemaRequest)(nil), // 8: eventbus.v1.SchemaRequest
+	(*SchemaInfo)(nil),    // 9: eventbus.v1.SchemaInfo
+}
+var file_pubsub_api_proto_depIdxs = []int32{
+	3, // 0: eventbus.v1.ProducerEvent.headers:type_name -> eventbus.v1.EventHeader
+	4, // 1: eventbus.v1.ConsumerEvent.event:type_name -> eventbus.v1.ProducerEvent
+	0, // 2: eventbus.v1.FetchRequest.replay_preset:type_name -> eventbus.v1.ReplayPreset
+	5, // 3: eventbus.v1.FetchResponse.events:type_name -> eventbus.v1.ConsumerEvent
+	6, // 4: eventbus.v1.PubSub.Subscribe:input_type -> eventbus.v1.FetchRequest
+	8, // 5: eventbus.v1.PubSub.GetSchema:input_type -> eventbus.v1.SchemaRequest
+	2, // 6: eventbus.v1.PubSub.GetTopic:input_type -> eventbus.v1.TopicRequest
+	7, // 7: eventbus.v1.PubSub.Subscribe:output_type -> eventbus.v1.FetchResponse
+	9, // 8: eventbus.v1.PubSub.GetSchema:output_type -> eventbus.v1.SchemaInfo
+	1, // 9: eventbus.v1.PubSub.GetTopic:output_type -> eventbus.v1.TopicInfo
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_pubsub_api_proto_init() }
+func file_pubsub_api_proto_init() {
+	if File_pubsub_api_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pubsub_api_proto_rawDesc), len(file_pubsub_api_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pubsub_api_proto_goTypes,
+		DependencyIndexes: file_pubsub_api_proto_depIdxs,
+		EnumInfos:         file_pubsub_api_proto_enumTypes,
+		MessageInfos:      file_pubsub_api_proto_msgTypes,
+	}.Build()
+	File_pubsub_api_proto = out.File
+	file_pubsub_api_proto_goTypes = nil
+	file_pubsub_api_proto_depIdxs = nil
+}
@@ -0,0 +1,8 @@
+package pubsub
+
+// Messages and the gRPC service are generated from pubsub_api.proto.
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pubsub_api.proto
+
+// DefaultEndpoint is the global Pub/Sub API endpoint, which serves every org.
+// https://developer.salesforce.com/docs/platform/pub-sub-api/guide/pub-sub-endpoints.html
+const DefaultEndpoint = "api.pubsub.salesforce.com:7443"
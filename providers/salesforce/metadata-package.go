@@ -0,0 +1,72 @@
+package salesforce
+
+import (
+	"context"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/providers/salesforce/internal/crm/custom"
+)
+
+// Validation rules, picklist value sets, record types and layouts are managed
+// through retrieve and deploy of zip packages using the Metadata API.
+// Same as UpsertMetadata, these calls require the access token to be attached to the context,
+// see common.WithAuthToken.
+
+type (
+	MetadataComponent = custom.MetadataComponent
+	MetadataPackage   = custom.MetadataPackage
+	RetrieveOptions   = custom.RetrieveOptions
+	DeployOptions     = custom.DeployOptions
+	DeployResult      = custom.DeployResult
+)
+
+const (
+	ComponentTypeCustomObject     = custom.ComponentTypeCustomObject
+	ComponentTypeCustomField      = custom.ComponentTypeCustomField
+	ComponentTypeValidationRule   = custom.ComponentTypeValidationRule
+	ComponentTypeRecordType       = custom.ComponentTypeRecordType
+	ComponentTypeLayout           = custom.ComponentTypeLayout
+	ComponentTypeGlobalValueSet   = custom.ComponentTypeGlobalValueSet
+	ComponentTypeStandardValueSet = custom.ComponentTypeStandardValueSet
+
+	TestLevelNoTestRun         = custom.TestLevelNoTestRun
+	TestLevelRunSpecifiedTests = custom.TestLevelRunSpecifiedTests
+	TestLevelRunLocalTests     = custom.TestLevelRunLocalTests
+	TestLevelRunAllTestsInOrg  = custom.TestLevelRunAllTestsInOrg
+)
+
+var (
+	ErrPackageEmpty   = custom.ErrPackageEmpty
+	ErrPackageInvalid = custom.ErrPackageInvalid
+	ErrRetrieveFailed = custom.ErrRetrieveFailed
+	ErrDeployFailed   = custom.ErrDeployFailed
+	ErrPollTimeout    = custom.ErrPollTimeout
+)
+
+// UnzipMetadataPackage reads a package archive, ex: one produced by the Salesforce CLI.
+func UnzipMetadataPackage(data []byte) (*MetadataPackage, error) {
+	return custom.UnzipPackage(data)
+}
+
+// RetrieveMetadata downloads metadata components, waiting until the retrieval is complete.
+func (c *Connector) RetrieveMetadata(
+	ctx context.Context, components []MetadataComponent, options RetrieveOptions,
+) (*MetadataPackage, error) {
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	return c.customAdapter.Retrieve(ctx, components, options)
+}
+
+// DeployMetadata uploads the package, waiting until the deployment is complete.
+// The package.xml manifest is generated from the package components.
+func (c *Connector) DeployMetadata(
+	ctx context.Context, pkg MetadataPackage, options DeployOptions,
+) (*DeployResult, error) {
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	return c.customAdapter.Deploy(ctx, pkg, options)
+}
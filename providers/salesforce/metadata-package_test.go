@@ -0,0 +1,330 @@
+package salesforce
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+	"github.com/go-test/deep"
+)
+
+// nolint:gochecknoglobals
+var (
+	objectAccountValidationRule = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<CustomObject xmlns="http://soap.sforce.com/2006/04/metadata">
+    <validationRules>
+        <fullName>Require_Phone</fullName>
+        <active>true</active>
+        <errorConditionFormula>ISBLANK(Phone)</errorConditionFormula>
+        <errorMessage>Phone is required.</errorMessage>
+    </validationRules>
+</CustomObject>
+`)
+	layoutAccount = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Layout xmlns="http://soap.sforce.com/2006/04/metadata"/>
+`)
+)
+
+func TestMetadataPackageZip(t *testing.T) {
+	t.Parallel()
+
+	pkg := MetadataPackage{
+		Components: []MetadataComponent{
+			{Type: ComponentTypeValidationRule, FullName: "Account.Require_Phone"},
+			{Type: ComponentTypeLayout, FullName: "Account-Account Layout"},
+			{Type: ComponentTypeValidationRule, FullName: "Account.Require_Email"},
+		},
+		Files: map[string][]byte{
+			"objects/Account.object":                objectAccountValidationRule,
+			"layouts/Account-Account Layout.layout": layoutAccount,
+		},
+	}
+
+	manifest, err := pkg.Manifest()
+	if err != nil {
+		t.Fatalf("failed to create manifest: %v", err)
+	}
+
+	expectedManifest := `<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types>
+        <members>Account-Account Layout</members>
+        <name>Layout</name>
+    </types>
+    <types>
+        <members>Account.Require_Email</members>
+        <members>Account.Require_Phone</members>
+        <name>ValidationRule</name>
+    </types>
+    <version>60.0</version>
+</Package>`
+
+	if string(manifest) != expectedManifest {
+		t.Fatalf("unexpected manifest:\n%s", manifest)
+	}
+
+	data, err := pkg.Zip()
+	if err != nil {
+		t.Fatalf("failed to zip package: %v", err)
+	}
+
+	unzipped, err := UnzipMetadataPackage(data)
+	if err != nil {
+		t.Fatalf("failed to unzip package: %v", err)
+	}
+
+	expected := &MetadataPackage{
+		Components: []MetadataComponent{
+			{Type: ComponentTypeLayout, FullName: "Account-Account Layout"},
+			{Type: ComponentTypeValidationRule, FullName: "Account.Require_Email"},
+			{Type: ComponentTypeValidationRule, FullName: "Account.Require_Phone"},
+		},
+		Files: pkg.Files,
+	}
+
+	if diff := deep.Equal(unzipped, expected); diff != nil {
+		t.Fatalf("unexpected package: %v", diff)
+	}
+
+	if _, err = (MetadataPackage{}).Zip(); !errors.Is(err, ErrPackageEmpty) {
+		t.Fatalf("expected empty package error, got %v", err)
+	}
+}
+
+func TestDeployMetadata(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseDeploy := testutils.DataFromFile(t, "metadata/deploy/deploy-response.xml")
+	responseInProgress := testutils.DataFromFile(t, "metadata/deploy/status-in-progress.xml")
+	responseSucceeded := testutils.DataFromFile(t, "metadata/deploy/status-succeeded.xml")
+	responseFailed := testutils.DataFromFile(t, "metadata/deploy/status-failed.xml")
+	responsePartial := testutils.DataFromFile(t, "metadata/deploy/status-partial.xml")
+
+	pkg := MetadataPackage{
+		Components: []MetadataComponent{
+			{Type: ComponentTypeValidationRule, FullName: "Account.Require_Phone"},
+		},
+		Files: map[string][]byte{
+			"objects/Account.object": objectAccountValidationRule,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		options      DeployOptions
+		statuses     [][]byte
+		expected     *DeployResult
+		expectedErrs []error
+	}{
+		{
+			name:     "Deployment status is polled until done",
+			statuses: [][]byte{responseInProgress, responseInProgress, responseSucceeded},
+			expected: &DeployResult{
+				ID:                 "0Afak000003xYZbCAM",
+				Status:             "Succeeded",
+				ComponentsDeployed: 3,
+				ComponentsTotal:    3,
+			},
+		},
+		{
+			name:     "Component failures are reported",
+			statuses: [][]byte{responseInProgress, responseFailed},
+			expectedErrs: []error{
+				ErrDeployFailed, common.ErrBadRequest,
+				errors.New("ValidationRule Account.Require_Phone: Field Phon does not exist"), // nolint:err113
+			},
+		},
+		{
+			name:     "Partial success keeps deployed components",
+			options:  DeployOptions{AllowPartialSuccess: true},
+			statuses: [][]byte{responsePartial},
+			expected: &DeployResult{
+				ID:                 "0Afak000003xYZbCAM",
+				Status:             "SucceededPartial",
+				ComponentsDeployed: 2,
+				ComponentsTotal:    3,
+				Problems: []string{
+					"ValidationRule Account.Require_Phone: Field Phon does not exist. Check spelling.",
+				},
+			},
+		},
+		{
+			name:         "Polling stops after the timeout",
+			options:      DeployOptions{Timeout: 50 * time.Millisecond, PollInterval: 20 * time.Millisecond},
+			statuses:     [][]byte{responseInProgress},
+			expectedErrs: []error{ErrPollTimeout},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var checks atomic.Int32
+
+			server := mockserver.Switch{
+				Setup: mockserver.ContentXML(),
+				Cases: mockserver.Cases{{
+					If: mockcond.And{
+						mockcond.Path("/services/Soap/m/60.0"),
+						mockcond.BodyContains("<deploy>"),
+						mockcond.BodyContains("<testLevel>NoTestRun</testLevel>"),
+						mockcond.BodyContains(fmt.Sprintf("<rollbackOnError>%v</rollbackOnError>",
+							!tt.options.AllowPartialSuccess)),
+					},
+					Then: mockserver.Response(http.StatusOK, responseDeploy),
+				}, {
+					If: mockcond.And{
+						mockcond.Path("/services/Soap/m/60.0"),
+						mockcond.BodyContains("<asyncProcessId>0Afak000003xYZbCAM</asyncProcessId>"),
+					},
+					Then: func(w http.ResponseWriter, r *http.Request) {
+						index := min(int(checks.Add(1))-1, len(tt.statuses)-1)
+						mockserver.Response(http.StatusOK, tt.statuses[index])(w, r)
+					},
+				}},
+			}.Server()
+			defer server.Close()
+
+			connector, err := constructTestConnector(server.URL)
+			if err != nil {
+				t.Fatalf("failed to construct connector: %v", err)
+			}
+
+			ctx := common.WithAuthToken(t.Context(), "TEST_ACCESS_TOKEN")
+
+			options := tt.options
+			options.TestLevel = TestLevelNoTestRun
+
+			if options.PollInterval == 0 {
+				options.PollInterval = time.Millisecond
+			}
+
+			result, err := connector.DeployMetadata(ctx, pkg, options)
+
+			testutils.CheckErrors(t, tt.name, tt.expectedErrs, err)
+			testutils.CheckOutput(t, tt.name, tt.expected, result)
+
+			// Statuses are repeated once the list is over, there is no exact count when polling times out.
+			if tt.options.Timeout == 0 && int(checks.Load()) != len(tt.statuses) {
+				t.Fatalf("expected %v status checks, got %v", len(tt.statuses), checks.Load())
+			}
+		})
+	}
+}
+
+func TestRetrieveMetadata(t *testing.T) {
+	t.Parallel()
+
+	payloadRetrieve := testutils.DataFromFile(t, "metadata/deploy/retrieve-payload.xml")
+	responseRetrieve := testutils.DataFromFile(t, "metadata/deploy/retrieve-response.xml")
+	responseStatus := testutils.DataFromFile(t, "metadata/deploy/retrieve-status.xml")
+
+	// Retrieved files are placed inside a folder.
+	archive := zipFiles(t, map[string][]byte{
+		"unpackaged/package.xml": []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Package xmlns="http://soap.sforce.com/2006/04/metadata">
+    <types>
+        <members>Account-Account Layout</members>
+        <name>Layout</name>
+    </types>
+    <types>
+        <members>Account.Require_Phone</members>
+        <name>ValidationRule</name>
+    </types>
+    <version>60.0</version>
+</Package>`),
+		"unpackaged/objects/Account.object":                objectAccountValidationRule,
+		"unpackaged/layouts/Account-Account Layout.layout": layoutAccount,
+	})
+	responseStatus = bytes.ReplaceAll(responseStatus,
+		[]byte("{{ZIP_FILE}}"), []byte(base64.StdEncoding.EncodeToString(archive)))
+
+	server := mockserver.Switch{
+		Setup: mockserver.ContentXML(),
+		Cases: mockserver.Cases{{
+			If: mockcond.And{
+				mockcond.Path("/services/Soap/m/60.0"),
+				mockcond.BodyBytes(payloadRetrieve),
+			},
+			Then: mockserver.Response(http.StatusOK, responseRetrieve),
+		}, {
+			If: mockcond.And{
+				mockcond.Path("/services/Soap/m/60.0"),
+				mockcond.BodyContains("<asyncProcessId>09Sak000001aBcDEFG</asyncProcessId>"),
+				mockcond.BodyContains("<includeZip>true</includeZip>"),
+			},
+			Then: mockserver.Response(http.StatusOK, responseStatus),
+		}},
+	}.Server()
+	defer server.Close()
+
+	connector, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	ctx := common.WithAuthToken(t.Context(), "TEST_ACCESS_TOKEN")
+
+	pkg, err := connector.RetrieveMetadata(ctx, []MetadataComponent{
+		{Type: ComponentTypeValidationRule, FullName: "Account.Require_Phone"},
+		{Type: ComponentTypeValidationRule, FullName: "Account.Missing_Rule"},
+		{Type: ComponentTypeLayout, FullName: "Account-Account Layout"},
+	}, RetrieveOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("failed to retrieve metadata: %v", err)
+	}
+
+	expected := &MetadataPackage{
+		Components: []MetadataComponent{
+			{Type: ComponentTypeLayout, FullName: "Account-Account Layout"},
+			{Type: ComponentTypeValidationRule, FullName: "Account.Require_Phone"},
+		},
+		Files: map[string][]byte{
+			"objects/Account.object":                objectAccountValidationRule,
+			"layouts/Account-Account Layout.layout": layoutAccount,
+		},
+		Problems: []string{
+			"package.xml: Entity of type 'ValidationRule' named 'Account.Missing_Rule' cannot be found",
+		},
+	}
+
+	if diff := deep.Equal(pkg, expected); diff != nil {
+		t.Fatalf("unexpected package: %v", diff)
+	}
+}
+
+func zipFiles(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	archive := zip.NewWriter(&buffer)
+
+	for name, content := range files {
+		writer, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+
+		if _, err = writer.Write(content); err != nil {
+			t.Fatalf("failed to write zip entry: %v", err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	return buffer.Bytes()
+}
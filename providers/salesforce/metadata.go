@@ -7,14 +7,29 @@ import (
 	"strings"
 
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/datautils"
 	"github.com/amp-labs/connectors/internal/goutils"
 )
 
 func (c *Connector) UpsertMetadata(
 	ctx context.Context, params *common.UpsertMetadataParams,
 ) (*common.UpsertMetadataResult, error) {
-	// Delegated.
-	return c.customAdapter.UpsertMetadata(ctx, params)
+	if params == nil || !params.DryRun {
+		// Delegated.
+		return c.customAdapter.UpsertMetadata(ctx, params)
+	}
+
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	// The plan is computed against current object metadata.
+	current, err := c.ListObjectMetadata(ctx, datautils.FromMap(params.Fields).Keys())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.customAdapter.PlanUpsertMetadata(ctx, params, current)
 }
 
 // ListObjectMetadata returns object metadata for each object name provided.
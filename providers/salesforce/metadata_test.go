@@ -7,6 +7,7 @@ import (
 
 	"github.com/amp-labs/connectors"
 	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/metadatadiff"
 	"github.com/amp-labs/connectors/internal/goutils"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
 	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
@@ -300,6 +301,7 @@ func TestUpsertMetadataCRM(t *testing.T) { // nolint:funlen,gocognit,cyclop
 	responsePermissionSet := testutils.DataFromFile(t, "metadata/write/permission-set.json")
 	responseUserInfo := testutils.DataFromFile(t, "metadata/write/user-info.json")
 	duplicatePermissionAssignment := testutils.DataFromFile(t, "metadata/write/err-duplicate-permission-assignment.json")
+	responseCustomObjMeta := testutils.DataFromFile(t, "metadata/custom-object-with-custom-fields.json")
 
 	tests := []testroutines.UpsertMetadata{
 		{
@@ -518,6 +520,105 @@ func TestUpsertMetadataCRM(t *testing.T) { // nolint:funlen,gocognit,cyclop
 						},
 					},
 				},
+				PermissionSets: map[string]common.PermissionSetUpsertResult{
+					"IntegrationCustomFieldVisibility": {
+						Name:   "IntegrationCustomFieldVisibility",
+						Action: "update",
+						GrantedFields: []string{
+							"TestObject15__c.Birthday__c",
+							"TestObject15__c.Connection__c",
+							"TestObject15__c.IsReady__c",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "Dry run plans fields and permission set without writing",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"TestObject15__c": {
+						{
+							FieldName:   "Birthday__c",
+							DisplayName: "Birthday",
+							ValueType:   common.ValueTypeString,
+							Required:    true,
+						}, {
+							FieldName:   "Interests__c",
+							DisplayName: "Interests",
+							ValueType:   common.ValueTypeMultiSelect,
+							Required:    true,
+							StringOptions: &common.StringFieldOptions{
+								Values: []string{"art", "travel", "swimming", "cooking"},
+							},
+						}, {
+							FieldName:   "Hobby__c",
+							DisplayName: "Hobby",
+							ValueType:   common.ValueTypeString,
+						}, {
+							FieldName:   "Nickname__c",
+							DisplayName: "Nickname",
+							ValueType:   common.ValueTypeString,
+						},
+					},
+				},
+				DryRun: true,
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: mockserver.Cases{{
+					// Current object metadata.
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/services/data/v60.0/composite"),
+					},
+					Then: mockserver.Response(http.StatusOK, responseCustomObjMeta),
+				}, {
+					// Fetch permission set which contains field permissions.
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/services/Soap/m/60.0"),
+						mockcond.BodyBytes(payloadFieldPermissions),
+					},
+					Then: mockserver.ResponseChainedFuncs(
+						mockserver.ContentXML(),
+						mockserver.Response(http.StatusOK, responseFieldPermissions),
+					),
+				}},
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				DryRun:  true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"TestObject15__c": {
+						"Birthday__c": {
+							FieldName: "Birthday__c",
+							Action:    "none",
+						},
+						"Interests__c": {
+							FieldName: "Interests__c",
+							Action:    "update",
+							Metadata: map[string]any{
+								"changes": []metadatadiff.ChangeKind{metadatadiff.ChangeValues},
+							},
+						},
+						"Hobby__c": {
+							FieldName: "Hobby__c",
+							Action:    "create",
+						},
+						"Nickname__c": {
+							FieldName: "Nickname__c",
+							Action:    "create",
+						},
+					},
+				},
+				PermissionSets: map[string]common.PermissionSetUpsertResult{
+					"IntegrationCustomFieldVisibility": {
+						Name:          "IntegrationCustomFieldVisibility",
+						Action:        "update",
+						GrantedFields: []string{"TestObject15__c.Nickname__c"},
+					},
+				},
 			},
 		},
 	}
@@ -1,6 +1,8 @@
 package salesforce
 
 import (
+	"strings"
+
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/internal/jsonquery"
 	"github.com/spyzhov/ajson"
@@ -53,7 +55,7 @@ func getSalesforceDataMarshaller(assoc []string) func([]map[string]any, []string
 			idStr, _ := id.(string)
 
 			data[idx] = common.ReadResultRow{
-				Fields: common.ExtractLowercaseFieldsFromRaw(fields, record),
+				Fields: extractFields(fields, record),
 				Raw:    record,
 				Id:     idStr,
 			}
@@ -67,6 +69,49 @@ func getSalesforceDataMarshaller(assoc []string) func([]map[string]any, []string
 	}
 }
 
+// extractFields returns requested fields, lowercased.
+// Fields of parent records, ex: "Account.Name", are nested objects in the response, they are flattened
+// under the dotted path: {"Account": {"Name": "Acme"}} becomes {"account.name": "Acme"}.
+func extractFields(fields []string, record map[string]any) map[string]any {
+	out := common.ExtractLowercaseFieldsFromRaw(fields, record)
+
+	for _, field := range fields {
+		if !strings.Contains(field, ".") {
+			continue
+		}
+
+		if value, ok := lookupPath(record, strings.Split(field, ".")); ok {
+			out[strings.ToLower(field)] = value
+		}
+	}
+
+	return out
+}
+
+// lookupPath walks nested parent records. A missing parent is returned as nil value,
+// since Salesforce responds with null for an empty lookup field.
+func lookupPath(record map[string]any, path []string) (any, bool) {
+	value, ok := common.ToStringMap(record).GetCaseInsensitive(path[0])
+	if !ok {
+		return nil, false
+	}
+
+	if len(path) == 1 {
+		return value, true
+	}
+
+	if value == nil {
+		return nil, true
+	}
+
+	parent, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	return lookupPath(parent, path[1:])
+}
+
 func extractAssociationsFromRecord(val any) []common.Association {
 	var result []common.Association
 
@@ -0,0 +1,342 @@
+package salesforce
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/amp-labs/connectors/providers/salesforce/internal/pubsub"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// Pub/Sub API delivers Change Data Capture events over gRPC, which unlike Event Relay needs no AWS account.
+// https://developer.salesforce.com/docs/platform/pub-sub-api/overview
+
+// PubSubEndpoint is the global Pub/Sub API address.
+const PubSubEndpoint = pubsub.DefaultEndpoint
+
+const (
+	// pubSubMaxBatchSize is the most events a single fetch request can ask for.
+	pubSubMaxBatchSize = 100
+
+	pubSubTopicPrefix = "/data/"
+)
+
+var errPubSubStreamClosed = errors.New("pub/sub stream closed by server")
+
+// DialPubSub opens a TLS connection to the Pub/Sub API.
+// Extra options can override the defaults, ex: to connect to a different address for testing.
+func DialPubSub(opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	defaults := []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})),
+	}
+
+	return grpc.NewClient(PubSubEndpoint, append(defaults, opts...)...)
+}
+
+// ChangeDataCaptureTopic returns the topic which streams changes of a single object, ex: "/data/AccountChangeEvent".
+func ChangeDataCaptureTopic(objectName string) string {
+	return pubSubTopicPrefix + GetChangeDataCaptureEventName(objectName)
+}
+
+// ChannelTopic returns the topic of a custom channel, ex: "/data/Ampersand__chn".
+func ChannelTopic(channelName string) string {
+	return pubSubTopicPrefix + GetChannelName(GetRawChannelNameFromChannel(channelName))
+}
+
+// ReplayStore persists the position of every topic subscription,
+// so that a restarted subscriber continues right after the last handled event.
+type ReplayStore interface {
+	// LoadReplayID returns nil if there is no position for the topic.
+	LoadReplayID(ctx context.Context, topic string) ([]byte, error)
+	SaveReplayID(ctx context.Context, topic string, replayID []byte) error
+}
+
+// MemoryReplayStore keeps replay IDs for the lifetime of the process.
+type MemoryReplayStore struct {
+	mutex     sync.Mutex
+	replayIDs map[string][]byte
+}
+
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{replayIDs: make(map[string][]byte)}
+}
+
+func (s *MemoryReplayStore) LoadReplayID(_ context.Context, topic string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.replayIDs[topic], nil
+}
+
+func (s *MemoryReplayStore) SaveReplayID(_ context.Context, topic string, replayID []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.replayIDs[topic] = replayID
+
+	return nil
+}
+
+// PubSubParams configures a PubSubSubscriber.
+type PubSubParams struct {
+	// Conn is a connection to the Pub/Sub API, see DialPubSub.
+	Conn grpc.ClientConnInterface
+	// TokenSource provides the access token of the user. It is consulted every time a stream is opened.
+	TokenSource oauth2.TokenSource
+	// TenantID is the Salesforce organization ID.
+	TenantID string
+	// ReplayStore keeps subscription positions. Defaults to MemoryReplayStore.
+	ReplayStore ReplayStore
+	// BatchSize is how many events to request at a time, 100 at most. Defaults to 100.
+	BatchSize int
+	// StartFromEarliest replays all retained events for topics without a stored position.
+	// By default, only events published after subscribing are delivered.
+	StartFromEarliest bool
+}
+
+// PubSubHandler processes a single event. Returning an error stops the subscription
+// and the event will be delivered again on the next subscription.
+type PubSubHandler func(ctx context.Context, event SubscriptionEvent) error
+
+// PubSubSubscriber consumes events from the Pub/Sub API.
+// Payloads are decoded with the Avro schema of each event, which is fetched once and cached.
+type PubSubSubscriber struct {
+	client      pubsub.PubSubClient
+	tokenSource oauth2.TokenSource
+	instanceURL string
+	tenantID    string
+	replayStore ReplayStore
+	batchSize   int32
+	preset      pubsub.ReplayPreset
+
+	mutex   sync.Mutex
+	schemas map[string]*pubsub.Schema
+}
+
+// NewPubSubSubscriber creates a subscriber for the organization this connector is connected to.
+func (c *Connector) NewPubSubSubscriber(params PubSubParams) (*PubSubSubscriber, error) {
+	if params.Conn == nil || params.TokenSource == nil || params.TenantID == "" {
+		return nil, fmt.Errorf("%w: Conn, TokenSource and TenantID are required", errMissingParams)
+	}
+
+	if params.ReplayStore == nil {
+		params.ReplayStore = NewMemoryReplayStore()
+	}
+
+	if params.BatchSize <= 0 || params.BatchSize > pubSubMaxBatchSize {
+		params.BatchSize = pubSubMaxBatchSize
+	}
+
+	preset := pubsub.ReplayPreset_LATEST
+	if params.StartFromEarliest {
+		preset = pubsub.ReplayPreset_EARLIEST
+	}
+
+	return &PubSubSubscriber{
+		client:      pubsub.NewPubSubClient(params.Conn),
+		tokenSource: params.TokenSource,
+		instanceURL: c.getModuleURL(),
+		tenantID:    params.TenantID,
+		replayStore: params.ReplayStore,
+		batchSize:   int32(params.BatchSize), // nolint:gosec
+		preset:      preset,
+		schemas:     make(map[string]*pubsub.Schema),
+	}, nil
+}
+
+// Subscribe delivers events of the topic to the handler until the context is cancelled or an error occurs.
+// Change events which bundle several records are split, so the handler receives one event per record.
+// The replay ID is saved once all records of an event are handled.
+//
+// Streams don't outlive access tokens. Once Subscribe returns, calling it again
+// resumes from the saved replay ID with a fresh token.
+func (s *PubSubSubscriber) Subscribe(ctx context.Context, topic string, handler PubSubHandler) error {
+	ctx, err := s.authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	request, err := s.initialRequest(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = stream.Send(request); err != nil {
+		return err
+	}
+
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if errors.Is(err, io.EOF) {
+				return errPubSubStreamClosed
+			}
+
+			return err
+		}
+
+		if err = s.handleResponse(ctx, topic, response, handler); err != nil {
+			return err
+		}
+
+		// Flow control: more events are sent only after they are requested.
+		if response.PendingNumRequested == 0 {
+			// A stream closed by the server fails with EOF, while the reason is reported by the next Recv.
+			err = stream.Send(&pubsub.FetchRequest{TopicName: topic, NumRequested: s.batchSize})
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+		}
+	}
+}
+
+func (s *PubSubSubscriber) authorize(ctx context.Context) (context.Context, error) {
+	token, err := s.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.AppendToOutgoingContext(ctx,
+		"accesstoken", token.AccessToken,
+		"instanceurl", s.instanceURL,
+		"tenantid", s.tenantID,
+	), nil
+}
+
+func (s *PubSubSubscriber) initialRequest(ctx context.Context, topic string) (*pubsub.FetchRequest, error) {
+	replayID, err := s.replayStore.LoadReplayID(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &pubsub.FetchRequest{
+		TopicName:    topic,
+		ReplayPreset: s.preset,
+		NumRequested: s.batchSize,
+	}
+
+	if len(replayID) != 0 {
+		request.ReplayPreset = pubsub.ReplayPreset_CUSTOM
+		request.ReplayId = replayID
+	}
+
+	return request, nil
+}
+
+func (s *PubSubSubscriber) handleResponse(
+	ctx context.Context, topic string, response *pubsub.FetchResponse, handler PubSubHandler,
+) error {
+	// Keepalive responses carry no events, but move the position past events filtered out by the server.
+	if len(response.Events) == 0 {
+		if len(response.LatestReplayId) == 0 {
+			return nil
+		}
+
+		return s.replayStore.SaveReplayID(ctx, topic, response.LatestReplayId)
+	}
+
+	for _, consumerEvent := range response.Events {
+		events, err := s.decodeEvent(ctx, consumerEvent.Event)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err = handler(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if err = s.replayStore.SaveReplayID(ctx, topic, consumerEvent.ReplayId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PubSubSubscriber) decodeEvent(ctx context.Context, event *pubsub.ProducerEvent) ([]SubscriptionEvent, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	schema, err := s.schema(ctx, event.SchemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := schema.Decode(event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding event %s: %w", event.Id, err)
+	}
+
+	// Platform events have no header and describe no records.
+	if _, ok := record[keyEventChangeEventHeader]; !ok {
+		return []SubscriptionEvent{record}, nil
+	}
+
+	list, err := CollapsedSubscriptionEvent(record).SubscriptionEventList()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]SubscriptionEvent, len(list))
+	for index, item := range list {
+		events[index] = item.(SubscriptionEvent) // nolint:forcetypeassert
+	}
+
+	return events, nil
+}
+
+// schema returns the cached schema, fetching unknown ones without holding the lock,
+// so events of known schemas are decoded while a new schema is downloaded.
+func (s *PubSubSubscriber) schema(ctx context.Context, schemaID string) (*pubsub.Schema, error) {
+	s.mutex.Lock()
+	schema, ok := s.schemas[schemaID]
+	s.mutex.Unlock()
+
+	if ok {
+		return schema, nil
+	}
+
+	info, err := s.client.GetSchema(ctx, &pubsub.SchemaRequest{SchemaId: schemaID})
+	if err != nil {
+		return nil, fmt.Errorf("fetching schema %s: %w", schemaID, err)
+	}
+
+	schema, err = pubsub.ParseSchema(info.SchemaJson)
+	if err != nil {
+		return nil, fmt.Errorf("schema %s: %w", schemaID, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Concurrent fetches of the same schema keep the first one stored.
+	if cached, ok := s.schemas[schemaID]; ok {
+		return cached, nil
+	}
+
+	s.schemas[schemaID] = schema
+
+	return schema, nil
+}
@@ -0,0 +1,314 @@
+package salesforce
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/providers/salesforce/internal/pubsub"
+	"github.com/linkedin/goavro/v2"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testAccountChangeEventSchema = `{
+  "type": "record", "name": "AccountChangeEvent", "namespace": "com.sforce.eventbus",
+  "fields": [
+    {"name": "ChangeEventHeader", "type": {
+      "type": "record", "name": "ChangeEventHeader", "namespace": "eventbus",
+      "fields": [
+        {"name": "entityName", "type": "string"},
+        {"name": "recordIds", "type": {"type": "array", "items": "string"}},
+        {"name": "changeType", "type": {
+          "type": "enum", "name": "ChangeType", "namespace": "com.sforce.eventbus",
+          "symbols": ["CREATE", "DELETE", "UNDELETE", "UPDATE"]
+        }},
+        {"name": "commitTimestamp", "type": "long"},
+        {"name": "nulledFields", "type": {"type": "array", "items": "string"}},
+        {"name": "diffFields", "type": {"type": "array", "items": "string"}},
+        {"name": "changedFields", "type": {"type": "array", "items": "string"}}
+      ]
+    }},
+    {"name": "Name", "type": ["null", "string"], "default": null},
+    {"name": "BillingAddress", "type": ["null", {
+      "type": "record", "name": "Address",
+      "fields": [
+        {"name": "Street", "type": ["null", "string"], "default": null},
+        {"name": "City", "type": ["null", "string"], "default": null}
+      ]
+    }], "default": null},
+    {"name": "Description", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+func TestPubSubSubscribe(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	payload := encodeTestAvro(t, map[string]any{
+		"ChangeEventHeader": map[string]any{
+			"entityName":      "Account",
+			"recordIds":       []any{"001a", "001b"},
+			"changeType":      "UPDATE",
+			"commitTimestamp": int64(1720000000000),
+			"nulledFields":    []any{},
+			"diffFields":      []any{},
+			// Name (position 1), Description (3), and BillingAddress.City (nested position 1 of position 2).
+			"changedFields": []any{"0x0a", "2-0x02"},
+		},
+		"Name":           goavro.Union("string", "Acme"),
+		"BillingAddress": goavro.Union("com.sforce.eventbus.Address", map[string]any{"City": goavro.Union("string", "Paris")}),
+		"Description":    nil,
+	})
+
+	server := &fakePubSubServer{
+		schemaJSON: testAccountChangeEventSchema,
+		responses: []*pubsub.FetchResponse{{
+			Events: []*pubsub.ConsumerEvent{{
+				Event:    &pubsub.ProducerEvent{Id: "evt-1", SchemaId: "schema-1", Payload: payload},
+				ReplayId: []byte{0, 1},
+			}},
+			LatestReplayId: []byte{0, 1},
+		}, {
+			// Keepalive.
+			LatestReplayId: []byte{0, 2},
+		}},
+	}
+
+	store := NewMemoryReplayStore()
+	_ = store.SaveReplayID(t.Context(), "/data/AccountChangeEvent", []byte{0, 0})
+
+	subscriber := newTestPubSubSubscriber(t, server, store)
+
+	var events []SubscriptionEvent
+
+	err := subscriber.Subscribe(t.Context(), ChangeDataCaptureTopic("Account"), func(_ context.Context, e SubscriptionEvent) error {
+		events = append(events, e)
+
+		return nil
+	})
+	if !errors.Is(err, errPubSubStreamClosed) {
+		t.Fatalf("expected stream to be closed by server, got %v", err)
+	}
+
+	first := server.request(0)
+	if first.ReplayPreset != pubsub.ReplayPreset_CUSTOM || !slices.Equal(first.ReplayId, []byte{0, 0}) ||
+		first.TopicName != "/data/AccountChangeEvent" || first.NumRequested != pubSubMaxBatchSize {
+		t.Fatalf("unexpected initial fetch request: %+v", first)
+	}
+
+	if token, tenant := server.authHeaders(); token != "token" || tenant != "00Dxx0000000001" {
+		t.Fatalf("missing auth headers: %q %q", token, tenant)
+	}
+
+	if len(events) != 2 { // nolint:mnd
+		t.Fatalf("expected an event per record, got %d", len(events))
+	}
+
+	for index, recordID := range []string{"001a", "001b"} {
+		assertTestPubSubEvent(t, events[index], recordID)
+	}
+
+	replayID, _ := store.LoadReplayID(t.Context(), "/data/AccountChangeEvent")
+	if !slices.Equal(replayID, []byte{0, 2}) {
+		t.Fatalf("expected replay ID of the keepalive to be saved, got %v", replayID)
+	}
+}
+
+func TestPubSubHandlerErrorKeepsReplayID(t *testing.T) {
+	t.Parallel()
+
+	server := &fakePubSubServer{
+		schemaJSON: testAccountChangeEventSchema,
+		responses: []*pubsub.FetchResponse{{
+			Events: []*pubsub.ConsumerEvent{{
+				Event: &pubsub.ProducerEvent{SchemaId: "schema-1", Payload: encodeTestAvro(t, map[string]any{
+					"ChangeEventHeader": map[string]any{
+						"entityName": "Account", "recordIds": []any{"001a"}, "changeType": "CREATE",
+						"commitTimestamp": int64(1), "nulledFields": []any{}, "diffFields": []any{}, "changedFields": []any{},
+					},
+				})},
+				ReplayId: []byte{0, 1},
+			}},
+		}},
+	}
+
+	store := NewMemoryReplayStore()
+	subscriber := newTestPubSubSubscriber(t, server, store)
+	errHandler := errors.New("handler failed")
+
+	err := subscriber.Subscribe(t.Context(), "/data/AccountChangeEvent", func(context.Context, SubscriptionEvent) error {
+		return errHandler
+	})
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+
+	if preset := server.request(0).ReplayPreset; preset != pubsub.ReplayPreset_LATEST {
+		t.Fatalf("expected subscription to start from latest, got %v", preset)
+	}
+
+	if replayID, _ := store.LoadReplayID(t.Context(), "/data/AccountChangeEvent"); replayID != nil {
+		t.Fatalf("unhandled event must not be acknowledged, got replay ID %v", replayID)
+	}
+}
+
+func assertTestPubSubEvent(t *testing.T, event SubscriptionEvent, recordID string) {
+	t.Helper()
+
+	objectName, _ := event.ObjectName()
+	eventType, _ := event.EventType()
+	identifier, _ := event.RecordId()
+	timestamp, _ := event.EventTimeStampNano()
+
+	if objectName != "Account" || eventType != common.SubscriptionEventTypeUpdate ||
+		identifier != recordID || timestamp != 1720000000000 {
+		t.Fatalf("unexpected event: %v", event)
+	}
+
+	fields, err := event.UpdatedFields()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(fields, []string{"Name", "Description", "City"}) {
+		t.Fatalf("unexpected updated fields: %v", fields)
+	}
+
+	if event["Name"] != "Acme" || event["Description"] != nil {
+		t.Fatalf("unexpected payload: %v", event)
+	}
+}
+
+func encodeTestAvro(t *testing.T, record map[string]any) []byte {
+	t.Helper()
+
+	codec, err := goavro.NewCodec(testAccountChangeEventSchema)
+	if err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	payload, err := codec.BinaryFromNative(nil, record)
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+
+	return payload
+}
+
+func newTestPubSubSubscriber(t *testing.T, srv *fakePubSubServer, store ReplayStore) *PubSubSubscriber {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20) // nolint:mnd
+	server := grpc.NewServer()
+	pubsub.RegisterPubSubServer(server, srv)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial fake server: %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	connector, err := constructTestConnector("https://example.my.salesforce.com")
+	if err != nil {
+		t.Fatalf("failed to construct test connector: %v", err)
+	}
+
+	subscriber, err := connector.NewPubSubSubscriber(PubSubParams{
+		Conn:        conn,
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		TenantID:    "00Dxx0000000001",
+		ReplayStore: store,
+	})
+	if err != nil {
+		t.Fatalf("failed to create subscriber: %v", err)
+	}
+
+	return subscriber
+}
+
+// fakePubSubServer replies to each fetch request with the next prepared response,
+// and closes the stream once responses run out.
+type fakePubSubServer struct {
+	pubsub.UnimplementedPubSubServer
+
+	schemaJSON string
+	responses  []*pubsub.FetchResponse
+
+	mutex       sync.Mutex
+	requests    []*pubsub.FetchRequest
+	accessToken string
+	tenantID    string
+}
+
+func (s *fakePubSubServer) Subscribe(stream grpc.BidiStreamingServer[pubsub.FetchRequest, pubsub.FetchResponse]) error {
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		s.mutex.Lock()
+		s.accessToken = firstValue(md, "accesstoken")
+		s.tenantID = firstValue(md, "tenantid")
+		s.mutex.Unlock()
+	}
+
+	for _, response := range s.responses {
+		request, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		s.mutex.Lock()
+		s.requests = append(s.requests, request)
+		s.mutex.Unlock()
+
+		if err = stream.Send(response); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *fakePubSubServer) request(index int) *pubsub.FetchRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.requests[index]
+}
+
+func (s *fakePubSubServer) authHeaders() (string, string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.accessToken, s.tenantID
+}
+
+func (s *fakePubSubServer) GetSchema(_ context.Context, request *pubsub.SchemaRequest) (*pubsub.SchemaInfo, error) {
+	return &pubsub.SchemaInfo{SchemaId: request.SchemaId, SchemaJson: s.schemaJSON}, nil
+}
+
+func (s *fakePubSubServer) GetTopic(_ context.Context, request *pubsub.TopicRequest) (*pubsub.TopicInfo, error) {
+	return &pubsub.TopicInfo{TopicName: request.TopicName, CanSubscribe: true}, nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) != 0 {
+		return values[0]
+	}
+
+	return ""
+}
@@ -62,20 +62,16 @@ func (c *Connector) buildReadURL(config common.ReadParams) (*urlbuilder.URL, err
 
 // makeSOQL returns the SOQL query for the desired read operation.
 func makeSOQL(config common.ReadParams) *core.SOQLBuilder {
-	fields := config.Fields.List()
+	soql := (&core.SOQLBuilder{}).SelectFields(config.Fields.List()).From(config.ObjectName)
 
 	// If AssociatedObjects is set, then we need to add a subquery for each requested association.
 	// Source: https://www.infallibletechie.com/2023/04/parent-child-records-in-salesforce-soql-using-rest-api.html
-	if config.AssociatedObjects != nil {
-		for _, obj := range config.AssociatedObjects {
-			// Generates subqueries like: (SELECT FIELDS(STANDARD) FROM Account)
-			// Just standard fields for now, because salesforce errors out > 200 fields on an object.
-			fields = append(fields, "(SELECT FIELDS(STANDARD) FROM "+obj+")")
-		}
+	for _, obj := range config.AssociatedObjects {
+		// Generates subqueries like: (SELECT FIELDS(STANDARD) FROM Contacts)
+		// Just standard fields for now, because salesforce errors out > 200 fields on an object.
+		soql.SelectChildren((&core.SOQLBuilder{}).SelectFields([]string{"FIELDS(STANDARD)"}).From(obj))
 	}
 
-	soql := (&core.SOQLBuilder{}).SelectFields(fields).From(config.ObjectName)
-
 	// If Since is not set, then we're doing a backfill. We read all rows (in pages)
 	if !config.Since.IsZero() {
 		soql.Where("SystemModstamp > " + datautils.Time.FormatRFC3339inUTC(config.Since))
@@ -89,8 +85,8 @@ func makeSOQL(config common.ReadParams) *core.SOQLBuilder {
 		soql.Where("IsDeleted = true")
 	}
 
-	// TODO: When we support builder facing filters, we should escape the
-	// filter string to avoid SOQL injection.
+	// Filter is passed as is. Callers building it from user input should quote values
+	// with SOQLLiteral, or run the whole query with Connector.Query.
 	if config.Filter != "" {
 		soql.Where(config.Filter)
 	}
@@ -3,6 +3,7 @@ package salesforce
 import (
 	"errors"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/amp-labs/connectors"
@@ -22,6 +23,7 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 	responseUnknownObject := testutils.DataFromFile(t, "unknown-object.json")
 	responseLeadsFirstPage := testutils.DataFromFile(t, "read-list-leads.json")
 	responseListContacts := testutils.DataFromFile(t, "read-list-contacts.json")
+	responseAccountsWithContacts := testutils.DataFromFile(t, "read-accounts-with-contacts.json")
 
 	tests := []testroutines.Read{
 		{
@@ -137,6 +139,81 @@ func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
 			},
 			ExpectedErrs: nil,
 		},
+		{
+			Name: "Parent fields and child records are returned in one read",
+			Input: common.ReadParams{
+				ObjectName:        "Account",
+				Fields:            connectors.Fields("Owner.Name"),
+				AssociatedObjects: []string{"Contacts"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.Path("/services/data/v60.0/query"),
+					mockcond.QueryParam("q", "SELECT Owner.Name,(SELECT FIELDS(STANDARD) FROM Contacts) FROM Account"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseAccountsWithContacts),
+			}.Server(),
+			Comparator: func(serverURL string, actual, expected *common.ReadResult) bool {
+				if !testroutines.ComparatorSubsetRead(serverURL, actual, expected) {
+					return false
+				}
+
+				for index, row := range expected.Data {
+					if row.Id != actual.Data[index].Id ||
+						!reflect.DeepEqual(row.Associations, actual.Data[index].Associations) {
+						return false
+					}
+				}
+
+				return true
+			},
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{
+						"owner.name": "Integration User",
+					},
+					Raw: map[string]any{
+						"Name": "Edge Communications",
+					},
+					Associations: map[string][]common.Association{
+						"Contacts": {{
+							ObjectId: "003ak000003dQCGAA2",
+							Raw: map[string]any{
+								"attributes": map[string]any{
+									"type": "Contact",
+									"url":  "/services/data/v60.0/sobjects/Contact/003ak000003dQCGAA2",
+								},
+								"Id":    "003ak000003dQCGAA2",
+								"Email": "rose@edge.com",
+							},
+						}, {
+							ObjectId: "003ak000003dQCHAA2",
+							Raw: map[string]any{
+								"attributes": map[string]any{
+									"type": "Contact",
+									"url":  "/services/data/v60.0/sobjects/Contact/003ak000003dQCHAA2",
+								},
+								"Id":    "003ak000003dQCHAA2",
+								"Email": "sean@edge.com",
+							},
+						}},
+					},
+					Id: "001ak00000OKNPHAA5",
+				}, {
+					Fields: map[string]any{
+						"owner.name": nil,
+					},
+					Raw: map[string]any{
+						"Name": "Burlington Textiles",
+					},
+					Id: "001ak00000OKNPIAA5",
+				}},
+				Done: true,
+			},
+			ExpectedErrs: nil,
+		},
 	}
 
 	for _, tt := range tests {
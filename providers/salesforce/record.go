@@ -68,10 +68,12 @@ func (c *Connector) buildReadByIdentifierURL(config recordsByIDsParams) (*urlbui
 	}
 
 	query := makeSOQL(config.ReadParams).
-		WithIDs(config.RecordIdentifiers.List()).
-		String()
+		WithIDs(config.RecordIdentifiers.List())
+	if err := query.Err(); err != nil {
+		return nil, err
+	}
 
-	url.WithQueryParam("q", query)
+	url.WithQueryParam("q", query.String())
 
 	return url, nil
 }
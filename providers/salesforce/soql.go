@@ -0,0 +1,112 @@
+package salesforce
+
+import (
+	"context"
+	"errors"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/amp-labs/connectors/providers/salesforce/internal/crm/core"
+)
+
+// SOQLBuilder constructs SOQL queries with parent relationship fields, child subqueries and sorting.
+//
+// Example:
+//
+//	query := NewSOQL("Account").
+//		SelectFields([]string{"Id", "Name"}).
+//		SelectParentFields("Owner", []string{"Name"}).
+//		SelectChildren(NewSOQL("Contacts").SelectFields([]string{"Id", "Email"})).
+//		WhereField("Industry", "=", "Energy").
+//		OrderBy("Name", SortAscending)
+type SOQLBuilder = core.SOQLBuilder
+
+type SortDirection = core.SortDirection
+
+const (
+	SortAscending            = core.SortAscending
+	SortDescending           = core.SortDescending
+	SortAscendingNullsLast   = core.SortAscendingNullsLast
+	SortDescendingNullsFirst = core.SortDescendingNullsFirst
+)
+
+var ErrMissingQuery = errors.New("query is required")
+
+// NewSOQL starts a query selecting from the object.
+// For subqueries the object is the name of the child relationship, ex: "Contacts".
+func NewSOQL(objectName string) *SOQLBuilder {
+	return (&core.SOQLBuilder{}).From(objectName)
+}
+
+var (
+	ErrEmptyList          = core.ErrEmptyList
+	ErrUnsupportedLiteral = core.ErrUnsupportedLiteral
+)
+
+// SOQLLiteral converts a value into a SOQL literal, quoting and escaping strings.
+// Use it to build ReadParams.Filter out of values that are not trusted.
+// Empty lists and byte slices cannot be converted.
+func SOQLLiteral(value any) (string, error) {
+	return core.Literal(value)
+}
+
+// QueryParams describe a page of query results.
+type QueryParams struct {
+	// Query is required for every page, it describes which fields and child records are returned.
+	Query *SOQLBuilder
+	// NextPage is the token returned by the previous page.
+	NextPage common.NextPageToken
+}
+
+// Query runs the SOQL query, paginating the same way as Read.
+// Selected fields, including fields of parent records such as "account.name", are returned lowercased.
+// Child records of subqueries are returned as associations keyed by the relationship name,
+// so related records are fetched together with their parents.
+// Large child relationships may be truncated, in which case the raw subquery result is marked as not done.
+func (c *Connector) Query(ctx context.Context, params QueryParams) (*common.ReadResult, error) {
+	if params.Query == nil {
+		return nil, ErrMissingQuery
+	}
+
+	if err := params.Query.Err(); err != nil {
+		return nil, err
+	}
+
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	url, err := c.buildQueryURL(params)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return common.ParseResult(
+		rsp,
+		getRecords,
+		getNextRecordsURL,
+		getSalesforceDataMarshaller(params.Query.Children()),
+		datautils.NewSetFromList(params.Query.Fields()),
+	)
+}
+
+func (c *Connector) buildQueryURL(params QueryParams) (*urlbuilder.URL, error) {
+	if len(params.NextPage) != 0 {
+		return c.getDomainURL(params.NextPage.String())
+	}
+
+	url, err := c.getRestApiURL("query")
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("q", params.Query.String())
+
+	return url, nil
+}
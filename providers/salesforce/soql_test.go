@@ -1,10 +1,15 @@
 package salesforce
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testutils"
 	"gotest.tools/v3/assert"
 )
 
@@ -42,3 +47,171 @@ func TestSoqlBuilderWithIDs(t *testing.T) {
 			"'001ak00000OQ4VCAA1')", "mismatching SOQL query string")
 	}
 }
+
+func TestSoqlBuilderRelationships(t *testing.T) {
+	t.Parallel()
+
+	soql := NewSOQL("Account").
+		SelectFields([]string{"Id", "Name"}).
+		SelectParentFields("Owner", []string{"Name", "Email"}).
+		SelectChildren(NewSOQL("Contacts").
+			SelectFields([]string{"Id", "Email"}).
+			WhereField("Email", "!=", nil).
+			OrderBy("CreatedDate", SortDescending).
+			Limit(5)).
+		WhereField("Industry", "IN", []string{"Energy", "Media"}).
+		WhereField("CreatedDate", ">", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)).
+		OrderBy("Name", SortAscendingNullsLast).
+		OrderBy("Id", SortAscending).
+		Limit(100)
+
+	assert.Equal(t, soql.String(), "SELECT Id,Name,Owner.Name,Owner.Email,"+
+		"(SELECT Id,Email FROM Contacts WHERE Email != null ORDER BY CreatedDate DESC LIMIT 5) "+
+		"FROM Account WHERE Industry IN ('Energy','Media') AND CreatedDate > 2024-03-01T10:00:00Z "+
+		"ORDER BY Name ASC NULLS LAST,Id ASC LIMIT 100")
+	assert.DeepEqual(t, soql.Fields(), []string{"Id", "Name", "Owner.Name", "Owner.Email"})
+	assert.DeepEqual(t, soql.Children(), []string{"Contacts"})
+}
+
+func TestSOQLLiteral(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    any
+		expected string
+	}{
+		{input: `O'Brien \ Co`, expected: `'O\'Brien \\ Co'`},
+		{input: "say \"hi\"\nand\tleave", expected: `'say \"hi\"\nand\tleave'`},
+		{input: "x' OR Name != '", expected: `'x\' OR Name != \''`},
+		{input: nil, expected: "null"},
+		{input: true, expected: "true"},
+		{input: 42, expected: "42"},
+		{input: 2.5, expected: "2.5"},
+		{input: int32(-7), expected: "-7"},
+		{input: uint(5), expected: "5"},
+		{input: float32(1.25), expected: "1.25"},
+		{input: []any{"a", 1}, expected: "('a',1)"},
+		{input: []int{1, 2, 3}, expected: "(1,2,3)"},
+		{input: [2]float64{0.5, 3}, expected: "(0.5,3)"},
+		{input: []common.NextPageToken{"a", "b"}, expected: "('a','b')"},
+		{input: time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600)), expected: "2024-03-01T11:00:00Z"},
+		{input: common.NextPageToken("token"), expected: "'token'"},
+	}
+
+	for _, tt := range tests {
+		literal, err := SOQLLiteral(tt.input)
+		assert.NilError(t, err)
+		assert.Equal(t, literal, tt.expected)
+	}
+}
+
+func TestSOQLLiteralErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    any
+		expected error
+	}{
+		{input: []string{}, expected: ErrEmptyList},
+		{input: [0]int{}, expected: ErrEmptyList},
+		{input: []any{"a", []int{}}, expected: ErrEmptyList},
+		{input: []byte("id"), expected: ErrUnsupportedLiteral},
+		{input: [2]byte{1, 2}, expected: ErrUnsupportedLiteral},
+	}
+
+	for _, tt := range tests {
+		_, err := SOQLLiteral(tt.input)
+		assert.ErrorIs(t, err, tt.expected)
+	}
+}
+
+func TestSoqlBuilderInvalidCondition(t *testing.T) {
+	t.Parallel()
+
+	soql := NewSOQL("Account").
+		SelectFields([]string{"Id"}).
+		SelectChildren(NewSOQL("Contacts").
+			SelectFields([]string{"Id"}).
+			WhereField("Email", "IN", []string{})).
+		WhereField("Name", "=", "Edge")
+
+	assert.ErrorIs(t, soql.Err(), ErrEmptyList)
+	assert.ErrorContains(t, soql.Err(), "subquery of Contacts: condition on Email")
+
+	soql = NewSOQL("Account").
+		SelectFields([]string{"Id"}).
+		WithIDs(nil)
+
+	assert.ErrorIs(t, soql.Err(), ErrEmptyList)
+
+	connector, err := constructTestConnector("http://localhost")
+	assert.NilError(t, err)
+
+	// The query is rejected before it is sent.
+	_, err = connector.Query(t.Context(), QueryParams{Query: soql})
+	assert.ErrorIs(t, err, ErrEmptyList)
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	responseAccounts := testutils.DataFromFile(t, "read-accounts-with-contacts.json")
+
+	query := NewSOQL("Account").
+		SelectFields([]string{"Id", "Name"}).
+		SelectParentFields("Owner", []string{"Name"}).
+		SelectChildren(NewSOQL("Contacts").SelectFields([]string{"Id", "Email"})).
+		OrderBy("Name", SortAscending)
+
+	server := mockserver.Conditional{
+		Setup: mockserver.ContentJSON(),
+		If: mockcond.And{
+			mockcond.Path("/services/data/v60.0/query"),
+			mockcond.QueryParam("q", "SELECT Id,Name,Owner.Name,(SELECT Id,Email FROM Contacts) "+
+				"FROM Account ORDER BY Name ASC"),
+		},
+		Then: mockserver.Response(http.StatusOK, responseAccounts),
+	}.Server()
+	defer server.Close()
+
+	connector, err := constructTestConnector(server.URL)
+	if err != nil {
+		t.Fatalf("failed to construct connector: %v", err)
+	}
+
+	result, err := connector.Query(t.Context(), QueryParams{Query: query})
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	if len(result.Data) != 2 || !result.Done {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	testutils.CheckOutput(t, "first account fields", map[string]any{
+		"id":         "001ak00000OKNPHAA5",
+		"name":       "Edge Communications",
+		"owner.name": "Integration User",
+	}, result.Data[0].Fields)
+
+	contacts := result.Data[0].Associations["Contacts"]
+	if len(contacts) != 2 || contacts[0].ObjectId != "003ak000003dQCGAA2" ||
+		contacts[1].ObjectId != "003ak000003dQCHAA2" || contacts[1].Raw["Email"] != "sean@edge.com" {
+		t.Fatalf("unexpected contacts: %v", contacts)
+	}
+
+	// Account without owner and without contacts.
+	testutils.CheckOutput(t, "second account fields", map[string]any{
+		"id":         "001ak00000OKNPIAA5",
+		"name":       "Burlington Textiles",
+		"owner.name": nil,
+	}, result.Data[1].Fields)
+
+	if result.Data[1].Associations != nil {
+		t.Fatalf("unexpected associations: %v", result.Data[1].Associations)
+	}
+
+	if _, err = connector.Query(t.Context(), QueryParams{}); err == nil {
+		t.Fatal("expected error for missing query")
+	}
+}
@@ -13,6 +13,18 @@ func (c *Connector) BatchWrite(ctx context.Context, params *common.BatchWritePar
 	return c.batchAdapter.BatchWrite(ctx, params)
 }
 
+// CompositeWrite writes records of different objects in a single transaction.
+func (c *Connector) CompositeWrite(
+	ctx context.Context, params *common.CompositeWriteParams,
+) (*common.CompositeWriteResult, error) {
+	if c.isPardotModule() {
+		return nil, common.ErrNotImplemented
+	}
+
+	// Delegated.
+	return c.batchAdapter.CompositeWrite(ctx, params)
+}
+
 // Write will write data to Salesforce.
 //
 //nolint:cyclop
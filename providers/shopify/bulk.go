@@ -0,0 +1,391 @@
+package shopify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/graphql"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+)
+
+// bulkPagePrefix marks NextPage tokens of bulk reads, other tokens are cursors of incremental reads.
+const bulkPagePrefix = "bulk:"
+
+var errInvalidBulkPage = errors.New("invalid bulk read page token")
+
+// Read reads records of an object.
+//
+// Incremental reads, having a Since time, page through records updated since then.
+// Customers, orders and products are read oldest first, other objects in the order of their ids.
+// Full backfills run a bulk operation instead, which exports every record without paging
+// through the query cost budget. Its first page is empty and starts the operation,
+// next pages wait for it to complete and stream the exported JSONL file.
+// Only one bulk query runs per store at a time. A running operation of the same query is resumed,
+// ex: of a backfill that was abandoned, while backfills of other queries page through records instead.
+// https://shopify.dev/docs/api/usage/bulk-operations/queries
+func (c *Connector) Read(ctx context.Context, params common.ReadParams) (*common.ReadResult, error) {
+	if params.NextPage != "" && !strings.HasPrefix(params.NextPage.String(), bulkPagePrefix) {
+		return c.Reader.Read(ctx, params)
+	}
+
+	if params.NextPage == "" && !params.Since.IsZero() {
+		return c.Reader.Read(ctx, params)
+	}
+
+	if err := params.ValidateParams(true); err != nil {
+		return nil, err
+	}
+
+	support, err := c.registry.GetSupport(c.ProviderContext.Module(), params.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !support.Read {
+		return nil, fmt.Errorf("%w: %s does not support read", common.ErrOperationNotSupportedForObject, params.ObjectName)
+	}
+
+	if params.NextPage == "" {
+		return c.startBulkRead(ctx, params)
+	}
+
+	page, err := parseBulkPage(params.NextPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.continueBulkRead(ctx, params, page)
+}
+
+// bulkPage is the position of a bulk read, encoded as its NextPage token.
+type bulkPage struct {
+	// OperationID is the global ID of the bulk operation.
+	OperationID string `json:"id"`
+	// Offset is the position in the exported file of the next record to read.
+	Offset int64 `json:"offset"`
+}
+
+func (p bulkPage) token() common.NextPageToken {
+	data, _ := json.Marshal(p) // nolint:errchkjson
+
+	return common.NextPageToken(bulkPagePrefix + base64.RawURLEncoding.EncodeToString(data))
+}
+
+func parseBulkPage(token common.NextPageToken) (*bulkPage, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token.String(), bulkPagePrefix))
+	if err != nil {
+		return nil, errors.Join(errInvalidBulkPage, err)
+	}
+
+	var page bulkPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, errors.Join(errInvalidBulkPage, err)
+	}
+
+	if page.OperationID == "" || page.Offset < 0 {
+		return nil, errInvalidBulkPage
+	}
+
+	return &page, nil
+}
+
+// bulkInProgressCode is the user error code of bulk queries rejected while another one runs.
+const bulkInProgressCode = "OPERATION_IN_PROGRESS"
+
+// startBulkRead submits a bulk query exporting records updated until the requested time.
+// When another bulk query runs, the read either resumes it, if it is the same query, or falls back to pages.
+func (c *Connector) startBulkRead(ctx context.Context, params common.ReadParams) (*common.ReadResult, error) {
+	schema, err := c.graphQLSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selection, err := schema.EdgesSelection(params.ObjectName, params.Fields.List())
+	if err != nil {
+		return nil, err
+	}
+
+	rootField := params.ObjectName
+	if query := searchQuery(params); query != "" {
+		rootField += "(query: " + strconv.Quote(query) + ")"
+	}
+
+	bulkQuery := fmt.Sprintf("{ %s { edges { node %s } } }", rootField, selection.Selection)
+
+	current, err := c.currentBulkOperation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != nil && current.running() {
+		if current.Query == bulkQuery {
+			return emptyBulkResult(bulkPage{OperationID: current.ID}), nil
+		}
+
+		return c.Reader.Read(ctx, params)
+	}
+
+	mutation, err := graphql.Operation(queryFS, "mutation", "bulkOperationRunQuery", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.execute(ctx, graphql.Request{
+		Query:     mutation,
+		Variables: map[string]any{"query": bulkQuery},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload := jsonquery.New(body, "data", "bulkOperationRunQuery")
+
+	userErrors, err := payload.ArrayOptional("userErrors")
+	if err != nil {
+		return nil, err
+	}
+
+	// Another bulk query may have started since the current operation was checked.
+	if hasErrorCode(userErrors, bulkInProgressCode) {
+		return c.Reader.Read(ctx, params)
+	}
+
+	if err := checkErrors(userErrors); err != nil {
+		return nil, err
+	}
+
+	operationID, err := jsonquery.New(body, "data", "bulkOperationRunQuery", "bulkOperation").StringRequired("id")
+	if err != nil {
+		return nil, err
+	}
+
+	return emptyBulkResult(bulkPage{OperationID: operationID}), nil
+}
+
+// bulkOperation is the state of a bulk operation.
+type bulkOperation struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	ErrorCode string `json:"errorCode"`
+	URL       string `json:"url"`
+	Query     string `json:"query"`
+}
+
+func (o bulkOperation) running() bool {
+	return o.Status == "CREATED" || o.Status == "RUNNING"
+}
+
+// currentBulkOperation returns the latest bulk query of the app, nil if there was none.
+func (c *Connector) currentBulkOperation(ctx context.Context) (*bulkOperation, error) {
+	query, err := graphql.Operation(queryFS, "query", "currentBulkOperation", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.execute(ctx, graphql.Request{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := jsonquery.New(body, "data").ObjectOptional("currentBulkOperation")
+	if err != nil || node == nil {
+		return nil, err
+	}
+
+	return jsonquery.ParseNode[bulkOperation](node)
+}
+
+// continueBulkRead waits for the bulk operation to complete, then reads the next page of its results.
+// Operations still running after maxBulkPollDuration return an empty page, to be read again.
+func (c *Connector) continueBulkRead(
+	ctx context.Context,
+	params common.ReadParams,
+	page *bulkPage,
+) (*common.ReadResult, error) {
+	deadline := time.Now().Add(maxBulkPollDuration)
+
+	for {
+		operation, err := c.bulkOperation(ctx, page.OperationID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case operation.running():
+			if time.Now().Add(c.bulkPollInterval).After(deadline) {
+				return emptyBulkResult(*page), nil
+			}
+
+			if err := graphql.Sleep(ctx, c.bulkPollInterval); err != nil {
+				return nil, err
+			}
+		case operation.Status == "COMPLETED":
+			// Operations exporting no records have no results file.
+			if operation.URL == "" {
+				return &common.ReadResult{Data: []common.ReadResultRow{}, Done: true}, nil
+			}
+
+			return c.readBulkResults(ctx, params, operation.URL, page)
+		default:
+			return nil, fmt.Errorf("%w: %s is %s %s",
+				ErrBulkOperation, operation.ID, operation.Status, operation.ErrorCode)
+		}
+	}
+}
+
+func (c *Connector) bulkOperation(ctx context.Context, operationID string) (*bulkOperation, error) {
+	query, err := graphql.Operation(queryFS, "query", "bulkOperation", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.execute(ctx, graphql.Request{
+		Query:     query,
+		Variables: map[string]any{"id": operationID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := jsonquery.New(body, "data").ObjectRequired("node")
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonquery.ParseNode[bulkOperation](node)
+}
+
+// readBulkResults streams a page of records from the JSONL file exported by a bulk operation.
+// Reading resumes at the offset of the page, so the file is never held in memory as a whole.
+func (c *Connector) readBulkResults(
+	ctx context.Context,
+	params common.ReadParams,
+	url string,
+	page *bulkPage,
+) (*common.ReadResult, error) {
+	schema, err := c.graphQLSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Markers of connections are the same as in the bulk query, which selected the same fields.
+	selection, err := schema.EdgesSelection(params.ObjectName, params.Fields.List())
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.downloadBulkResults(ctx, url, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return &common.ReadResult{Data: []common.ReadResultRow{}, Done: true}, nil
+	}
+
+	defer body.Close()
+
+	results := newBulkResults(selection, bulkPageSize(params))
+
+	offset, done, err := results.read(bufio.NewReader(body), page.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := common.GetMarshalledDataWithId(results.records, params.Fields.List())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &common.ReadResult{
+		Rows: int64(len(rows)),
+		Data: rows,
+		Done: done,
+	}
+
+	if !done {
+		result.NextPage = bulkPage{OperationID: page.OperationID, Offset: offset}.token()
+	}
+
+	return result, nil
+}
+
+// newDownloadClient returns a client for the storage host of bulk results,
+// which gives up on hosts that stop responding instead of blocking the read.
+func newDownloadClient() *http.Client {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return &http.Client{Timeout: bulkDownloadResponseTimeout}
+	}
+
+	transport = transport.Clone()
+	transport.ResponseHeaderTimeout = bulkDownloadResponseTimeout
+
+	return &http.Client{Transport: transport}
+}
+
+// downloadBulkResults opens the results file from the offset.
+// It returns nil when the offset is the end of the file.
+func (c *Connector) downloadBulkResults(ctx context.Context, url string, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	rsp, err := c.downloadClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rsp.StatusCode {
+	case http.StatusPartialContent:
+		return rsp.Body, nil
+	case http.StatusOK:
+		// The host ignored the range, records before the offset were already read.
+		if _, err := io.CopyN(io.Discard, rsp.Body, offset); err != nil && !errors.Is(err, io.EOF) {
+			rsp.Body.Close()
+
+			return nil, err
+		}
+
+		return rsp.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		rsp.Body.Close()
+
+		return nil, nil // nolint:nilnil
+	default:
+		rsp.Body.Close()
+
+		return nil, fmt.Errorf("%w: downloading results failed with status %s", ErrBulkOperation, rsp.Status)
+	}
+}
+
+func emptyBulkResult(page bulkPage) *common.ReadResult {
+	return &common.ReadResult{
+		Data:     []common.ReadResultRow{},
+		NextPage: page.token(),
+	}
+}
+
+// bulkPageSize returns the requested page size, bulk results are not limited by the API.
+func bulkPageSize(params common.ReadParams) int {
+	if params.PageSize > 0 {
+		return params.PageSize
+	}
+
+	return defaultBulkPageSize
+}
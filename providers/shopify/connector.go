@@ -0,0 +1,99 @@
+package shopify
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/interpreter"
+	"github.com/amp-labs/connectors/common/naming"
+	"github.com/amp-labs/connectors/internal/components"
+	"github.com/amp-labs/connectors/internal/components/operations"
+	"github.com/amp-labs/connectors/internal/components/reader"
+	"github.com/amp-labs/connectors/internal/components/schema"
+	"github.com/amp-labs/connectors/internal/components/writer"
+	"github.com/amp-labs/connectors/internal/graphql"
+	"github.com/amp-labs/connectors/providers"
+)
+
+type Connector struct {
+	// Basic connector
+	*components.Connector
+
+	// Require authenticated client
+	common.RequireAuthenticatedClient
+	// Require workspace, the name of the store
+	common.RequireWorkspace
+
+	// Supported operations
+	components.SchemaProvider
+	components.Reader
+	components.Writer
+
+	registry *components.EndpointRegistry
+	// introspector caches the GraphQL schema used to build reads and metadata.
+	introspector *graphql.Introspector
+	// budget paces reads by the query cost points reported by every response.
+	budget *graphql.Budget
+	// downloadClient fetches results of bulk operations, which are served by a storage host
+	// that must not receive the access token of the store.
+	downloadClient *http.Client
+	// bulkPollInterval is the delay between checks of a running bulk operation.
+	bulkPollInterval time.Duration
+}
+
+func NewConnector(params common.ConnectorParams) (*Connector, error) {
+	// Create base connector with provider info
+	return components.Initialize(providers.Shopify, params, constructor)
+}
+
+func constructor(base *components.Connector) (*Connector, error) {
+	connector := &Connector{
+		Connector:        base,
+		introspector:     graphql.NewIntrospector(base.JSONHTTPClient()),
+		budget:           graphql.NewBudget(graphql.ShopifyCost),
+		downloadClient:   newDownloadClient(),
+		bulkPollInterval: defaultBulkPollInterval,
+	}
+
+	// Set the metadata provider for the connector
+	connector.SchemaProvider = schema.NewGraphQLSchemaProvider(
+		connector.graphQLSchema,
+		naming.CapitalizeFirstLetterEveryWord,
+	)
+
+	registry, err := components.NewEndpointRegistry(supportedOperations())
+	if err != nil {
+		return nil, err
+	}
+
+	connector.registry = registry
+
+	connector.Reader = reader.NewHTTPReader(
+		connector.HTTPClient().Client,
+		registry,
+		connector.ProviderContext.Module(),
+		operations.ReadHandlers{
+			BuildRequest:  connector.buildReadRequest,
+			ParseResponse: connector.parseReadResponse,
+			ErrorHandler: connector.budget.ErrorHandler(interpreter.ErrorHandler{
+				JSON: interpreter.NewFaultyResponder(errorFormats, nil),
+			}.Handle),
+		},
+	)
+
+	connector.Writer = writer.NewHTTPWriter(
+		connector.HTTPClient().Client,
+		registry,
+		connector.ProviderContext.Module(),
+		operations.WriteHandlers{
+			BuildRequest:  connector.buildWriteRequest,
+			ParseResponse: connector.parseWriteResponse,
+			ErrorHandler: connector.budget.ErrorHandler(interpreter.ErrorHandler{
+				JSON: interpreter.NewFaultyResponder(errorFormats, nil),
+			}.Handle),
+		},
+	)
+
+	return connector, nil
+}
@@ -0,0 +1,95 @@
+package shopify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/interpreter"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+// ErrBulkOperation is returned when a bulk operation fails, expires or is canceled.
+var ErrBulkOperation = errors.New("bulk operation did not complete")
+
+// Implement error abstraction layers to streamline provider error handling.
+var errorFormats = interpreter.NewFormatSwitch( // nolint:gochecknoglobals
+	[]interpreter.FormatTemplate{
+		{
+			MustKeys: nil,
+			Template: func() interpreter.ErrorDescriptor { return &ResponseError{} },
+		},
+	}...,
+)
+
+// ResponseError represents an error response from the Shopify Admin API.
+// Errors are either a message, ex: authentication failures, or a list of GraphQL errors.
+//
+// Example:
+//
+//	{"errors": "[API] Invalid API key or access token (unrecognized login or wrong password)"}
+//	{"errors": [{"message": "Field 'foo' doesn't exist on type 'Product'", "locations": [...]}]}
+type ResponseError struct {
+	Errors any `json:"errors"`
+}
+
+func (r ResponseError) CombineErr(base error) error {
+	switch errs := r.Errors.(type) {
+	case string:
+		return fmt.Errorf("%w: %v", base, errs)
+	case []any:
+		messages := make([]string, 0, len(errs))
+
+		for _, item := range errs {
+			if obj, ok := item.(map[string]any); ok {
+				if message, ok := obj["message"].(string); ok {
+					messages = append(messages, message)
+				}
+			}
+		}
+
+		if len(messages) == 0 {
+			return base
+		}
+
+		return fmt.Errorf("%w: %v", base, strings.Join(messages, ", "))
+	default:
+		return base
+	}
+}
+
+// hasErrorCode reports whether one of the user errors has the code.
+func hasErrorCode(errs []*ajson.Node, code string) bool {
+	for _, value := range errs {
+		if errorCode, _ := jsonquery.New(value).StrWithDefault("code", ""); errorCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkErrors reports errors returned with a 200 status code,
+// ex: GraphQL errors of invalid queries, or user errors of mutations failing validation.
+func checkErrors(errs []*ajson.Node) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+
+	for _, value := range errs {
+		message, err := jsonquery.New(value).StrWithDefault("message", "")
+		if err != nil {
+			return err
+		}
+
+		if message != "" {
+			messages = append(messages, message)
+		}
+	}
+
+	return fmt.Errorf("%w: %s", common.ErrBadRequest, strings.Join(messages, "; "))
+}
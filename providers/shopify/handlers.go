@@ -0,0 +1,241 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/graphql"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/spyzhov/ajson"
+)
+
+//go:embed graphql/*.graphql
+var queryFS embed.FS
+
+// graphQLSchema returns the introspected schema of the pinned API version, fetched once per connector.
+func (c *Connector) graphQLSchema(ctx context.Context) (*graphql.Schema, error) {
+	url, err := c.graphQLURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.introspector.Schema(ctx, url.String())
+}
+
+// buildReadRequest queries records updated within the requested time range.
+// Customers, orders and products are sorted oldest first, so an interrupted sync resumes where it stopped.
+// Other objects, ex: inventory items, cannot be sorted by update time and come in the order of their ids.
+func (c *Connector) buildReadRequest(ctx context.Context, params common.ReadParams) (*http.Request, error) {
+	url, err := c.graphQLURL()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := c.graphQLSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages shrink as the query cost budget runs low.
+	reservation, err := c.budget.Reserve(ctx, params.ObjectName, readPageSize(params))
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]any{
+		"first": reservation.PageSize(),
+	}
+
+	if updatedAtSortObjects.Has(params.ObjectName) {
+		variables["sortKey"] = "UPDATED_AT"
+	}
+
+	if query := searchQuery(params); query != "" {
+		variables["query"] = query
+	}
+
+	if params.NextPage != "" {
+		variables["after"] = params.NextPage.String()
+	}
+
+	requestBody, err := schema.Read(params.ObjectName, params.Fields.List(), variables)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.NewRequestWithContext(graphql.WithReservation(ctx, reservation),
+		http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+}
+
+func (c *Connector) parseReadResponse(
+	ctx context.Context,
+	params common.ReadParams,
+	request *http.Request,
+	response *common.JSONHTTPResponse,
+) (*common.ReadResult, error) {
+	body, ok := response.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	// Throttled queries are rejected with a 200 status code.
+	if err := c.budget.Observe(graphql.ReservationFrom(request.Context()),
+		graphql.RecordCount(params.ObjectName, body), response.Headers, body); err != nil {
+		return nil, err
+	}
+
+	if err := checkResponseErrors(body); err != nil {
+		return nil, err
+	}
+
+	return common.ParseResult(
+		response,
+		graphql.Records(params.ObjectName),
+		graphql.NextPage(params.ObjectName),
+		common.GetMarshalledDataWithId,
+		params.Fields,
+	)
+}
+
+func (c *Connector) buildWriteRequest(ctx context.Context, params common.WriteParams) (*http.Request, error) {
+	url, err := c.graphQLURL()
+	if err != nil {
+		return nil, err
+	}
+
+	mutation, err := graphql.Operation(queryFS, "mutation", mutationName(params), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := common.RecordDataToMap(params.RecordData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updates identify the record inside the input, by its global ID.
+	if params.RecordId != "" {
+		input["id"] = params.RecordId
+	}
+
+	jsonBody, err := json.Marshal(graphql.Request{
+		Query:     mutation,
+		Variables: map[string]any{"input": input},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(jsonBody))
+}
+
+func (c *Connector) parseWriteResponse(
+	ctx context.Context,
+	params common.WriteParams,
+	request *http.Request,
+	response *common.JSONHTTPResponse,
+) (*common.WriteResult, error) {
+	body, ok := response.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	// Mutations are not paged, their cost doesn't tell the cost of reading records of the object.
+	if err := c.budget.Observe(nil, 0, response.Headers, body); err != nil {
+		return nil, err
+	}
+
+	if err := checkResponseErrors(body); err != nil {
+		return nil, err
+	}
+
+	payload := jsonquery.New(body, "data", mutationName(params))
+
+	// Invalid input is reported as user errors, ex: {"field": ["email"], "message": "Email has already been taken"}.
+	userErrors, err := payload.ArrayOptional("userErrors")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkErrors(userErrors); err != nil {
+		return nil, err
+	}
+
+	record, err := payload.ObjectRequired(writeObjectNodePath(params.ObjectName))
+	if err != nil {
+		return nil, err
+	}
+
+	recordID, err := jsonquery.New(record).StrWithDefault("id", "")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := jsonquery.Convertor.ObjectToMap(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.WriteResult{
+		Success:  true,
+		RecordId: recordID,
+		Data:     data,
+	}, nil
+}
+
+// execute sends a GraphQL request outside of reads and writes, ex: to manage bulk operations.
+// Its cost updates the budget, but doesn't tell the cost of reading records of any object.
+func (c *Connector) execute(ctx context.Context, request graphql.Request) (*ajson.Node, error) {
+	url, err := c.graphQLURL()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.JSONHTTPClient().Post(ctx, url.String(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := response.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	if err := c.budget.Observe(nil, 0, response.Headers, body); err != nil {
+		return nil, err
+	}
+
+	if err := checkResponseErrors(body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// checkResponseErrors reports GraphQL errors of a response with a 200 status code.
+func checkResponseErrors(body *ajson.Node) error {
+	errs, err := jsonquery.New(body).ArrayOptional("errors")
+	if err != nil {
+		return err
+	}
+
+	return checkErrors(errs)
+}
+
+// readPageSize returns the requested page size within the limit of the API.
+func readPageSize(params common.ReadParams) int {
+	if params.PageSize > 0 && params.PageSize < defaultPageSize {
+		return params.PageSize
+	}
+
+	return defaultPageSize
+}
@@ -0,0 +1,123 @@
+package shopify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/amp-labs/connectors/internal/graphql"
+)
+
+// parentIDField is added by bulk operations to nodes of nested connections.
+const parentIDField = "__parentId"
+
+// bulkResults assembles records from the JSONL file exported by a bulk operation.
+// Every line is a node. Nodes of nested connections are lines of their own following their parent,
+// which they refer to by __parentId. The connection marker selected by the node tells its connection,
+// where the node is put back, ex: {"variants": {"nodes": [...]}}, the same way incremental reads return them.
+// https://shopify.dev/docs/api/usage/bulk-operations/queries#the-jsonl-data-format
+type bulkResults struct {
+	selection *graphql.BulkSelection
+	pageSize  int
+
+	records []map[string]any
+	// nodes holds every node of the page by id, so nested nodes find their parent.
+	nodes map[string]map[string]any
+}
+
+func newBulkResults(selection *graphql.BulkSelection, pageSize int) *bulkResults {
+	return &bulkResults{
+		selection: selection,
+		pageSize:  pageSize,
+		nodes:     make(map[string]map[string]any),
+	}
+}
+
+// read consumes lines until the page is full, starting at the offset of the file.
+// It returns the offset of the first record of the next page, and whether the file was read to the end.
+func (r *bulkResults) read(reader *bufio.Reader, offset int64) (int64, bool, error) {
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, false, err
+		}
+
+		if len(bytes.TrimSpace(line)) != 0 {
+			full, addErr := r.add(line)
+			if addErr != nil {
+				return 0, false, addErr
+			}
+
+			if full {
+				return offset, false, nil
+			}
+		}
+
+		offset += int64(len(line))
+
+		if errors.Is(err, io.EOF) {
+			return offset, true, nil
+		}
+	}
+}
+
+// add parses a line into a record or a nested node.
+// It returns true, leaving the line unread, when the line starts a record beyond the page.
+// Pages end only before records, so every record comes with all of its nested nodes.
+func (r *bulkResults) add(line []byte) (bool, error) {
+	var node map[string]any
+	if err := json.Unmarshal(line, &node); err != nil {
+		return false, err
+	}
+
+	parentID, nested := node[parentIDField].(string)
+	if !nested {
+		if len(r.records) == r.pageSize {
+			return true, nil
+		}
+
+		r.records = append(r.records, node)
+		r.remember(node)
+
+		return false, nil
+	}
+
+	delete(node, parentIDField)
+
+	path, ok := r.selection.Connection(node)
+	if !ok {
+		return false, fmt.Errorf("%w: connection of node %v is not selected", ErrBulkOperation, node["id"])
+	}
+
+	r.remember(node)
+
+	parent, ok := r.nodes[parentID]
+	if !ok {
+		return false, fmt.Errorf("%w: parent %s of node %v is missing", ErrBulkOperation, parentID, node["id"])
+	}
+
+	// Connections may be nested in objects of the parent, ex: {"options": {"values": ...}}.
+	for _, field := range path {
+		child, ok := parent[field].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			parent[field] = child
+		}
+
+		parent = child
+	}
+
+	nodes, _ := parent["nodes"].([]any)
+	parent["nodes"] = append(nodes, node)
+
+	return false, nil
+}
+
+func (r *bulkResults) remember(node map[string]any) {
+	if id, ok := node["id"].(string); ok {
+		r.nodes[id] = node
+	}
+}
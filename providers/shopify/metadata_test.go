@@ -0,0 +1,105 @@
+package shopify
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestListObjectMetadata(t *testing.T) { // nolint:funlen,gocognit,cyclop
+	t.Parallel()
+
+	responseSchema := testutils.DataFromFile(t, "schema.json")
+
+	tests := []testroutines.Metadata{
+		{
+			Name:         "At least one object name must be queried",
+			Input:        nil,
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:  "Successfully describe products and customers",
+			Input: []string{"products", "customers", "shop"},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.Path("/admin/api/2025-01/graphql.json"),
+					mockcond.BodyContains("__schema"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseSchema),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetMetadata,
+			Expected: &common.ListObjectMetadataResult{
+				Result: map[string]common.ObjectMetadata{
+					"products": {
+						DisplayName: "Products",
+						Fields: map[string]common.FieldMetadata{
+							"id": {
+								DisplayName:  "id",
+								ValueType:    common.ValueTypeString,
+								ProviderType: "ID",
+							},
+							"status": {
+								DisplayName:  "status",
+								ValueType:    common.ValueTypeSingleSelect,
+								ProviderType: "ProductStatus",
+								Values: []common.FieldValue{
+									{Value: "ACTIVE", DisplayValue: "ACTIVE"},
+									{Value: "ARCHIVED", DisplayValue: "ARCHIVED"},
+									{Value: "DRAFT", DisplayValue: "DRAFT"},
+								},
+							},
+							"updatedAt": {
+								DisplayName:  "updatedAt",
+								ValueType:    common.ValueTypeDateTime,
+								ProviderType: "DateTime",
+							},
+							"variants": {
+								DisplayName:  "variants",
+								ValueType:    common.ValueTypeOther,
+								ProviderType: "ProductVariantConnection",
+							},
+						},
+					},
+					"customers": {
+						DisplayName: "Customers",
+						Fields: map[string]common.FieldMetadata{
+							"email": {
+								DisplayName:  "email",
+								ValueType:    common.ValueTypeString,
+								ProviderType: "String",
+							},
+							"verifiedEmail": {
+								DisplayName:  "verifiedEmail",
+								ValueType:    common.ValueTypeBoolean,
+								ProviderType: "Boolean",
+							},
+						},
+					},
+				},
+				Errors: map[string]error{
+					"shop": common.ErrObjectNotSupported,
+				},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ObjectMetadataConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
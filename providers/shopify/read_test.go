@@ -0,0 +1,489 @@
+package shopify
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestRead(t *testing.T) { //nolint:funlen,gocognit,cyclop,maintidx
+	t.Parallel()
+
+	responseSchema := testutils.DataFromFile(t, "schema.json")
+	responseProducts := testutils.DataFromFile(t, "products/read.json")
+	errorUnknownField := testutils.DataFromFile(t, "products/err-unknown-field.json")
+	errorThrottled := testutils.DataFromFile(t, "products/err-throttled.json")
+	errorUnauthorized := testutils.DataFromFile(t, "products/err-unauthorized.json")
+	responseBulkRun := testutils.DataFromFile(t, "bulk/run.json")
+	errorBulkInProgress := testutils.DataFromFile(t, "bulk/err-in-progress.json")
+	responseNoBulkOperation := testutils.DataFromFile(t, "bulk/current-none.json")
+	responseCurrentBulkRunning := testutils.DataFromFile(t, "bulk/current-running.json")
+	responseBulkRunning := testutils.DataFromFile(t, "bulk/running.json")
+	responseBulkFailed := testutils.DataFromFile(t, "bulk/failed.json")
+	bulkResults := testutils.DataFromFile(t, "bulk/products.jsonl")
+
+	// Bulk results are downloaded from a storage host rather than the Admin API.
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "products.jsonl", time.Time{}, bytes.NewReader(bulkResults))
+	}))
+	t.Cleanup(storage.Close)
+
+	responseBulkCompleted := []byte(strings.ReplaceAll(
+		string(testutils.DataFromFile(t, "bulk/completed.json")), "RESULTS_URL", storage.URL+"/products.jsonl"))
+
+	// The last product starts the second page of bulk results.
+	secondPageOffset := int64(bytes.Index(bulkResults, []byte(`{"id":"gid://shopify/Product/8003"`)))
+	operation := bulkPage{OperationID: "gid://shopify/BulkOperation/9001"}
+
+	// Every read introspects the schema first, the schema is cached per connector.
+	introspection := mockserver.Case{
+		If:   mockcond.BodyContains("__schema"),
+		Then: mockserver.Response(http.StatusOK, responseSchema),
+	}
+
+	// Backfills check for a running bulk query before starting their own.
+	noBulkOperation := mockserver.Case{
+		If:   mockcond.BodyContains("currentBulkOperation(type: QUERY)"),
+		Then: mockserver.Response(http.StatusOK, responseNoBulkOperation),
+	}
+	runningBulkOperation := mockserver.Case{
+		If:   mockcond.BodyContains("currentBulkOperation(type: QUERY)"),
+		Then: mockserver.Response(http.StatusOK, responseCurrentBulkRunning),
+	}
+	pagedProducts := mockserver.Case{
+		If:   mockcond.BodyContains(`products(first: $first`),
+		Then: mockserver.Response(http.StatusOK, responseProducts),
+	}
+
+	tests := []testroutines.Read{
+		{
+			Name:         "Read object must be included",
+			Input:        common.ReadParams{},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Unknown object is not supported",
+			Input:        common.ReadParams{ObjectName: "shop", Fields: connectors.Fields("name")},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Error requesting unknown field for the object",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("id", "random_field"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, errorUnknownField),
+			}.Server(),
+			ExpectedErrs: []error{
+				common.ErrBadRequest,
+				errors.New("Field 'random_field' doesn't exist on type 'Product'"),
+			},
+		},
+		{
+			Name: "Invalid access token",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusUnauthorized, errorUnauthorized),
+			}.Server(),
+			ExpectedErrs: []error{
+				common.ErrAccessToken,
+				errors.New("[API] Invalid API key or access token"),
+			},
+		},
+		{
+			Name: "Throttled query is a rate limit error",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, errorThrottled),
+			}.Server(),
+			ExpectedErrs: []error{common.ErrLimitExceeded},
+		},
+		{
+			Name: "Incremental read queries records updated within the time range",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title", "status"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Until:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.Path("/admin/api/2025-01/graphql.json"),
+						mockcond.BodyContains(`products(first: $first, query: $query, sortKey: $sortKey) ` +
+							`{ nodes { id status title } pageInfo { hasNextPage endCursor } }`),
+						mockcond.BodyContains(`"first":250`),
+						// Request bodies are marshaled with HTML characters escaped.
+						mockcond.BodyContains(`"query":"updated_at:\u003e='2025-01-01T00:00:00Z' ` +
+							`AND updated_at:\u003c='2025-01-31T00:00:00Z'"`),
+						mockcond.BodyContains(`"sortKey":"UPDATED_AT"`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseProducts),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Id:     "gid://shopify/Product/8001",
+					Fields: map[string]any{"title": "Snowboard", "status": "ACTIVE"},
+					Raw:    map[string]any{"id": "gid://shopify/Product/8001", "title": "Snowboard"},
+				}, {
+					Id:     "gid://shopify/Product/8002",
+					Fields: map[string]any{"title": "Board Wax", "status": "DRAFT"},
+					Raw:    map[string]any{"id": "gid://shopify/Product/8002", "title": "Board Wax"},
+				}},
+				NextPage: "eyJsYXN0X2lkIjo4MDAyfQ==",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Incremental read continues after the cursor",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				NextPage:   "eyJsYXN0X2lkIjo4MDAyfQ==",
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If:   mockcond.BodyContains(`"after":"eyJsYXN0X2lkIjo4MDAyfQ=="`),
+					Then: mockserver.Response(http.StatusOK, responseProducts),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     2,
+				NextPage: "eyJsYXN0X2lkIjo4MDAyfQ==",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Incremental read of objects without update time sorting omits the sort key",
+			Input: common.ReadParams{
+				ObjectName: "inventoryItems",
+				Fields:     connectors.Fields("sku"),
+				Since:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, {
+					If: mockcond.And{
+						mockcond.BodyContains(`inventoryItems(first: $first, query: $query) ` +
+							`{ nodes { id sku } pageInfo { hasNextPage endCursor } }`),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"data": {"inventoryItems": {
+						"nodes": [{"id": "gid://shopify/InventoryItem/7001", "sku": "SB-154"}],
+						"pageInfo": {"hasNextPage": false, "endCursor": null}
+					}}}`),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Id:     "gid://shopify/InventoryItem/7001",
+					Fields: map[string]any{"sku": "SB-154"},
+					Raw:    map[string]any{"id": "gid://shopify/InventoryItem/7001"},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Backfill starts a bulk operation",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title", "variants.sku"),
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, noBulkOperation, {
+					If: mockcond.And{
+						mockcond.BodyContains(`bulkOperationRunQuery(query: $query)`),
+						mockcond.BodyContains(`"query":"{ products { edges { node { id title ` +
+							`variants { edges { node { bulkConnection0: __typename id sku } } } } } } }"`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseBulkRun),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     0,
+				NextPage: operation.token(),
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Backfill until a time filters the bulk query",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				Until:      time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, noBulkOperation, {
+					If:   mockcond.BodyContains(`products(query: \"updated_at:\u003c='2025-01-31T00:00:00Z'\")`),
+					Then: mockserver.Response(http.StatusOK, responseBulkRun),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     0,
+				NextPage: operation.token(),
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Backfill resumes the running bulk operation of the same query",
+			Input: common.ReadParams{ObjectName: "products", Fields: connectors.Fields("title")},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, runningBulkOperation},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     0,
+				NextPage: bulkPage{OperationID: "gid://shopify/BulkOperation/9000"}.token(),
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Backfill reads pages while another bulk operation runs",
+			Input: common.ReadParams{ObjectName: "products", Fields: connectors.Fields("title", "status")},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, runningBulkOperation, pagedProducts},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     2,
+				NextPage: "eyJsYXN0X2lkIjo4MDAyfQ==",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name:  "Backfill reads pages when another bulk operation started first",
+			Input: common.ReadParams{ObjectName: "products", Fields: connectors.Fields("title")},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{introspection, noBulkOperation, pagedProducts, {
+					If:   mockcond.BodyContains(`bulkOperationRunQuery(query: $query)`),
+					Then: mockserver.Response(http.StatusOK, errorBulkInProgress),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     2,
+				NextPage: "eyJsYXN0X2lkIjo4MDAyfQ==",
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Running bulk operation returns an empty page",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				NextPage:   operation.token(),
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.BodyContains(`... on BulkOperation`),
+						mockcond.BodyContains(`"variables":{"id":"gid://shopify/BulkOperation/9001"}`),
+					},
+					Then: mockserver.Response(http.StatusOK, responseBulkRunning),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorPagination,
+			Expected: &common.ReadResult{
+				Rows:     0,
+				NextPage: operation.token(),
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Failed bulk operation is an error",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				NextPage:   operation.token(),
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, responseBulkFailed),
+			}.Server(),
+			ExpectedErrs: []error{ErrBulkOperation, errors.New("ACCESS_DENIED")},
+		},
+		{
+			Name: "Invalid bulk page token",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				NextPage:   bulkPagePrefix + "%%%",
+			},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{errInvalidBulkPage},
+		},
+		{
+			Name: "Completed bulk operation streams the first page of results",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title", "variants.sku"),
+				NextPage:   operation.token(),
+				PageSize:   2,
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, responseBulkCompleted),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 2,
+				Data: []common.ReadResultRow{{
+					Id:     "gid://shopify/Product/8001",
+					Fields: map[string]any{"title": "Snowboard"},
+					Raw: map[string]any{
+						"id":    "gid://shopify/Product/8001",
+						"title": "Snowboard",
+						"variants": map[string]any{
+							"nodes": []any{
+								map[string]any{"id": "gid://shopify/ProductVariant/8101", "sku": "SB-154"},
+								map[string]any{"id": "gid://shopify/ProductVariant/8102", "sku": "SB-158"},
+							},
+						},
+					},
+				}, {
+					Id:     "gid://shopify/Product/8002",
+					Fields: map[string]any{"title": "Board Wax"},
+					Raw:    map[string]any{"id": "gid://shopify/Product/8002", "title": "Board Wax"},
+				}},
+				NextPage: bulkPage{OperationID: operation.OperationID, Offset: secondPageOffset}.token(),
+				Done:     false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Bulk results of connections which were not selected are an error",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title"),
+				NextPage:   operation.token(),
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, responseBulkCompleted),
+			}.Server(),
+			ExpectedErrs: []error{ErrBulkOperation},
+		},
+		{
+			Name: "Completed bulk operation resumes results at the page offset",
+			Input: common.ReadParams{
+				ObjectName: "products",
+				Fields:     connectors.Fields("title", "variants.sku"),
+				NextPage:   bulkPage{OperationID: operation.OperationID, Offset: secondPageOffset}.token(),
+				PageSize:   2,
+			},
+			Server: mockserver.Switch{
+				Setup:   mockserver.ContentJSON(),
+				Cases:   []mockserver.Case{introspection},
+				Default: mockserver.Response(http.StatusOK, responseBulkCompleted),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Id:     "gid://shopify/Product/8003",
+					Fields: map[string]any{"title": "Bindings"},
+					Raw: map[string]any{
+						"id":    "gid://shopify/Product/8003",
+						"title": "Bindings",
+						"variants": map[string]any{
+							"nodes": []any{
+								map[string]any{"id": "gid://shopify/ProductVariant/8301", "sku": "BD-M"},
+							},
+						},
+					},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func constructTestConnector(serverURL string) (*Connector, error) {
+	connector, err := NewConnector(
+		common.ConnectorParams{
+			AuthenticatedClient: mockutils.NewClient(),
+			Workspace:           "test-store",
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	connector.SetBaseURL(mockutils.ReplaceURLOrigin(connector.HTTPClient().Base, serverURL))
+	// Running bulk operations are polled once, returning an empty page right away.
+	connector.bulkPollInterval = 2 * maxBulkPollDuration
+
+	return connector, nil
+}
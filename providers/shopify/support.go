@@ -0,0 +1,45 @@
+package shopify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/components"
+	"github.com/amp-labs/connectors/internal/datautils"
+)
+
+// updatedAtSortObjects lists objects whose query field accepts the UPDATED_AT sort key.
+// Others, ex: inventoryItems, are returned in the default order of the API, by id.
+var updatedAtSortObjects = datautils.NewSet( //nolint:gochecknoglobals
+	"customers",
+	"orders",
+	"products",
+)
+
+func supportedOperations() components.EndpointRegistryInput {
+	readSupport := []string{
+		"customers",
+		"inventoryItems",
+		"orders",
+		"products",
+	}
+
+	writeSupport := []string{
+		"customers",
+		"products",
+	}
+
+	return components.EndpointRegistryInput{
+		common.ModuleRoot: {
+			{
+				Endpoint: fmt.Sprintf("{%s}", strings.Join(readSupport, ",")),
+				Support:  components.ReadSupport,
+			},
+			{
+				Endpoint: fmt.Sprintf("{%s}", strings.Join(writeSupport, ",")),
+				Support:  components.WriteSupport,
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package shopify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+)
+
+const (
+	apiVersion = "2025-01"
+	// defaultPageSize is the largest page the Admin API returns.
+	defaultPageSize = 250
+	// defaultBulkPageSize is the number of records read at once from bulk operation results.
+	defaultBulkPageSize = 1000
+	// defaultBulkPollInterval is the delay between checks of a running bulk operation.
+	defaultBulkPollInterval = 5 * time.Second
+	// maxBulkPollDuration is how long a read waits for a bulk operation,
+	// after which it returns an empty page to be read again later.
+	maxBulkPollDuration = time.Minute
+	// bulkDownloadResponseTimeout limits waiting for the storage host to respond with bulk results.
+	// The body is not limited, it streams for as long as records are read.
+	bulkDownloadResponseTimeout = 30 * time.Second
+)
+
+// graphQLURL returns the endpoint of the Admin API of the pinned version.
+func (c *Connector) graphQLURL() (*urlbuilder.URL, error) {
+	return urlbuilder.New(c.ProviderInfo().BaseURL, "admin/api", apiVersion, "graphql.json")
+}
+
+// searchQuery filters records by the time they were updated using the search syntax of the Admin API.
+// https://shopify.dev/docs/api/usage/search-syntax
+func searchQuery(params common.ReadParams) string {
+	var terms []string
+
+	if !params.Since.IsZero() {
+		terms = append(terms, fmt.Sprintf("updated_at:>='%s'", params.Since.UTC().Format(time.RFC3339)))
+	}
+
+	if !params.Until.IsZero() {
+		terms = append(terms, fmt.Sprintf("updated_at:<='%s'", params.Until.UTC().Format(time.RFC3339)))
+	}
+
+	return strings.Join(terms, " AND ")
+}
+
+// mutationName returns the name of the mutation writing an object, ex: productCreate, customerUpdate.
+func mutationName(params common.WriteParams) string {
+	name := writeObjectNodePath(params.ObjectName)
+
+	if params.RecordId != "" {
+		return name + "Update"
+	}
+
+	return name + "Create"
+}
+
+// writeObjectNodePath returns the field of a mutation payload holding the written record, ex: product.
+func writeObjectNodePath(objectName string) string {
+	return naming.NewSingularString(objectName).String()
+}
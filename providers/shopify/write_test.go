@@ -0,0 +1,120 @@
+package shopify
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestWrite(t *testing.T) { //nolint:funlen,gocognit,cyclop
+	t.Parallel()
+
+	responseCustomerCreate := testutils.DataFromFile(t, "customers/write-create.json")
+	errorCustomerUserErrors := testutils.DataFromFile(t, "customers/err-user-errors.json")
+	responseProductUpdate := testutils.DataFromFile(t, "products/write-update.json")
+
+	tests := []testroutines.Write{
+		{
+			Name:         "Write object must be included",
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingObjects},
+		},
+		{
+			Name:         "Write needs data payload",
+			Input:        common.WriteParams{ObjectName: "customers"},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingRecordData},
+		},
+		{
+			Name:         "Orders are read only",
+			Input:        common.WriteParams{ObjectName: "orders", RecordData: map[string]any{"note": "gift"}},
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrOperationNotSupportedForObject},
+		},
+		{
+			Name: "Create customer via mutation",
+			Input: common.WriteParams{
+				ObjectName: "customers",
+				RecordData: map[string]any{"firstName": "Ada", "email": "ada@example.com"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.MethodPOST(),
+					mockcond.Path("/admin/api/2025-01/graphql.json"),
+					mockcond.BodyContains(`customerCreate(input: $input)`),
+					mockcond.BodyContains(`"variables":{"input":{"email":"ada@example.com","firstName":"Ada"}}`),
+				},
+				Then: mockserver.Response(http.StatusOK, responseCustomerCreate),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetWrite,
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "gid://shopify/Customer/7001",
+				Data: map[string]any{
+					"id":    "gid://shopify/Customer/7001",
+					"email": "ada@example.com",
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Update product puts its id into the input",
+			Input: common.WriteParams{
+				ObjectName: "products",
+				RecordId:   "gid://shopify/Product/8001",
+				RecordData: map[string]any{"title": "Snowboard Pro"},
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.BodyContains(`productUpdate(product: $input)`),
+					mockcond.BodyContains(`"input":{"id":"gid://shopify/Product/8001","title":"Snowboard Pro"}`),
+				},
+				Then: mockserver.Response(http.StatusOK, responseProductUpdate),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetWrite,
+			Expected: &common.WriteResult{
+				Success:  true,
+				RecordId: "gid://shopify/Product/8001",
+				Data: map[string]any{
+					"title": "Snowboard Pro",
+				},
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "User errors fail the write",
+			Input: common.WriteParams{
+				ObjectName: "customers",
+				RecordData: map[string]any{"email": "ada@example.com"},
+			},
+			Server: mockserver.Fixed{
+				Setup:  mockserver.ContentJSON(),
+				Always: mockserver.Response(http.StatusOK, errorCustomerUserErrors),
+			}.Server(),
+			ExpectedErrs: []error{
+				common.ErrBadRequest,
+				errors.New("Email has already been taken"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.WriteConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
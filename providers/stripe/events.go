@@ -0,0 +1,370 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/jsonquery"
+	"github.com/amp-labs/connectors/internal/simultaneously"
+	"github.com/spyzhov/ajson"
+)
+
+// Incremental reading via the Events API.
+//
+// Listing endpoints only filter by creation time, so updates and deletions are invisible to them.
+// Instead, every change to an object within the retention window is recorded as an event.
+// Events are listed newest first, collapsed per object ID on every page, and then:
+//   - for regular reads the current state of each changed object is fetched,
+//     so duplicates across pages are harmless and always reflect the latest state;
+//   - for deleted reads the snapshot attached to the "*.deleted" event is returned.
+//
+// https://docs.stripe.com/api/events
+const (
+	// eventsRetention is how long Stripe keeps events.
+	// Checkpoints older than that fall back to listing by creation time.
+	eventsRetention = 30 * 24 * time.Hour
+
+	eventsPath         = "events"
+	eventSuffixDeleted = ".deleted"
+
+	// refetchConcurrency limits parallel requests to retrieve changed objects.
+	refetchConcurrency = 10
+)
+
+// eventObject describes how events relate to a readable object.
+type eventObject struct {
+	// typePrefix is the event type without action, ex: "customer.subscription" for "customer.subscription.updated".
+	typePrefix string
+	// objectType is the "object" property of the event payload.
+	// Wildcard types match nested resources as well, which must be filtered out.
+	objectType string
+}
+
+// eventObjects lists objects which support events-driven incremental reading.
+var eventObjects = map[string]eventObject{ // nolint:gochecknoglobals
+	"charges":                {typePrefix: "charge", objectType: "charge"},
+	"checkout/sessions":      {typePrefix: "checkout.session", objectType: "checkout.session"},
+	"coupons":                {typePrefix: "coupon", objectType: "coupon"},
+	"credit_notes":           {typePrefix: "credit_note", objectType: "credit_note"},
+	"customers":              {typePrefix: "customer", objectType: "customer"},
+	"disputes":               {typePrefix: "charge.dispute", objectType: "dispute"},
+	"invoiceitems":           {typePrefix: "invoiceitem", objectType: "invoiceitem"},
+	"invoices":               {typePrefix: "invoice", objectType: "invoice"},
+	"payment_intents":        {typePrefix: "payment_intent", objectType: "payment_intent"},
+	"payment_methods":        {typePrefix: "payment_method", objectType: "payment_method"},
+	"payouts":                {typePrefix: "payout", objectType: "payout"},
+	"plans":                  {typePrefix: "plan", objectType: "plan"},
+	"prices":                 {typePrefix: "price", objectType: "price"},
+	"products":               {typePrefix: "product", objectType: "product"},
+	"promotion_codes":        {typePrefix: "promotion_code", objectType: "promotion_code"},
+	"refunds":                {typePrefix: "refund", objectType: "refund"},
+	"setup_intents":          {typePrefix: "setup_intent", objectType: "setup_intent"},
+	"subscription_schedules": {typePrefix: "subscription_schedule", objectType: "subscription_schedule"},
+	"subscriptions":          {typePrefix: "customer.subscription", objectType: "subscription"},
+	"tax_rates":              {typePrefix: "tax_rate", objectType: "tax_rate"},
+	"transfers":              {typePrefix: "transfer", objectType: "transfer"},
+}
+
+// isEventsRead decides whether the Events API should serve this read.
+// Deleted records are only discoverable via events, in which case the whole retention window is scanned.
+// Otherwise, events are used when the checkpoint is recent enough for events to cover every change since.
+func isEventsRead(config common.ReadParams, now time.Time) bool {
+	if _, ok := eventObjects[config.ObjectName]; !ok {
+		return false
+	}
+
+	if len(config.NextPage) != 0 {
+		return isEventsPage(config.NextPage)
+	}
+
+	if config.Deleted {
+		return true
+	}
+
+	return !config.Since.IsZero() && now.Sub(config.Since) < eventsRetention
+}
+
+// isEventsPage tells if next page token was produced by the events read.
+func isEventsPage(nextPage common.NextPageToken) bool {
+	url, err := urlbuilder.New(nextPage.String())
+	if err != nil {
+		return false
+	}
+
+	return strings.HasSuffix(url.Path(), "/"+apiVersion+"/"+eventsPath)
+}
+
+func (c *Connector) readEvents(ctx context.Context, config common.ReadParams) (*common.ReadResult, error) {
+	object := eventObjects[config.ObjectName]
+
+	url, err := c.buildEventsURL(config, object, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	body, ok := res.Body()
+	if !ok {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	changes, err := collapseEvents(body, object)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]any
+	if config.Deleted {
+		records = changes.deletedSnapshots()
+	} else {
+		records, err = c.refetchObjects(ctx, config, changes.updatedIdentifiers())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	nextPage, err := makeNextRecordsURL(url)(body)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := common.GetMarshaledData(records, config.Fields.List())
+	if err != nil {
+		return nil, err
+	}
+
+	// Unlike other reads, a page may produce no rows because all events were filtered out.
+	// Only the events cursor tells when the read is over.
+	return &common.ReadResult{
+		Rows:     int64(len(rows)),
+		Data:     rows,
+		NextPage: common.NextPageToken(nextPage),
+		Done:     nextPage == "",
+	}, nil
+}
+
+func (c *Connector) buildEventsURL(
+	config common.ReadParams, object eventObject, now time.Time,
+) (*urlbuilder.URL, error) {
+	if len(config.NextPage) != 0 {
+		return urlbuilder.New(config.NextPage.String())
+	}
+
+	url, err := c.getURL(eventsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("limit", strconv.Itoa(DefaultPageSize))
+
+	eventType := object.typePrefix + ".*"
+	if config.Deleted {
+		eventType = object.typePrefix + eventSuffixDeleted
+	}
+
+	withEventTypes(url, eventType)
+
+	since := config.Since
+	if windowStart := now.Add(-eventsRetention); since.Before(windowStart) {
+		since = windowStart
+	}
+
+	url.WithQueryParam("created[gte]", strconv.FormatInt(since.Unix(), 10))
+
+	if !config.Until.IsZero() {
+		url.WithQueryParam("created[lte]", strconv.FormatInt(config.Until.Unix(), 10))
+	}
+
+	return url, nil
+}
+
+// objectChanges holds the latest event per object, ordered from the oldest change to the newest.
+type objectChanges []objectChange
+
+type objectChange struct {
+	identifier string
+	deleted    bool
+	snapshot   map[string]any
+}
+
+// collapseEvents keeps only the newest event for each object.
+// Events of nested resources sharing the type prefix are ignored,
+// ex: "customer.source.created" when reading customers.
+func collapseEvents(body *ajson.Node, object eventObject) (objectChanges, error) {
+	events, err := jsonquery.New(body).ArrayOptional("data")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	changes := make(objectChanges, 0, len(events))
+
+	// Events are sorted newest first.
+	for _, event := range events {
+		eventType, err := jsonquery.New(event).StringRequired("type")
+		if err != nil {
+			return nil, err
+		}
+
+		snapshotNode, err := jsonquery.New(event, "data").ObjectRequired("object")
+		if err != nil {
+			return nil, err
+		}
+
+		objectType, err := jsonquery.New(snapshotNode).StringOptional("object")
+		if err != nil {
+			return nil, err
+		}
+
+		if objectType == nil || *objectType != object.objectType {
+			continue
+		}
+
+		identifier, err := jsonquery.New(snapshotNode).StringRequired("id")
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[identifier] {
+			continue
+		}
+
+		seen[identifier] = true
+
+		snapshot, err := jsonquery.Convertor.ObjectToMap(snapshotNode)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, objectChange{
+			identifier: identifier,
+			deleted:    strings.HasSuffix(eventType, eventSuffixDeleted),
+			snapshot:   snapshot,
+		})
+	}
+
+	slices.Reverse(changes)
+
+	return changes, nil
+}
+
+func (c objectChanges) deletedSnapshots() []map[string]any {
+	records := make([]map[string]any, 0, len(c))
+
+	for _, change := range c {
+		if change.deleted {
+			records = append(records, change.snapshot)
+		}
+	}
+
+	return records
+}
+
+func (c objectChanges) updatedIdentifiers() []string {
+	identifiers := make([]string, 0, len(c))
+
+	for _, change := range c {
+		if !change.deleted {
+			identifiers = append(identifiers, change.identifier)
+		}
+	}
+
+	return identifiers
+}
+
+// refetchObjects retrieves the current state of each object preserving the order of identifiers.
+// Objects deleted since the event was recorded are skipped, they will be reported by the deleted read.
+func (c *Connector) refetchObjects(
+	ctx context.Context, config common.ReadParams, identifiers []string,
+) ([]map[string]any, error) {
+	var (
+		mutex   sync.Mutex
+		results = make([]map[string]any, len(identifiers))
+		jobs    = make([]simultaneously.Job, 0, len(identifiers))
+	)
+
+	for index, identifier := range identifiers {
+		jobs = append(jobs, func(ctx context.Context) error {
+			record, err := c.fetchObject(ctx, config, identifier)
+			if err != nil {
+				if errors.Is(err, common.ErrNotFound) {
+					return nil
+				}
+
+				return err
+			}
+
+			mutex.Lock()
+			results[index] = record
+			mutex.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := simultaneously.DoCtx(ctx, refetchConcurrency, jobs...); err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]any, 0, len(results))
+
+	for _, record := range results {
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+func (c *Connector) fetchObject(
+	ctx context.Context, config common.ReadParams, identifier string,
+) (map[string]any, error) {
+	url, err := c.getURL(config.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	url.AddPath(identifier)
+
+	if len(config.AssociatedObjects) != 0 {
+		url.WithQueryParamList("expand[]", config.AssociatedObjects)
+	}
+
+	res, err := c.Client.Get(ctx, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := common.UnmarshalJSON[map[string]any](res)
+	if err != nil {
+		return nil, err
+	}
+
+	return *record, nil
+}
+
+// withEventTypes filters events by their type.
+// Wildcards, ex: customer.*, are accepted only by the type parameter, which takes a single value.
+// Lists of exact types go to types[] instead.
+// https://docs.stripe.com/api/events/list
+func withEventTypes(url *urlbuilder.URL, eventTypes ...string) {
+	if len(eventTypes) == 1 {
+		url.WithQueryParam("type", eventTypes[0])
+
+		return
+	}
+
+	url.WithQueryParamList("types[]", eventTypes)
+}
@@ -0,0 +1,185 @@
+package stripe
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestReadEvents(t *testing.T) { //nolint:funlen
+	t.Parallel()
+
+	responseChanges := testutils.DataFromFile(t, "read/events/customers-changes.json")
+	responseDeleted := testutils.DataFromFile(t, "read/events/customers-deleted.json")
+	responseCustomer := testutils.DataFromFile(t, "read/events/customer-current.json")
+	responseMissing := testutils.DataFromFile(t, "read/events/customer-missing.json")
+
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	sinceUnix := strconv.FormatInt(since.Unix(), 10)
+
+	tests := []testroutines.Read{
+		{
+			Name: "Recent checkpoint reads current state of changed customers",
+			Input: common.ReadParams{
+				ObjectName: "customers",
+				Fields:     connectors.Fields("name"),
+				Since:      since,
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.Path("/v1/events"),
+						mockcond.QueryParam("type", "customer.*"),
+						mockcond.QueryParamsMissing("types[]"),
+						mockcond.QueryParam("created[gte]", sinceUnix),
+					},
+					Then: mockserver.Response(http.StatusOK, responseChanges),
+				}, {
+					If:   mockcond.Path("/v1/customers/cus_SmNkq0r8fFZwbY"),
+					Then: mockserver.Response(http.StatusOK, responseCustomer),
+				}, {
+					// Deleted after the event was recorded.
+					If:   mockcond.Path("/v1/customers/cus_SmNiXo6z3aBq1M"),
+					Then: mockserver.Response(http.StatusNotFound, responseMissing),
+				}},
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{
+						"name": "Freddy Buckley",
+					},
+					Raw: map[string]any{
+						"id":     "cus_SmNkq0r8fFZwbY",
+						"object": "customer",
+						"name":   "Freddy Buckley",
+						"email":  "freddy.buckley@company.com",
+					},
+				}},
+				NextPage: common.NextPageToken(testroutines.URLTestServer + "/v1/events?created%5Bgte%5D=" + sinceUnix +
+					"&limit=100&starting_after=evt_1RrVzZFLEwOmHEGUm1sXk0Pa&type=customer.%2A"),
+				Done: false,
+			},
+			ExpectedErrs: nil,
+		},
+		{
+			Name: "Deleted customers are taken from event snapshots",
+			Input: common.ReadParams{
+				ObjectName: "customers",
+				Fields:     connectors.Fields("name"),
+				Since:      since,
+				Deleted:    true,
+			},
+			Server: mockserver.Conditional{
+				Setup: mockserver.ContentJSON(),
+				If: mockcond.And{
+					mockcond.Path("/v1/events"),
+					mockcond.QueryParam("type", "customer.deleted"),
+				},
+				Then: mockserver.Response(http.StatusOK, responseDeleted),
+			}.Server(),
+			Comparator: testroutines.ComparatorSubsetRead,
+			Expected: &common.ReadResult{
+				Rows: 1,
+				Data: []common.ReadResultRow{{
+					Fields: map[string]any{
+						"name": "Lena Ortiz",
+					},
+					Raw: map[string]any{
+						"id":     "cus_SmNj3v5b5tP7fT",
+						"object": "customer",
+						"name":   "Lena Ortiz",
+					},
+				}},
+				NextPage: "",
+				Done:     true,
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.ReadConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
+
+func TestIsEventsRead(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		input    common.ReadParams
+		expected bool
+	}{
+		{
+			name:     "Full read lists objects",
+			input:    common.ReadParams{ObjectName: "customers"},
+			expected: false,
+		},
+		{
+			name:     "Recent checkpoint uses events",
+			input:    common.ReadParams{ObjectName: "customers", Since: now.Add(-24 * time.Hour)},
+			expected: true,
+		},
+		{
+			name:     "Checkpoint beyond retention lists objects",
+			input:    common.ReadParams{ObjectName: "customers", Since: now.Add(-31 * 24 * time.Hour)},
+			expected: false,
+		},
+		{
+			name:     "Deleted records require events",
+			input:    common.ReadParams{ObjectName: "invoices", Deleted: true},
+			expected: true,
+		},
+		{
+			name:     "Objects without events are listed",
+			input:    common.ReadParams{ObjectName: "balance_transactions", Since: now.Add(-time.Hour)},
+			expected: false,
+		},
+		{
+			name: "Listing next page is continued",
+			input: common.ReadParams{
+				ObjectName: "customers", Since: now.Add(-time.Hour),
+				NextPage: "https://api.stripe.com/v1/customers?starting_after=cus_Rd3NjdGWtynChD",
+			},
+			expected: false,
+		},
+		{
+			name: "Events next page is continued",
+			input: common.ReadParams{
+				ObjectName: "customers",
+				NextPage:   "https://api.stripe.com/v1/events?starting_after=evt_1RrVzZFLEwOmHEGUm1sXk0Pa",
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if output := isEventsRead(tt.input, now); output != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, output)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package stripe
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/amp-labs/connectors/common"
 	"github.com/amp-labs/connectors/common/urlbuilder"
@@ -13,7 +14,10 @@ import (
 // Read retrieves a list of items for a given object.
 // Features:
 //   - NextPage: Supported for those objects that Stripe paginates.
-//   - Incremental Reading: The `Since` parameter is not supported.
+//   - Incremental Reading: For objects listed in eventObjects, checkpoints within the last 30 days
+//     are served by the Events API, which captures updates as well as creations.
+//     Older checkpoints, and other objects, only pick up records created since then.
+//   - Deleted: Supported for objects listed in eventObjects, limited to the last 30 days.
 //   - AssociatedObjects: This parameter allows fetching nested objects. You need to specify list of fields to expand.
 //     For more details, refer to the Stripe documentation on expanding objects:
 //     https://docs.stripe.com/api/expanding_objects
@@ -22,6 +26,10 @@ func (c *Connector) Read(ctx context.Context, config common.ReadParams) (*common
 		return nil, err
 	}
 
+	if isEventsRead(config, time.Now()) {
+		return c.readEvents(ctx, config)
+	}
+
 	url, err := c.buildReadURL(config)
 	if err != nil {
 		return nil, err
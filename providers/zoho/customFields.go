@@ -0,0 +1,411 @@
+package zoho
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/naming"
+	"github.com/amp-labs/connectors/common/urlbuilder"
+	"github.com/amp-labs/connectors/internal/datautils"
+	"github.com/amp-labs/connectors/providers"
+)
+
+const (
+	// Longest value accepted by single line text fields. Longer text goes to multi line fields.
+	crmTextMaxLength = 255
+	// Number of digits Zoho assigns to integer fields by default.
+	crmIntegerLength = 9
+)
+
+// crmFieldPayload is the subset of field metadata we manage.
+// It describes a field both in requests and when comparing with existing fields.
+// doc: https://www.zoho.com/crm/developer/docs/api/v6/create-custom-fields.html
+//
+//nolint:tagliatelle
+type crmFieldPayload struct {
+	APIName        string          `json:"api_name,omitempty"`
+	FieldLabel     string          `json:"field_label"`
+	DataType       string          `json:"data_type,omitempty"`
+	Length         int             `json:"length,omitempty"`
+	DecimalPlace   int             `json:"decimal_place,omitempty"`
+	Tooltip        *crmTooltip     `json:"tooltip,omitempty"`
+	PickListValues []crmPickValue  `json:"pick_list_values,omitempty"`
+	Unique         *crmFieldUnique `json:"unique,omitempty"`
+}
+
+type crmTooltip struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+//nolint:tagliatelle
+type crmPickValue struct {
+	DisplayValue string `json:"display_value"`
+	ActualValue  string `json:"actual_value"`
+}
+
+type crmFieldUnique struct {
+	CaseSensitive bool `json:"casesensitive"`
+}
+
+// crmExistingField is a field as returned by the fields metadata API.
+//
+//nolint:tagliatelle
+type crmExistingField struct {
+	crmFieldPayload
+
+	ID          string `json:"id"`
+	CustomField bool   `json:"custom_field"`
+}
+
+//nolint:tagliatelle
+type crmFieldsResponse struct {
+	Fields []struct {
+		Code    string         `json:"code"`
+		Status  string         `json:"status"`
+		Message string         `json:"message"`
+		Details map[string]any `json:"details"`
+	} `json:"fields"`
+}
+
+// UpsertMetadata creates custom fields of Zoho CRM modules or updates them if they already exist.
+// Fields are matched by API name, falling back to the label, because Zoho derives API names from labels.
+// Fields whose definition matches are left untouched.
+// A dry run compares definitions with existing fields the same way, but doesn't write them.
+func (c *Connector) UpsertMetadata(
+	ctx context.Context, params *common.UpsertMetadataParams,
+) (*common.UpsertMetadataResult, error) {
+	if err := params.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	if c.moduleID != providers.ModuleZohoCRM {
+		return nil, common.ErrNotImplemented
+	}
+
+	result := &common.UpsertMetadataResult{
+		Success: true,
+		Fields:  make(map[string]map[string]common.FieldUpsertResult, len(params.Fields)),
+		DryRun:  params.DryRun,
+	}
+
+	for objectName, definitions := range params.Fields {
+		fields, err := c.upsertCRMFields(ctx, objectName, definitions, params.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("object %s: %w", objectName, err)
+		}
+
+		result.Fields[objectName] = fields
+	}
+
+	return result, nil
+}
+
+func (c *Connector) upsertCRMFields(
+	ctx context.Context, objectName string, definitions []common.FieldDefinition, dryRun bool,
+) (map[string]common.FieldUpsertResult, error) {
+	module := naming.CapitalizeFirstLetterEveryWord(objectName)
+
+	existing, err := c.fetchCRMFields(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]common.FieldUpsertResult, len(definitions))
+
+	for _, definition := range definitions {
+		payload, warnings, err := newCRMFieldPayload(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		current := findCRMField(existing, definition)
+
+		action := common.UpsertMetadataActionNone
+		metadata := map[string]any{}
+
+		switch {
+		case current == nil:
+			action = common.UpsertMetadataActionCreate
+
+			if !dryRun {
+				metadata, err = c.writeCRMField(ctx, module, "", payload)
+			}
+		case !current.matches(payload):
+			action = common.UpsertMetadataActionUpdate
+
+			if current.DataType != payload.DataType {
+				warnings = append(warnings, fmt.Sprintf(
+					"data type cannot be changed from %s to %s", current.DataType, payload.DataType))
+			}
+
+			// Neither API name nor data type can be changed.
+			payload.APIName = ""
+			payload.DataType = ""
+
+			if !dryRun {
+				metadata, err = c.writeCRMField(ctx, module, current.ID, payload)
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", definition.FieldName, err)
+		}
+
+		if current != nil {
+			metadata["id"] = current.ID
+			metadata["api_name"] = current.APIName
+		}
+
+		fields[definition.FieldName] = common.FieldUpsertResult{
+			FieldName: definition.FieldName,
+			Action:    action,
+			Metadata:  metadata,
+			Warnings:  warnings,
+		}
+	}
+
+	return fields, nil
+}
+
+func (c *Connector) fetchCRMFields(ctx context.Context, module string) ([]crmExistingField, error) {
+	resp, err := c.fetchCRMFieldResponse(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[struct {
+		Fields []crmExistingField `json:"fields"`
+	}](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if response == nil {
+		return nil, nil
+	}
+
+	return response.Fields, nil
+}
+
+// writeCRMField creates a field when the identifier is empty, otherwise updates it.
+// doc: https://www.zoho.com/crm/developer/docs/api/v6/update-custom-field.html
+func (c *Connector) writeCRMField(
+	ctx context.Context, module, identifier string, payload *crmFieldPayload,
+) (map[string]any, error) {
+	url, err := c.getCRMFieldsURL(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	url.WithQueryParam("module", module)
+
+	body := map[string]any{"fields": []*crmFieldPayload{payload}}
+
+	var resp *common.JSONHTTPResponse
+	if identifier == "" {
+		resp, err = c.Client.Post(ctx, url.String(), body)
+	} else {
+		resp, err = c.Client.Patch(ctx, url.String(), body)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := common.UnmarshalJSON[crmFieldsResponse](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if response == nil || len(response.Fields) == 0 {
+		return nil, common.ErrEmptyJSONHTTPResponse
+	}
+
+	outcome := response.Fields[0]
+	if !strings.EqualFold(outcome.Status, "success") {
+		return nil, fmt.Errorf("%w: %s %s", common.ErrBadRequest, outcome.Code, outcome.Message)
+	}
+
+	if outcome.Details == nil {
+		return map[string]any{}, nil
+	}
+
+	return outcome.Details, nil
+}
+
+func (c *Connector) getCRMFieldsURL(identifier string) (*urlbuilder.URL, error) {
+	if identifier == "" {
+		return c.getAPIURL(crmAPIVersion, restMetadataEndpoint)
+	}
+
+	return urlbuilder.New(c.BaseURL, crmAPIVersion, restMetadataEndpoint, identifier)
+}
+
+func findCRMField(fields []crmExistingField, definition common.FieldDefinition) *crmExistingField {
+	for index, field := range fields {
+		if field.APIName == definition.FieldName {
+			return &fields[index]
+		}
+	}
+
+	for index, field := range fields {
+		if field.CustomField && strings.EqualFold(field.FieldLabel, definition.DisplayName) {
+			return &fields[index]
+		}
+	}
+
+	return nil
+}
+
+// matches reports whether updating the field with the payload would change anything.
+func (f crmExistingField) matches(payload *crmFieldPayload) bool {
+	if f.FieldLabel != payload.FieldLabel || f.DataType != payload.DataType {
+		return false
+	}
+
+	if payload.Length != 0 && f.Length != payload.Length {
+		return false
+	}
+
+	if payload.DecimalPlace != 0 && f.DecimalPlace != payload.DecimalPlace {
+		return false
+	}
+
+	if payload.Tooltip != nil && (f.Tooltip == nil || f.Tooltip.Value != payload.Tooltip.Value) {
+		return false
+	}
+
+	if len(payload.PickListValues) == 0 {
+		return true
+	}
+
+	// Zoho adds "-None-" to every pick list.
+	current := datautils.NewStringSet()
+	for _, value := range f.PickListValues {
+		current.AddOne(value.ActualValue)
+	}
+
+	current.Remove("-None-")
+
+	desired := datautils.NewStringSet()
+	for _, value := range payload.PickListValues {
+		desired.AddOne(value.ActualValue)
+	}
+
+	return reflect.DeepEqual(current, desired)
+}
+
+func newCRMFieldPayload(definition common.FieldDefinition) (*crmFieldPayload, []string, error) {
+	payload := &crmFieldPayload{
+		APIName:    definition.FieldName,
+		FieldLabel: definition.DisplayName,
+	}
+
+	if definition.Description != "" {
+		payload.Tooltip = &crmTooltip{Name: "Info Icon", Value: definition.Description}
+	}
+
+	var warnings []string
+
+	switch definition.ValueType {
+	case common.FieldTypeString:
+		payload.DataType = "text"
+		payload.Length = crmTextMaxLength
+
+		if options := definition.StringOptions; options != nil && options.Length != nil {
+			payload.Length = *options.Length
+		}
+
+		if payload.Length > crmTextMaxLength || isMultiLine(definition.StringOptions) {
+			payload.DataType = "textarea"
+			payload.Length = 0
+		}
+
+		if definition.Unique {
+			payload.Unique = &crmFieldUnique{CaseSensitive: false}
+		}
+	case common.FieldTypeBoolean:
+		payload.DataType = "boolean"
+	case common.FieldTypeDate:
+		payload.DataType = "date"
+	case common.FieldTypeDateTime:
+		payload.DataType = "datetime"
+	case common.FieldTypeSingleSelect, common.FieldTypeMultiSelect:
+		payload.DataType = "picklist"
+		if definition.ValueType == common.FieldTypeMultiSelect {
+			payload.DataType = "multiselectpicklist"
+		}
+
+		if definition.StringOptions != nil {
+			for _, value := range definition.StringOptions.Values {
+				payload.PickListValues = append(payload.PickListValues, crmPickValue{
+					DisplayValue: value,
+					ActualValue:  value,
+				})
+			}
+		}
+	case common.FieldTypeInt:
+		payload.DataType = "integer"
+		payload.Length = crmIntegerLength
+	case common.FieldTypeFloat:
+		payload.DataType = "double"
+
+		if options := definition.NumericOptions; options != nil {
+			if options.Precision != nil {
+				payload.Length = *options.Precision
+			}
+
+			if options.Scale != nil {
+				payload.DecimalPlace = *options.Scale
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("%w, fieldName: %v", common.ErrFieldTypeUnknown, definition.FieldName)
+	}
+
+	if definition.Unique && payload.Unique == nil {
+		warnings = append(warnings, "unique values can only be enforced for text fields")
+	}
+
+	if definition.Required {
+		warnings = append(warnings, "required fields are configured per layout and were not changed")
+	}
+
+	if definition.Indexed {
+		warnings = append(warnings, "indexing is not configurable")
+	}
+
+	if definition.Association != nil {
+		warnings = append(warnings, "lookup fields are not supported")
+	}
+
+	if options := definition.StringOptions; options != nil {
+		if options.Pattern != "" {
+			warnings = append(warnings, "pattern validation is not supported")
+		}
+
+		if options.DefaultValue != nil {
+			warnings = append(warnings, "default values are not supported")
+		}
+	}
+
+	if options := definition.NumericOptions; options != nil {
+		if options.Min != nil || options.Max != nil {
+			warnings = append(warnings, "value range is not supported")
+		}
+
+		if options.DefaultValue != nil {
+			warnings = append(warnings, "default values are not supported")
+		}
+	}
+
+	return payload, warnings, nil
+}
+
+func isMultiLine(options *common.StringFieldOptions) bool {
+	return options != nil && options.NumDisplayLines != nil && *options.NumDisplayLines > 1
+}
@@ -0,0 +1,121 @@
+package zoho
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/internal/goutils"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockcond"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+	"github.com/amp-labs/connectors/test/utils/testroutines"
+	"github.com/amp-labs/connectors/test/utils/testutils"
+)
+
+func TestUpsertMetadata(t *testing.T) { // nolint:funlen
+	t.Parallel()
+
+	responseFields := testutils.DataFromFile(t, "custom-fields/leads-fields.json")
+
+	tests := []testroutines.UpsertMetadata{
+		{
+			Name:         "At least one field must be provided",
+			Input:        nil,
+			Server:       mockserver.Dummy(),
+			ExpectedErrs: []error{common.ErrMissingFieldsMetadata},
+		},
+		{
+			Name: "Fields are created, updated or left as is",
+			Input: &common.UpsertMetadataParams{
+				Fields: map[string][]common.FieldDefinition{
+					"leads": {{
+						FieldName:     "Priority",
+						DisplayName:   "Priority",
+						ValueType:     common.FieldTypeSingleSelect,
+						StringOptions: &common.StringFieldOptions{Values: []string{"Low", "High"}},
+					}, {
+						FieldName:   "Budget",
+						DisplayName: "Yearly Budget",
+						ValueType:   common.FieldTypeFloat,
+						NumericOptions: &common.NumericFieldOptions{
+							Scale: goutils.Pointer(2),
+							Min:   goutils.Pointer(0.0),
+						},
+					}, {
+						FieldName:     "Nickname",
+						DisplayName:   "Nickname",
+						Description:   "How the lead likes to be called",
+						ValueType:     common.FieldTypeString,
+						StringOptions: &common.StringFieldOptions{Length: goutils.Pointer(40)},
+					}},
+				},
+			},
+			Server: mockserver.Switch{
+				Setup: mockserver.ContentJSON(),
+				Cases: []mockserver.Case{{
+					If: mockcond.And{
+						mockcond.MethodGET(),
+						mockcond.Path("/crm/v6/settings/fields"),
+						mockcond.QueryParam("module", "Leads"),
+					},
+					Then: mockserver.Response(http.StatusOK, responseFields),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPATCH(),
+						mockcond.Path("/crm/v6/settings/fields/5725767000005471017"),
+						mockcond.QueryParam("module", "Leads"),
+						mockcond.Body(`{"fields":[{"field_label":"Yearly Budget","decimal_place":2}]}`),
+					},
+					Then: mockserver.ResponseString(http.StatusOK, `{"fields":[{"code":"SUCCESS",
+						"details":{"id":"5725767000005471017"},"message":"field updated","status":"success"}]}`),
+				}, {
+					If: mockcond.And{
+						mockcond.MethodPOST(),
+						mockcond.Path("/crm/v6/settings/fields"),
+						mockcond.Body(`{"fields":[{"api_name":"Nickname","field_label":"Nickname",
+							"data_type":"text","length":40,
+							"tooltip":{"name":"Info Icon","value":"How the lead likes to be called"}}]}`),
+					},
+					Then: mockserver.ResponseString(http.StatusCreated, `{"fields":[{"code":"SUCCESS",
+						"details":{"id":"5725767000005471033"},"message":"field created","status":"success"}]}`),
+				}},
+			}.Server(),
+			Expected: &common.UpsertMetadataResult{
+				Success: true,
+				Fields: map[string]map[string]common.FieldUpsertResult{
+					"leads": {
+						"Priority": {
+							FieldName: "Priority",
+							Action:    common.UpsertMetadataActionNone,
+							Metadata:  map[string]any{"id": "5725767000005471001", "api_name": "Priority"},
+						},
+						"Budget": {
+							FieldName: "Budget",
+							Action:    common.UpsertMetadataActionUpdate,
+							Metadata:  map[string]any{"id": "5725767000005471017", "api_name": "Budget"},
+							Warnings:  []string{"value range is not supported"},
+						},
+						"Nickname": {
+							FieldName: "Nickname",
+							Action:    common.UpsertMetadataActionCreate,
+							Metadata:  map[string]any{"id": "5725767000005471033"},
+						},
+					},
+				},
+			},
+			ExpectedErrs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		// nolint:varnamelen
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.Run(t, func() (connectors.UpsertMetadataConnector, error) {
+				return constructTestConnector(tt.Server.URL)
+			})
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package shopify
+
+import (
+	"context"
+
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/common/scanning/credscanning"
+	"github.com/amp-labs/connectors/providers"
+	"github.com/amp-labs/connectors/providers/shopify"
+	"github.com/amp-labs/connectors/test/utils"
+)
+
+func GetShopifyConnector(ctx context.Context) *shopify.Connector {
+	filePath := credscanning.LoadPath(providers.Shopify)
+	reader := utils.MustCreateProvCredJSON(filePath, false, credscanning.Fields.Workspace)
+
+	client, err := common.NewApiKeyHeaderAuthHTTPClient(ctx,
+		"X-Shopify-Access-Token", reader.Get(credscanning.Fields.ApiKey))
+	if err != nil {
+		utils.Fail("error creating client", "error", err)
+	}
+
+	conn, err := shopify.NewConnector(common.ConnectorParams{
+		AuthenticatedClient: client,
+		Workspace:           reader.Get(credscanning.Fields.Workspace),
+	})
+	if err != nil {
+		utils.Fail("error creating connector", "error", err)
+	}
+
+	return conn
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	connTest "github.com/amp-labs/connectors/test/shopify"
+	"github.com/amp-labs/connectors/test/utils"
+)
+
+func main() {
+	// Handle Ctrl-C gracefully.
+	ctx, done := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer done()
+
+	// Set up slog logging.
+	utils.SetupLogging()
+
+	conn := connTest.GetShopifyConnector(ctx)
+
+	// Incremental read of products updated during the last month.
+	res, err := conn.Read(ctx, common.ReadParams{
+		ObjectName: "products",
+		Fields:     connectors.Fields("title", "status", "updatedAt", "variants.sku"),
+		Since:      time.Now().Add(-30 * 24 * time.Hour),
+	})
+	if err != nil {
+		utils.Fail("error reading from Shopify", "error", err)
+	}
+
+	utils.DumpJSON(res, os.Stdout)
+
+	// Backfill of every order using a bulk operation, pages are empty until the operation completes.
+	params := common.ReadParams{
+		ObjectName: "orders",
+		Fields:     connectors.Fields("name", "email", "updatedAt", "lineItems.quantity"),
+	}
+
+	for {
+		res, err = conn.Read(ctx, params)
+		if err != nil {
+			utils.Fail("error reading from Shopify", "error", err)
+		}
+
+		utils.DumpJSON(res, os.Stdout)
+
+		if res.Done {
+			break
+		}
+
+		params.NextPage = res.NextPage
+	}
+}
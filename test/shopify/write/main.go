@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/amp-labs/connectors/common"
+	connTest "github.com/amp-labs/connectors/test/shopify"
+	"github.com/amp-labs/connectors/test/utils"
+	"github.com/brianvoe/gofakeit/v6"
+)
+
+func main() {
+	// Handle Ctrl-C gracefully.
+	ctx, done := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer done()
+
+	// Set up slog logging.
+	utils.SetupLogging()
+
+	conn := connTest.GetShopifyConnector(ctx)
+
+	created, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "customers",
+		RecordData: map[string]any{
+			"firstName": gofakeit.FirstName(),
+			"lastName":  gofakeit.LastName(),
+			"email":     gofakeit.Email(),
+		},
+	})
+	if err != nil {
+		utils.Fail("error creating a customer in Shopify", "error", err)
+	}
+
+	utils.DumpJSON(created, os.Stdout)
+
+	updated, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "customers",
+		RecordId:   created.RecordId,
+		RecordData: map[string]any{
+			"note": "Updated by the connectors test suite",
+		},
+	})
+	if err != nil {
+		utils.Fail("error updating a customer in Shopify", "error", err)
+	}
+
+	utils.DumpJSON(updated, os.Stdout)
+
+	product, err := conn.Write(ctx, common.WriteParams{
+		ObjectName: "products",
+		RecordData: map[string]any{
+			"title":  gofakeit.ProductName(),
+			"status": "DRAFT",
+		},
+	})
+	if err != nil {
+		utils.Fail("error creating a product in Shopify", "error", err)
+	}
+
+	utils.DumpJSON(product, os.Stdout)
+}
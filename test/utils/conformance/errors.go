@@ -0,0 +1,109 @@
+package conformance
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+	"github.com/amp-labs/connectors/test/utils/mockutils/mockserver"
+)
+
+// DefaultStatusErrors lists documented errors for failed responses.
+// A connector must wrap at least one error listed for the status.
+// Connectors relying on common.InterpretError report some statuses with more general errors,
+// ex: ErrCaller for 400, both are accepted. Some providers respond with 503 when their API is turned off.
+func DefaultStatusErrors() map[int][]error {
+	return map[int][]error{
+		http.StatusBadRequest:          {common.ErrBadRequest, common.ErrCaller},
+		http.StatusUnauthorized:        {common.ErrAccessToken},
+		http.StatusForbidden:           {common.ErrForbidden},
+		http.StatusTooManyRequests:     {common.ErrLimitExceeded, common.ErrRetryable},
+		http.StatusInternalServerError: {common.ErrServer},
+		http.StatusServiceUnavailable:  {common.ErrServer, common.ErrApiDisabled},
+	}
+}
+
+// Errors checks that failed responses are reported with the documented errors.
+// For every status a server responds with that status and Body, the Call must fail with a matching error.
+type Errors[C any] struct {
+	// Call is the connector method to invoke.
+	Call Call[C]
+	// Body is the response of the failing server. Defaults to an empty JSON object.
+	Body string
+	// ContentType of the Body. Defaults to application/json.
+	ContentType string
+	// Statuses to check. Defaults to DefaultStatusErrors.
+	Statuses map[int][]error
+}
+
+// ReadErrors checks errors returned by Read with the given parameters.
+func ReadErrors[C any](params common.ReadParams) *Errors[C] {
+	return &Errors[C]{
+		Call: func(t *testing.T, conn C) error {
+			_, err := asInterface[C, connectors.ReadConnector](t, conn).Read(t.Context(), params)
+
+			return err
+		},
+	}
+}
+
+// WriteErrors checks errors returned by Write with the given parameters.
+func WriteErrors[C any](params common.WriteParams) *Errors[C] {
+	return &Errors[C]{
+		Call: func(t *testing.T, conn C) error {
+			_, err := asInterface[C, connectors.WriteConnector](t, conn).Write(t.Context(), params)
+
+			return err
+		},
+	}
+}
+
+// Run calls the connector against a failing server for every status.
+func (e Errors[C]) Run(t *testing.T, builder Builder[C]) {
+	t.Helper()
+
+	statuses := e.Statuses
+	if statuses == nil {
+		statuses = DefaultStatusErrors()
+	}
+
+	body := e.Body
+	if body == "" {
+		body = "{}"
+	}
+
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	for status, expected := range statuses {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server := mockserver.Fixed{
+				Setup:  mockserver.Header("Content-Type", contentType),
+				Always: mockserver.ResponseString(status, body),
+			}.Server()
+			defer server.Close()
+
+			conn, err := builder(server.URL)
+			if err != nil {
+				t.Fatalf("error in test while constructing connector %v", err)
+			}
+
+			err = e.Call(t, conn)
+			if err == nil {
+				t.Fatalf("status %v: expected error", status)
+			}
+
+			for _, sentinel := range expected {
+				if errors.Is(err, sentinel) {
+					return
+				}
+			}
+
+			t.Errorf("status %v: error %q wraps none of %v", status, err, expected)
+		})
+	}
+}
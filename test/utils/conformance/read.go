@@ -0,0 +1,162 @@
+package conformance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+)
+
+const defaultMaxPages = 20
+
+// Read describes a read that is followed page by page. Every page must satisfy:
+//   - Done is set if and only if NextPage is empty, and next page tokens never repeat;
+//   - Rows matches the number of returned rows;
+//   - Raw is populated for every row, and keys of Fields are lowercase;
+//   - modification time of every row is within Since and Until, when TimestampField is set.
+type Read struct {
+	// Name of the check, defaults to the object name.
+	Name string
+	// Input of the first page. Following pages reuse it with the NextPage token.
+	Input common.ReadParams
+	// TimestampField locates the modification time within ReadResultRow.Raw.
+	// Nested objects are separated by dots, ex: "properties.hs_lastmodifieddate".
+	// When empty Since and Until are not verified.
+	TimestampField string
+	// TimestampLayout is used to parse the modification time. Defaults to time.RFC3339.
+	TimestampLayout string
+	// MaxPages stops reading after this many pages. Defaults to 20.
+	MaxPages int
+}
+
+func (r Read) title() string {
+	if r.Name != "" {
+		return r.Name
+	}
+
+	return r.Input.ObjectName
+}
+
+// Run reads all pages, reporting every violated contract.
+func (r Read) Run(t *testing.T, conn connectors.ReadConnector) {
+	t.Helper()
+
+	maxPages := r.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	params := r.Input
+	tokens := make(map[common.NextPageToken]bool)
+
+	for page := 1; page <= maxPages; page++ {
+		result, err := conn.Read(t.Context(), params)
+		if err != nil {
+			t.Fatalf("page %v: read failed: %v", page, err)
+		}
+
+		if result == nil {
+			t.Fatalf("page %v: nil result without error", page)
+		}
+
+		r.checkPage(t, page, result)
+
+		if result.Done || result.NextPage == "" {
+			return
+		}
+
+		if tokens[result.NextPage] {
+			t.Fatalf("page %v: next page token %q was already returned", page, result.NextPage)
+		}
+
+		tokens[result.NextPage] = true
+		params.NextPage = result.NextPage
+	}
+}
+
+func (r Read) checkPage(t *testing.T, page int, result *common.ReadResult) {
+	t.Helper()
+
+	if result.Done && result.NextPage != "" {
+		t.Errorf("page %v: result is done, yet has next page %q", page, result.NextPage)
+	}
+
+	if !result.Done && result.NextPage == "" {
+		t.Errorf("page %v: result is not done, yet has no next page", page)
+	}
+
+	if result.Rows != int64(len(result.Data)) {
+		t.Errorf("page %v: rows count %v doesn't match %v returned rows", page, result.Rows, len(result.Data))
+	}
+
+	for index, row := range result.Data {
+		if row.Raw == nil {
+			t.Errorf("page %v, row %v: raw record is missing", page, index)
+		}
+
+		for key := range row.Fields {
+			if key != strings.ToLower(key) {
+				t.Errorf("page %v, row %v: field %q is not lowercase", page, index, key)
+			}
+		}
+
+		r.checkTimestamp(t, page, index, row)
+	}
+}
+
+func (r Read) checkTimestamp(t *testing.T, page, index int, row common.ReadResultRow) {
+	t.Helper()
+
+	if r.TimestampField == "" || (r.Input.Since.IsZero() && r.Input.Until.IsZero()) {
+		return
+	}
+
+	value, ok := lookup(row.Raw, strings.Split(r.TimestampField, "."))
+	if !ok {
+		t.Errorf("page %v, row %v: timestamp %q is missing", page, index, r.TimestampField)
+
+		return
+	}
+
+	layout := r.TimestampLayout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	timestamp, err := time.Parse(layout, value)
+	if err != nil {
+		t.Errorf("page %v, row %v: timestamp %q is invalid: %v", page, index, value, err)
+
+		return
+	}
+
+	if !r.Input.Since.IsZero() && timestamp.Before(r.Input.Since) {
+		t.Errorf("page %v, row %v: modified at %v, before since %v", page, index, timestamp, r.Input.Since)
+	}
+
+	if !r.Input.Until.IsZero() && timestamp.After(r.Input.Until) {
+		t.Errorf("page %v, row %v: modified at %v, after until %v", page, index, timestamp, r.Input.Until)
+	}
+}
+
+func lookup(record map[string]any, path []string) (string, bool) {
+	value, ok := record[path[0]]
+	if !ok {
+		return "", false
+	}
+
+	if len(path) == 1 {
+		text, ok := value.(string)
+
+		return text, ok
+	}
+
+	nested, ok := value.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	return lookup(nested, path[1:])
+}
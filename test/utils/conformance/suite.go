@@ -0,0 +1,94 @@
+// Package conformance verifies that connectors honour the contracts of connector interfaces.
+// Unlike testroutines, which compare output with expectations of each test,
+// the checks here apply to any connector and any response of the provider.
+package conformance
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amp-labs/connectors"
+)
+
+// Suite groups conformance checks of a connector.
+// Read and Write checks call the Server, which can be a mockserver recipe or a mockserver.Recording.
+// Error checks start their own servers, one per HTTP status.
+//
+// Example:
+//
+//	conformance.Suite[*Connector]{
+//		Server: mockserver.Fixed{...}.Server(),
+//		Reads:  []conformance.Read{{Input: common.ReadParams{ObjectName: "contacts", Fields: connectors.Fields("id")}}},
+//		Errors: conformance.ReadErrors(common.ReadParams{ObjectName: "contacts", Fields: connectors.Fields("id")}),
+//	}.Run(t, constructTestConnector)
+type Suite[C any] struct {
+	// Server answers Read and Write calls. It is closed when the suite is done.
+	Server *httptest.Server
+	// Reads are checked for pagination and row contracts.
+	Reads []Read
+	// Writes are checked for result contracts.
+	Writes []Write
+	// Errors describe how the connector must report failed responses.
+	Errors *Errors[C]
+}
+
+// Builder constructs the connector which calls the server at the given URL.
+type Builder[C any] func(serverURL string) (C, error)
+
+// Run executes every check of the suite as a subtest.
+func (s Suite[C]) Run(t *testing.T, builder Builder[C]) {
+	t.Helper()
+
+	if s.Server != nil {
+		t.Cleanup(s.Server.Close)
+	}
+
+	for _, read := range s.Reads {
+		t.Run("Read "+read.title(), func(t *testing.T) {
+			read.Run(t, asInterface[C, connectors.ReadConnector](t, s.build(t, builder)))
+		})
+	}
+
+	for _, write := range s.Writes {
+		t.Run("Write "+write.title(), func(t *testing.T) {
+			write.Run(t, asInterface[C, connectors.WriteConnector](t, s.build(t, builder)))
+		})
+	}
+
+	if s.Errors != nil {
+		t.Run("Errors", func(t *testing.T) {
+			s.Errors.Run(t, builder)
+		})
+	}
+}
+
+func (s Suite[C]) build(t *testing.T, builder Builder[C]) C {
+	t.Helper()
+
+	if s.Server == nil {
+		t.Fatal("suite server is required for reads and writes")
+	}
+
+	conn, err := builder(s.Server.URL)
+	if err != nil {
+		t.Fatalf("error in test while constructing connector %v", err)
+	}
+
+	return conn
+}
+
+func asInterface[C any, I any](t *testing.T, conn C) I {
+	t.Helper()
+
+	value, ok := any(conn).(I)
+	if !ok {
+		var empty I
+
+		t.Fatalf("connector %T doesn't implement %T", conn, &empty)
+	}
+
+	return value
+}
+
+// Call invokes a connector method whose error output is checked.
+type Call[C any] func(t *testing.T, conn C) error
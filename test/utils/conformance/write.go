@@ -0,0 +1,57 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+)
+
+// Write describes a write whose result must satisfy:
+//   - a successful create, which is a write without RecordId, returns the identifier of the new record;
+//   - a successful update returns the identifier of the updated record, if any;
+//   - a failed write, which is not reported as error, carries the reasons of failure.
+type Write struct {
+	// Name of the check, defaults to the object name.
+	Name string
+	// Input passed to Write.
+	Input common.WriteParams
+}
+
+func (w Write) title() string {
+	if w.Name != "" {
+		return w.Name
+	}
+
+	return w.Input.ObjectName
+}
+
+// Run performs the write, reporting every violated contract.
+func (w Write) Run(t *testing.T, conn connectors.WriteConnector) {
+	t.Helper()
+
+	result, err := conn.Write(t.Context(), w.Input)
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	if result == nil {
+		t.Fatal("nil result without error")
+	}
+
+	if !result.Success {
+		if len(result.Errors) == 0 {
+			t.Error("unsuccessful write has no errors")
+		}
+
+		return
+	}
+
+	if w.Input.RecordId == "" && result.RecordId == "" {
+		t.Error("create didn't return the record id")
+	}
+
+	if w.Input.RecordId != "" && result.RecordId != "" && result.RecordId != w.Input.RecordId {
+		t.Errorf("update of record %q returned record %q", w.Input.RecordId, result.RecordId)
+	}
+}
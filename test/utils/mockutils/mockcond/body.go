@@ -37,6 +37,22 @@ func Body(expected string) Check {
 	}
 }
 
+// BodyContains returns a check expecting body to include the text.
+// Useful when the rest of the body is not deterministic, ex: it embeds an encoded archive.
+func BodyContains(text string) Check {
+	return func(w http.ResponseWriter, r *http.Request) bool {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return false
+		}
+
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		return strings.Contains(string(body), text)
+	}
+}
+
 func jsonBodyMatch(actual []byte, expected string) bool {
 	first := make(map[string]any)
 	if err := json.Unmarshal(actual, &first); err != nil {
@@ -0,0 +1,50 @@
+package mockcond
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// MultipartPart returns a check expecting multipart/form-data body to have a part
+// with the given form name, whose content matches the template.
+// Templates are compared the same way as in Body, so JSON parts are matched ignoring formatting.
+func MultipartPart(name, expected string) Check {
+	return func(w http.ResponseWriter, r *http.Request) bool {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return false
+		}
+
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+			return false
+		}
+
+		reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return false
+			}
+
+			if part.FormName() != name {
+				continue
+			}
+
+			content, err := io.ReadAll(part)
+			if err != nil {
+				return false
+			}
+
+			return textBodyMatch(content, expected) || jsonBodyMatch(content, expected)
+		}
+	}
+}
@@ -22,6 +22,11 @@ type Conditional struct {
 
 // Server creates mock server that will produce different response based on conditionals.
 func (re Conditional) Server() *httptest.Server {
+	return NewServer(re.Handler())
+}
+
+// Handler serves requests the same way the mock server does.
+func (re Conditional) Handler() http.HandlerFunc {
 	// Reactive server is a simpler version of a Switch with one possible successful route.
 	// This acts as syntactic sugar.
 	return Switch{
@@ -31,5 +36,5 @@ func (re Conditional) Server() *httptest.Server {
 			Then: re.Then,
 		}},
 		Default: re.Else,
-	}.Server()
+	}.Handler()
 }
@@ -0,0 +1,34 @@
+package mockserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/amp-labs/connectors/common/faults"
+)
+
+// Recipe is any server recipe of this package.
+type Recipe interface {
+	Server() *httptest.Server
+	Handler() http.HandlerFunc
+}
+
+// Faulty is a server recipe that injects faults into responses of another recipe.
+// Faults such as rate limits, server errors or truncated bodies are decided by the profile,
+// see the faults package for deterministic profiles.
+type Faulty struct {
+	// Profile decides which requests fail.
+	Profile faults.Profile
+	// Upstream serves requests that pass.
+	Upstream Recipe
+}
+
+// Server creates mock server.
+func (f Faulty) Server() *httptest.Server {
+	return NewServer(f.Handler())
+}
+
+// Handler serves requests the same way the mock server does.
+func (f Faulty) Handler() http.HandlerFunc {
+	return faults.Handler(f.Profile, f.Upstream.Handler()).ServeHTTP
+}
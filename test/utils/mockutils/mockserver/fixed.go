@@ -17,11 +17,16 @@ type Fixed struct {
 
 // Server creates mock server.
 func (f Fixed) Server() *httptest.Server {
+	return NewServer(f.Handler())
+}
+
+// Handler serves requests the same way the mock server does.
+func (f Fixed) Handler() http.HandlerFunc {
 	return Conditional{
 		Setup: f.Setup,
 		If: mockcond.Check(func(w http.ResponseWriter, r *http.Request) bool {
 			return true
 		}),
 		Then: f.Always,
-	}.Server()
+	}.Handler()
 }
@@ -0,0 +1,138 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// Recording is a server recipe that replays captured provider traffic.
+// Each request is answered by the first unused interaction with the same method, path and query parameters.
+// Repeated requests, ex: pages of the same list, are answered by interactions in the order of the recording.
+// Requests without a matching interaction receive 501 Not Implemented.
+type Recording struct {
+	// Setup is optional handler, where common http.ResponseWrite configuration takes place.
+	Setup http.HandlerFunc
+	// Interactions in the order they were captured.
+	Interactions []Interaction
+}
+
+// Interaction is a single request with its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Query parameters that the request must have. Parameters not listed here are ignored.
+	Query map[string]string `json:"query,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is stored as is when it is JSON, otherwise as a JSON string.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// LoadRecording reads a JSON array of interactions.
+//
+// Example:
+//
+//	[{
+//		"request": {"method": "GET", "path": "/v1/contacts", "query": {"limit": "100"}},
+//		"response": {"status": 200, "headers": {"Content-Type": "application/json"}, "body": {"data": []}}
+//	}]
+func LoadRecording(data []byte) (Recording, error) {
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return Recording{}, fmt.Errorf("invalid recording: %w", err)
+	}
+
+	return Recording{Interactions: interactions}, nil
+}
+
+// Server creates mock server.
+func (r Recording) Server() *httptest.Server {
+	var (
+		mutex sync.Mutex
+		used  = make([]bool, len(r.Interactions))
+	)
+
+	return NewServer(func(w http.ResponseWriter, req *http.Request) {
+		if r.Setup != nil {
+			r.Setup(w, req)
+		}
+
+		mutex.Lock()
+		index := r.find(req, used)
+		if index != -1 {
+			used[index] = true
+		}
+		mutex.Unlock()
+
+		if index == -1 {
+			w.WriteHeader(http.StatusNotImplemented)
+			_, _ = fmt.Fprintf(w, "no recorded interaction for %v %v", req.Method, req.URL.RequestURI())
+
+			return
+		}
+
+		r.Interactions[index].Response.write(w)
+	})
+}
+
+func (r Recording) find(req *http.Request, used []bool) int {
+	for index, interaction := range r.Interactions {
+		if !used[index] && interaction.Request.matches(req) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (r RecordedRequest) matches(req *http.Request) bool {
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+
+	if r.Path != req.URL.Path {
+		return false
+	}
+
+	query := req.URL.Query()
+	for name, value := range r.Query {
+		if query.Get(name) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r RecordedResponse) write(w http.ResponseWriter) {
+	for name, value := range r.Headers {
+		w.Header().Set(name, value)
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	body := []byte(r.Body)
+
+	// Non JSON bodies are recorded as strings.
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -21,7 +21,12 @@ type Switch struct {
 
 // Server creates mock server that will produce different response based on conditionals.
 func (c Switch) Server() *httptest.Server {
-	return NewServer(func(w http.ResponseWriter, r *http.Request) {
+	return NewServer(c.Handler())
+}
+
+// Handler serves requests the same way the mock server does.
+func (c Switch) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		// Common setup is optional.
 		if c.Setup != nil {
 			c.Setup(w, r)
@@ -46,7 +51,7 @@ func (c Switch) Server() *httptest.Server {
 		// Default fail behaviour.
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte(`{"error": {"message": "condition failed"}}`))
-	})
+	}
 }
 
 // Case is one possible route a mock server can take if condition is satisfied.
@@ -0,0 +1,58 @@
+package testroutines
+
+import (
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+)
+
+type (
+	ListAssociationsType = TestCase[common.ListAssociationsParams, *common.ListAssociationsResult]
+	// ListAssociations is a test suite useful for testing connectors.AssociationConnector interface.
+	ListAssociations ListAssociationsType
+
+	CreateAssociationType = TestCase[common.AssociationDescriptor, *common.WriteResult]
+	// CreateAssociation is a test suite useful for testing connectors.AssociationConnector interface.
+	CreateAssociation CreateAssociationType
+
+	DeleteAssociationType = TestCase[common.AssociationDescriptor, *common.DeleteResult]
+	// DeleteAssociation is a test suite useful for testing connectors.AssociationConnector interface.
+	DeleteAssociation DeleteAssociationType
+)
+
+// Run provides a procedure to test connectors.AssociationConnector ListAssociations method.
+func (m ListAssociations) Run(t *testing.T, builder ConnectorBuilder[connectors.AssociationConnector]) {
+	t.Helper()
+	t.Cleanup(func() {
+		ListAssociationsType(m).Close()
+	})
+
+	conn := builder.Build(t, m.Name)
+	output, err := conn.ListAssociations(t.Context(), m.Input)
+	ListAssociationsType(m).Validate(t, err, output)
+}
+
+// Run provides a procedure to test connectors.AssociationConnector CreateAssociation method.
+func (m CreateAssociation) Run(t *testing.T, builder ConnectorBuilder[connectors.AssociationConnector]) {
+	t.Helper()
+	t.Cleanup(func() {
+		CreateAssociationType(m).Close()
+	})
+
+	conn := builder.Build(t, m.Name)
+	output, err := conn.CreateAssociation(t.Context(), m.Input)
+	CreateAssociationType(m).Validate(t, err, output)
+}
+
+// Run provides a procedure to test connectors.AssociationConnector DeleteAssociation method.
+func (m DeleteAssociation) Run(t *testing.T, builder ConnectorBuilder[connectors.AssociationConnector]) {
+	t.Helper()
+	t.Cleanup(func() {
+		DeleteAssociationType(m).Close()
+	})
+
+	conn := builder.Build(t, m.Name)
+	output, err := conn.DeleteAssociation(t.Context(), m.Input)
+	DeleteAssociationType(m).Validate(t, err, output)
+}
@@ -0,0 +1,26 @@
+package testroutines
+
+import (
+	"testing"
+
+	"github.com/amp-labs/connectors"
+	"github.com/amp-labs/connectors/common"
+)
+
+type (
+	CompositeWriteType = TestCase[*common.CompositeWriteParams, *common.CompositeWriteResult]
+	// CompositeWrite is a test suite useful for testing connectors.CompositeWriteConnector interface.
+	CompositeWrite CompositeWriteType
+)
+
+// Run provides a procedure to test connectors.CompositeWriteConnector
+func (m CompositeWrite) Run(t *testing.T, builder ConnectorBuilder[connectors.CompositeWriteConnector]) {
+	t.Helper()
+	t.Cleanup(func() {
+		CompositeWriteType(m).Close()
+	})
+
+	conn := builder.Build(t, m.Name)
+	output, err := conn.CompositeWrite(t.Context(), m.Input)
+	CompositeWriteType(m).Validate(t, err, output)
+}